@@ -3,7 +3,13 @@ package api
 import (
   "encoding/json"
   "net/http"
+  "time"
+
+  "github.com/google/uuid"
   "github.com/gorilla/mux"
+
+  "github.com/saint/babel-protocol/backend/internal/metrics"
+  "github.com/saint/babel-protocol/backend/internal/storage"
 )
 
 // HandleAPIRequests handles all API requests
@@ -20,14 +26,43 @@ func GetNextPost(w http.ResponseWriter, r *http.Request) {
   // Logic to retrieve the next post and serve it to the user
 }
 
+// voteRequest is the body of a like/dislike request.
+type voteRequest struct {
+  UserID string `json:"user_id"`
+}
+
+func recordVote(w http.ResponseWriter, r *http.Request, voteType string) {
+  postID := mux.Vars(r)["id"]
+
+  var req voteRequest
+  if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+    http.Error(w, err.Error(), http.StatusBadRequest)
+    return
+  }
+
+  vote := &storage.Vote{
+    ID:        uuid.New().String(),
+    PostID:    postID,
+    UserID:    req.UserID,
+    Type:      voteType,
+    CreatedAt: time.Now(),
+  }
+  if err := storage.RecordVoteAndCommit(storage.Default(), vote); err != nil {
+    http.Error(w, err.Error(), http.StatusInternalServerError)
+    return
+  }
+
+  metrics.LikesTotal.WithLabelValues(voteType).Inc()
+}
+
 // LikePost handles the user liking a post
 func LikePost(w http.ResponseWriter, r *http.Request) {
-  // Logic to handle user liking a post
+  recordVote(w, r, "like")
 }
 
 // DislikePost handles the user disliking a post
 func DislikePost(w http.ResponseWriter, r *http.Request) {
-  // Logic to handle user disliking a post
+  recordVote(w, r, "dislike")
 }
 
 // LabelMisinformation allows users to label a post as misinformation
@@ -35,7 +70,33 @@ func LabelMisinformation(w http.ResponseWriter, r *http.Request) {
   // Logic to allow users to label a post as misinformation
 }
 
+// addCommentRequest is the body of an AddComment request.
+type addCommentRequest struct {
+  AuthorID string `json:"author_id"`
+  Text     string `json:"text"`
+}
+
 // AddComment handles user adding a comment to a post
 func AddComment(w http.ResponseWriter, r *http.Request) {
-  // Logic to handle user adding a comment to a post
+  postID := mux.Vars(r)["id"]
+
+  var req addCommentRequest
+  if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+    http.Error(w, err.Error(), http.StatusBadRequest)
+    return
+  }
+
+  comment := &storage.Comment{
+    ID:        uuid.New().String(),
+    PostID:    postID,
+    AuthorID:  req.AuthorID,
+    Text:      req.Text,
+    CreatedAt: time.Now(),
+  }
+  if err := storage.PersistCommentAndCommit(storage.Default(), comment); err != nil {
+    http.Error(w, err.Error(), http.StatusInternalServerError)
+    return
+  }
+
+  metrics.CommentsCreatedTotal.Inc()
 }
@@ -3,24 +3,94 @@
 package api
 
 import (
-    "github.com/gin-gonic/gin"
     "net/http"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+    "golang.org/x/crypto/bcrypt"
+
+    "github.com/saint/babel-protocol/backend/internal/metrics"
+    "github.com/saint/babel-protocol/backend/internal/storage"
 )
 
+// registerRequest is the body of a Register request.
+type registerRequest struct {
+    Username string `json:"username" binding:"required"`
+    Password string `json:"password" binding:"required"`
+    Bio      string `json:"bio"`
+}
+
 // Register handles user registration
 func Register(c *gin.Context) {
-    // Implement user registration logic
+    var req registerRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    user := &storage.User{
+        ID:           uuid.New().String(),
+        Username:     req.Username,
+        PasswordHash: string(hash),
+        Bio:          req.Bio,
+        CreatedAt:    time.Now(),
+    }
+    if err := storage.UpsertUserAndCommit(storage.Default(), user); err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    metrics.UsersRegisteredTotal.Inc()
     c.JSON(http.StatusOK, gin.H{"message": "User registration endpoint"})
 }
 
+// loginRequest is the body of a Login request.
+type loginRequest struct {
+    Username string `json:"username" binding:"required"`
+    Password string `json:"password" binding:"required"`
+}
+
 // Login handles user login
 func Login(c *gin.Context) {
-    // Implement user login logic
+    var req loginRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    user, err := storage.FindUserAndRollback(storage.Default(), req.Username)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    if user == nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)) != nil {
+        c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid username or password"})
+        return
+    }
+
     c.JSON(http.StatusOK, gin.H{"message": "User login endpoint"})
 }
 
 // Profile handles user profile retrieval
 func Profile(c *gin.Context) {
-    // Implement user profile retrieval logic
-    c.JSON(http.StatusOK, gin.H{"message": "User profile endpoint"})
+    username := c.Param("username")
+
+    user, err := storage.FindUserAndRollback(storage.Default(), username)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    if user == nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"username": user.Username, "bio": user.Bio})
 }
@@ -3,19 +3,60 @@
 package api
 
 import (
-    "github.com/gin-gonic/gin"
-    "net/http"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/recommend"
 )
 
-// CollaborativeFiltering handles recommending content based on collaborative filtering
-func CollaborativeFiltering(c *gin.Context) {
-    // Implement collaborative filtering logic
-    c.JSON(http.StatusOK, gin.H{"message": "Collaborative filtering endpoint"})
+// defaultRecommendationCount is how many items CollaborativeFiltering and
+// ItemBasedCollabFiltering return when the caller doesn't specify count.
+const defaultRecommendationCount = 20
+
+// CollaborativeFiltering recommends content for user_id via item-based
+// collaborative filtering - see ItemBasedCollabFiltering, which this
+// delegates to; there's no separate user-based variant implemented.
+func CollaborativeFiltering(recommender *recommend.Recommender) gin.HandlerFunc {
+	return ItemBasedCollabFiltering(recommender)
+}
+
+// ItemBasedCollabFiltering ranks unseen content for user_id by predicted
+// rating (Recommender.Recommend), falling back to trending content for
+// users below the recommender's interaction floor.
+func ItemBasedCollabFiltering(recommender *recommend.Recommender) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.Query("user_id")
+		if userID == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "user_id is required"})
+			return
+		}
+		count := queryInt(c, "count", defaultRecommendationCount)
+
+		recommendations, err := recommender.Recommend(c.Request.Context(), userID, count)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"user_id":         userID,
+			"recommendations": recommendations,
+		})
+	}
 }
 
-// ItemBasedCollabFiltering handles recommending content based on item-based collaborative filtering
-func ItemBasedCollabFiltering(c *gin.Context) {
-    // Implement item-based collaborative filtering logic
-    // This can be within the scope of collaborative filtering, as it's a variant of collaborative filtering
-    c.JSON(http.StatusOK, gin.H{"message": "Item-based collaborative filtering endpoint"})
+// queryInt parses the named query parameter as an int, returning
+// defaultValue if it's absent or malformed.
+func queryInt(c *gin.Context, name string, defaultValue int) int {
+	value := c.Query(name)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
 }
@@ -3,20 +3,59 @@
 package api
 
 import (
-    "github.com/gin-gonic/gin"
     "net/http"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+
+    "github.com/saint/babel-protocol/backend/internal/metrics"
+    "github.com/saint/babel-protocol/backend/internal/storage"
 )
 
+// createPostRequest is the body of a CreatePost request.
+type createPostRequest struct {
+    Text string `json:"text" binding:"required"`
+}
+
 // CreatePost handles creating a new post
 func CreatePost(c *gin.Context) {
-    // Implement logic to create a new post
-    c.JSON(http.StatusOK, gin.H{"message": "Create post endpoint"})
+    var req createPostRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    post := &storage.Post{
+        ID:        uuid.New().String(),
+        AuthorID:  c.GetString("user_id"),
+        Text:      req.Text,
+        CreatedAt: time.Now(),
+    }
+    if err := storage.PersistPostAndCommit(storage.Default(), post); err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    metrics.PostsCreatedTotal.Inc()
+    c.JSON(http.StatusOK, gin.H{"message": "Create post endpoint", "id": post.ID})
 }
 
 // GetPost handles retrieving a post by ID
 func GetPost(c *gin.Context) {
-    // Implement logic to retrieve a post by ID
-    c.JSON(http.StatusOK, gin.H{"message": "Get post endpoint"})
+    id := c.Param("id")
+
+    post, err := storage.FindPostAndRollback(storage.Default(), id)
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+    if post == nil {
+        c.JSON(http.StatusNotFound, gin.H{"error": "post not found"})
+        return
+    }
+
+    c.JSON(http.StatusOK, post)
 }
 
 // UpdatePost handles updating an existing post
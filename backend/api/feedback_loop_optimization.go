@@ -3,18 +3,104 @@
 package api
 
 import (
-    "github.com/gin-gonic/gin"
-    "net/http"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/loaders"
 )
 
-// FeedbackLoopOptimization handles optimizing content recommendations based on user feedback
-func FeedbackLoopOptimization(c *gin.Context) {
-    // Implement feedback loop optimization logic
-    c.JSON(http.StatusOK, gin.H{"message": "Feedback loop optimization endpoint"})
+// feedbackLoopExperiment is the standing experiment FeedbackLoopOptimization
+// reports engagement reward against - the ranking/recommendation variants
+// it compares are seeded out-of-band via CreateExperiment.
+const feedbackLoopExperiment = "feedback_loop_ranking"
+
+// FeedbackLoopOptimization records a dwell-time-derived engagement reward
+// for content_id against the user's current feedback_loop_ranking arm,
+// folding it into that arm's bandit posterior.
+func FeedbackLoopOptimization(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		contentID := c.Query("content_id")
+		userID := c.Query("user_id")
+		dwellSeconds := queryFloat(c, "dwell_seconds", 0)
+
+		content, err := loaders.FromContext(c).ContentByID.Load(contentID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if content == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "content not found"})
+			return
+		}
+
+		// Normalize dwell time into a [0, 1] engagement reward: anything
+		// past 30s of attention counts as a full success.
+		reward := dwellSeconds / 30
+		if reward > 1 {
+			reward = 1
+		}
+
+		if err := repo.RecordExperimentEvent(feedbackLoopExperiment, userID, reward); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := repo.RecordMetric(&models.AlgorithmMetric{
+			AlgorithmName: "experiment:" + feedbackLoopExperiment,
+			MetricName:    "reward",
+			Value:         reward,
+			Timestamp:     time.Now(),
+			Metadata:      map[string]interface{}{"user_id": userID, "content_id": content.ID},
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"content_id": content.ID, "user_id": userID, "reward": reward})
+	}
+}
+
+// ABTesting assigns user_id an arm of feedback_loop_ranking via the
+// Thompson-sampling bandit (sticky across repeat calls).
+func ABTesting(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.Query("user_id")
+
+		user, err := loaders.FromContext(c).UsersByID.Load(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+
+		arm, err := repo.AssignArm(feedbackLoopExperiment, user.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"user_id": user.ID, "arm_id": arm.ID, "arm_name": arm.Name})
+	}
 }
 
-// ABTesting handles A/B testing for content recommendations
-func ABTesting(c *gin.Context) {
-    // Implement A/B testing logic
-    c.JSON(http.StatusOK, gin.H{"message": "A/B testing endpoint"})
+// queryFloat parses the named query parameter as a float64, returning
+// defaultValue if it's absent or malformed.
+func queryFloat(c *gin.Context, name string, defaultValue float64) float64 {
+	value := c.Query(name)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
 }
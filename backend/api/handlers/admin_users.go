@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/user"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+// overrideScoresRequest is the body PATCH /api/admin/users/:id/scores
+// expects. Scores only needs the fields the moderator wants to change -
+// Manager.OverrideScores ignores anything it doesn't recognize.
+type overrideScoresRequest struct {
+	Reason string             `json:"reason" binding:"required"`
+	Scores map[string]float64 `json:"scores" binding:"required"`
+}
+
+// overrideAuthenticityRequest is the body PATCH
+// /api/admin/users/:id/authenticity expects.
+type overrideAuthenticityRequest struct {
+	Reason            string  `json:"reason" binding:"required"`
+	AuthenticityScore float64 `json:"authenticity_score"`
+	VerificationLevel int     `json:"verification_level"`
+}
+
+// GetUserScoresHandler returns userID's cached and persisted scores for a
+// moderator reviewing an account.
+func GetUserScoresHandler(userMgr *user.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		snapshot, err := userMgr.GetScores(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"scores": snapshot})
+	}
+}
+
+// PatchUserScoresHandler applies a moderator's manual override to a
+// user's truth/evidence/engagement/community scores. The moderator ID
+// comes from the caller's own authenticated identity, not the request
+// body, so the audit trail can't be forged.
+func PatchUserScoresHandler(userMgr *user.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req overrideScoresRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		moderatorID := c.GetString("user_id")
+		if err := userMgr.OverrideScores(c.Request.Context(), c.Param("id"), moderatorID, req.Reason, req.Scores); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "overridden"})
+	}
+}
+
+// PatchUserAuthenticityHandler applies a moderator's manual override to a
+// user's authenticity score and verification level.
+func PatchUserAuthenticityHandler(userMgr *user.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req overrideAuthenticityRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		moderatorID := c.GetString("user_id")
+		if err := userMgr.OverrideAuthenticity(c.Request.Context(), c.Param("id"), moderatorID, req.Reason, req.AuthenticityScore, req.VerificationLevel); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "overridden"})
+	}
+}
+
+// RecomputeUserScoresHandler forces UpdateUserScores' work for a user,
+// bypassing the cache-freshness and "too soon" gates - e.g. right after
+// an override, or while chasing a live abuse incident.
+func RecomputeUserScoresHandler(userMgr *user.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := userMgr.Recompute(c.Request.Context(), c.Param("id")); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "recomputed"})
+	}
+}
+
+// UserScoreAuditHandler returns a paginated history of a user's score
+// deltas, merging algorithm-service metrics with moderator overrides.
+func UserScoreAuditHandler(userMgr *user.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultAuditLimit)))
+		if err != nil || limit <= 0 || limit > maxAuditLimit {
+			limit = defaultAuditLimit
+		}
+		offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+
+		entries, err := userMgr.Audit(c.Param("id"), limit, offset)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"audit": entries})
+	}
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/auth"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/user"
+)
+
+// loginRequest is the body POST /api/auth/login expects. Username/
+// Password are used by the local provider, Code by the OIDC provider's
+// authorization code exchange; the standalone JWT provider doesn't accept
+// logins at all (its tokens are issued by whatever system owns it) and
+// returns auth.ErrUnsupported.
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// LoginHandler authenticates loginRequest against authenticator, ensures
+// a local user record exists for the resulting Identity, records the
+// login in SessionData (LoginCount, LastIPAddress, DeviceInfo), and
+// returns a bearer token for subsequent requests.
+func LoginHandler(authenticator auth.Authenticator, repo db.Repository, userMgr *user.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req loginRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		identity, err := authenticator.Authenticate(c.Request.Context(), auth.Credentials{
+			Username: req.Username,
+			Password: req.Password,
+			Code:     req.Code,
+		})
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "authentication failed"})
+			return
+		}
+
+		u, err := repo.GetUser(identity.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if u == nil {
+			u = &models.User{
+				ID:         identity.UserID,
+				Username:   identity.Username,
+				CreatedAt:  time.Now(),
+				LastActive: time.Now(),
+			}
+			if err := repo.CreateUser(u); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+
+		session := u.SessionData
+		if session == nil {
+			session = &models.UserSession{}
+		}
+		session.LastLogin = time.Now()
+		session.LoginCount++
+		session.LastIPAddress = c.ClientIP()
+		session.DeviceInfo = c.GetHeader("User-Agent")
+		u.SessionData = session
+		u.LastActive = time.Now()
+		if err := repo.UpdateUser(u); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if identity.VerificationLevel > 0 && userMgr != nil {
+			_ = userMgr.RecordVerification(c.Request.Context(), identity.UserID, "oidc", identity.VerificationLevel, map[string]interface{}{
+				"username": identity.Username,
+			})
+		}
+
+		token, err := authenticator.IssueToken(identity)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"token": token, "user_id": identity.UserID})
+	}
+}
+
+// registerRequest is the body POST /api/auth/register expects.
+type registerRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RegisterHandler creates a new local username/password credential. It's
+// only meaningful when the active provider is *auth.LocalProvider, since
+// JWT/OIDC sessions are established by an external identity system
+// instead.
+func RegisterHandler(authenticator auth.Authenticator, repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		local, ok := authenticator.(*auth.LocalProvider)
+		if !ok {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "registration is not available with the active auth provider"})
+			return
+		}
+
+		var req registerRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		userID := uuid.New().String()
+		if err := local.Register(userID, req.Username, req.Password); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := repo.CreateUser(&models.User{
+			ID:         userID,
+			Username:   req.Username,
+			CreatedAt:  time.Now(),
+			LastActive: time.Now(),
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"user_id": userID})
+	}
+}
+
+// LogoutHandler is stateless on this server - bearer tokens are
+// self-contained JWTs with no server-side session to revoke, so logout is
+// purely a client-side "discard the token" operation. The endpoint exists
+// so clients have a consistent place to call, and so a future token-
+// revocation list has somewhere to hook in.
+func LogoutHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "logged_out"})
+	}
+}
+
+// ProfileHandler returns the authenticated caller's own profile, reading
+// user_id from the Identity RequireAuth injected rather than a path
+// parameter - the whole point being a caller can't ask for anyone else's.
+func ProfileHandler(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.GetString("user_id")
+
+		u, err := repo.GetUser(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if u == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"user": u})
+	}
+}
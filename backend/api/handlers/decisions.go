@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/decisions"
+)
+
+// decisionsService gates a handler on the decision feed being available -
+// only when the active backend satisfies decisions.Store was a Service
+// built for it in main.go, the same gate moderationService uses for
+// moderation.Store.
+func decisionsService(c *gin.Context, service *decisions.Service) (*decisions.Service, bool) {
+	if service == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "the decision feed is not available on this database backend"})
+		return nil, false
+	}
+	return service, true
+}
+
+// createDecisionRequest is the body POST /api/decisions expects. TTLSeconds
+// is required since a Decision with no expiry would never be reaped.
+type createDecisionRequest struct {
+	Scope      string `json:"scope" binding:"required"`
+	Value      string `json:"value" binding:"required"`
+	Action     string `json:"action" binding:"required"`
+	Reason     string `json:"reason"`
+	TTLSeconds int64  `json:"ttl_seconds" binding:"required"`
+}
+
+// CreateDecisionHandler lets an operator push a ban/captcha/throttle
+// decision onto the feed by hand, the same entry point moderation tooling
+// and user.Manager reach through the DecisionFeeder interface.
+func CreateDecisionHandler(service *decisions.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		svc, ok := decisionsService(c, service)
+		if !ok {
+			return
+		}
+
+		var req createDecisionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		d, err := svc.Feed(req.Scope, req.Value, req.Action, req.Reason, time.Duration(req.TTLSeconds)*time.Second)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"decision": d})
+	}
+}
+
+// ListDecisionsHandler returns every decision currently in the feed,
+// expired or not.
+func ListDecisionsHandler(service *decisions.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		svc, ok := decisionsService(c, service)
+		if !ok {
+			return
+		}
+
+		list, err := svc.ListDecisions()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"decisions": list})
+	}
+}
+
+// DeleteDecisionHandler lifts a decision early, e.g. once an operator
+// confirms a ban was a false positive.
+func DeleteDecisionHandler(service *decisions.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		svc, ok := decisionsService(c, service)
+		if !ok {
+			return
+		}
+
+		id := c.Param("id")
+		if err := svc.DeleteDecision(id); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"status": "deleted"})
+	}
+}
+
+// CaptchaChallengeHandler is the stub challenge endpoint
+// RateLimitMiddleware points callers at when an active ActionCaptcha
+// decision is in effect. This API has no page to render a captcha widget
+// on; a real deployment would swap this for a proxied challenge (e.g.
+// hCaptcha/Turnstile) and verify its response token here.
+func CaptchaChallengeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "captcha challenges are not implemented on this deployment"})
+	}
+}
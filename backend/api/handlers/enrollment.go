@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+	"github.com/saint/babel-protocol/backend/internal/content"
+)
+
+// EnrollmentStatusHandler reports whether the API server has completed
+// mTLS bootstrap-enrollment with the algorithm service, along with the
+// client cert's fingerprint and expiry.
+func EnrollmentStatusHandler(tlsCfg config.TLSConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, err := content.GetEnrollmentStatus(tlsCfg)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, status)
+	}
+}
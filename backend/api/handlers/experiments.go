@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// assignArmRequest is the body POST /experiments/:name/assign expects.
+type assignArmRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// AssignArmHandler returns the arm the named experiment's Thompson-sampling
+// bandit has assigned (or already assigned) the requesting user.
+func AssignArmHandler(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		var req assignArmRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		arm, err := repo.AssignArm(name, req.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"experiment": name,
+			"user_id":    req.UserID,
+			"arm_id":     arm.ID,
+			"arm_name":   arm.Name,
+		})
+	}
+}
+
+// recordExperimentEventRequest is the body POST /experiments/:name/events
+// expects. Reward is clamped to [0, 1] by the bandit package.
+type recordExperimentEventRequest struct {
+	UserID string  `json:"user_id" binding:"required"`
+	Reward float64 `json:"reward"`
+}
+
+// RecordExperimentEventHandler folds a reward signal into the posterior
+// of the arm the user was assigned, and mirrors it into algorithm_metrics
+// so existing dashboards pick up experiment outcomes.
+func RecordExperimentEventHandler(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		var req recordExperimentEventRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := repo.RecordExperimentEvent(name, req.UserID, req.Reward); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := repo.RecordMetric(&models.AlgorithmMetric{
+			AlgorithmName: "experiment:" + name,
+			MetricName:    "reward",
+			Value:         req.Reward,
+			Timestamp:     time.Now(),
+			Metadata:      map[string]interface{}{"user_id": req.UserID},
+		}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"experiment": name, "user_id": req.UserID, "reward": req.Reward})
+	}
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/federation"
+	"github.com/saint/babel-protocol/backend/internal/loaders"
+)
+
+// federationStore resolves repo's federation.Store, if the active backend
+// supports one, and writes a 501 otherwise - the same optional-capability
+// gate VoteFinalityHandler uses for hcs.Store.
+func federationStore(c *gin.Context, repo db.Repository) (federation.Store, bool) {
+	store, ok := repo.(federation.Store)
+	if !ok {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "federation is not available on this database backend"})
+		return nil, false
+	}
+	return store, true
+}
+
+// addFollowerRequest is the body POST /federation/followers expects, sent
+// by a remote instance once it has decided to follow a local actor.
+type addFollowerRequest struct {
+	LocalActorID  string `json:"local_actor_id" binding:"required"`
+	RemoteActorID string `json:"remote_actor_id" binding:"required"`
+	RemoteInbox   string `json:"remote_inbox" binding:"required"`
+	SharedSecret  string `json:"shared_secret" binding:"required"`
+}
+
+// AddFollowerHandler registers a remote actor as a follower of a local
+// actor, so future FederateContent/FederateVote calls enqueue deliveries
+// to it.
+func AddFollowerHandler(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store, ok := federationStore(c, repo)
+		if !ok {
+			return
+		}
+
+		var req addFollowerRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		follower := federation.Follower{
+			ID:            uuid.New().String(),
+			LocalActorID:  req.LocalActorID,
+			RemoteActorID: req.RemoteActorID,
+			RemoteInbox:   req.RemoteInbox,
+			SharedSecret:  req.SharedSecret,
+		}
+		if err := store.AddFollower(follower); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, follower)
+	}
+}
+
+// addFollowingRequest is the body POST /federation/following expects,
+// sent when a local actor decides to follow a remote one. TrustWeight
+// scales the vote weight of activities replayed from RemoteActorID;
+// it defaults to 1.0 (full trust) when omitted.
+type addFollowingRequest struct {
+	LocalActorID  string  `json:"local_actor_id" binding:"required"`
+	RemoteActorID string  `json:"remote_actor_id" binding:"required"`
+	RemoteInbox   string  `json:"remote_inbox" binding:"required"`
+	SharedSecret  string  `json:"shared_secret" binding:"required"`
+	TrustWeight   float64 `json:"trust_weight"`
+}
+
+// AddFollowingHandler registers a remote actor as one a local actor
+// follows, so inbound activities claiming to be from it can be verified
+// and replayed.
+func AddFollowingHandler(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store, ok := federationStore(c, repo)
+		if !ok {
+			return
+		}
+
+		var req addFollowingRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		following := federation.Following{
+			ID:            uuid.New().String(),
+			LocalActorID:  req.LocalActorID,
+			RemoteActorID: req.RemoteActorID,
+			RemoteInbox:   req.RemoteInbox,
+			SharedSecret:  req.SharedSecret,
+			TrustWeight:   req.TrustWeight,
+		}
+		if err := store.AddFollowing(following); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, following)
+	}
+}
+
+// GetActorHandler returns the local user identified by :id as a minimal
+// actor document - just enough (ID, public key, inbox) for a remote
+// instance to address activities to it. PublicKey is purely identifying
+// here; it is never the key activities are signed or verified with - see
+// models.Activity.SignWith.
+func GetActorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		user, err := loaders.FromContext(c).UsersByID.Load(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "actor not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"id":         user.ID,
+			"username":   user.Username,
+			"public_key": user.PublicKey,
+			"inbox":      "/api/federation/inbox/" + user.ID,
+		})
+	}
+}
+
+// InboxHandler accepts a POSTed models.Activity addressed to the local
+// actor named by :actor_id and pushes it onto inbox for the Processor to
+// verify and apply asynchronously. It only does transport framing, so a
+// slow Processor never blocks the sender's connection.
+func InboxHandler(inbox *federation.HTTPInbox) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actorID := c.Param("actor_id")
+
+		var activity models.Activity
+		if err := c.ShouldBindJSON(&activity); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !inbox.Push(actorID, &activity) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "inbox is backlogged, retry later"})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"status": "accepted"})
+	}
+}
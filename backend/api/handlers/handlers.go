@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
 	"time"
 
@@ -8,12 +9,51 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/saint/babel-protocol/backend/api/models"
-	"github.com/saint/babel-protocol/backend/internal/db/sqlite"
+	"github.com/saint/babel-protocol/backend/internal/consensus/hcs"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/federation"
+	"github.com/saint/babel-protocol/backend/internal/loaders"
+	"github.com/saint/babel-protocol/backend/internal/virality"
 	"github.com/saint/babel-protocol/backend/internal/websocket"
 )
 
+// federateContent enqueues content for delivery to actorID's federation
+// followers, if federator is configured. A federator is nil when
+// cfg.Federation.Enabled is false or the active backend doesn't support
+// federation.Store, in which case this is a no-op - federation is an
+// enhancement on top of the local write, never a precondition for it. A
+// delivery error is logged, not surfaced to the caller, for the same
+// reason: the local write already succeeded.
+func federateContent(federator *federation.Federator, db db.Repository, content *models.Content) {
+	if federator == nil {
+		return
+	}
+	actor, err := db.GetUser(content.AuthorID)
+	if err != nil {
+		log.Printf("federation: loading actor %s for content %s: %v", content.AuthorID, content.ID, err)
+		return
+	}
+	if err := federator.FederateContent(content, actor); err != nil {
+		log.Printf("federation: announcing content %s: %v", content.ID, err)
+	}
+}
+
+func federateVote(federator *federation.Federator, db db.Repository, vote *models.Vote) {
+	if federator == nil {
+		return
+	}
+	actor, err := db.GetUser(vote.UserID)
+	if err != nil {
+		log.Printf("federation: loading actor %s for vote %s: %v", vote.UserID, vote.ID, err)
+		return
+	}
+	if err := federator.FederateVote(vote, actor); err != nil {
+		log.Printf("federation: announcing vote %s: %v", vote.ID, err)
+	}
+}
+
 // CreateContentHandler handles the creation of new content
-func CreateContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.HandlerFunc {
+func CreateContentHandler(hub *websocket.WebSocketHub, db db.Repository, federator *federation.Federator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var content models.Content
 		if err := c.ShouldBindJSON(&content); err != nil {
@@ -23,7 +63,7 @@ func CreateContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin
 
 		// Set content metadata
 		content.AuthorID = c.GetString("user_id")
-		content.Timestamp = time.Now()
+		content.Timestamp = models.Now()
 		content.LastUpdated = time.Now()
 		content.ProcessingStatus = "pending"
 
@@ -39,21 +79,36 @@ func CreateContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin
 		// Broadcast new content to all users
 		hub.BroadcastUpdate(websocket.EventContentUpdate, content)
 
+		federateContent(federator, db, &content)
+
 		c.JSON(http.StatusCreated, content)
 	}
 }
 
-// GetContentHandler handles retrieving content by ID
+// GetContentHandler handles retrieving content by ID. It loads through the
+// request's Loaders rather than calling the repository directly, so a page
+// that renders many content items in one request still issues a single
+// batched query instead of one per item.
 func GetContentHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
-		// TODO: Implement content retrieval logic
-		c.JSON(http.StatusOK, gin.H{"id": id})
+
+		content, err := loaders.FromContext(c).ContentByID.Load(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if content == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "content not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, content)
 	}
 }
 
 // VoteContentHandler handles voting on content
-func VoteContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.HandlerFunc {
+func VoteContentHandler(hub *websocket.WebSocketHub, db db.Repository, federator *federation.Federator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		contentID := c.Param("id")
 		var vote models.Vote
@@ -66,7 +121,7 @@ func VoteContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.H
 		vote.ID = uuid.New().String()
 		vote.ContentID = contentID
 		vote.UserID = c.GetString("user_id")
-		vote.Timestamp = time.Now()
+		vote.Timestamp = models.Now()
 		vote.LastUpdated = time.Now()
 
 		// Validate vote
@@ -98,6 +153,8 @@ func VoteContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.H
 		// Notify content author
 		hub.SendToUser(content.AuthorID, websocket.EventContentVote, vote)
 
+		federateVote(federator, db, &vote)
+
 		// Get updated vote count
 		votes, err := db.GetContentVotes(contentID)
 		if err != nil {
@@ -117,7 +174,7 @@ func VoteContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.H
 }
 
 // CommentContentHandler handles adding comments to content
-func CommentContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.HandlerFunc {
+func CommentContentHandler(hub *websocket.WebSocketHub, db db.Repository, federator *federation.Federator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		contentID := c.Param("id")
 		var comment models.Comment
@@ -139,7 +196,7 @@ func CommentContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gi
 			ContentType: "comment",
 			ContentText: comment.Text,
 			ParentID:    &contentID,
-			Timestamp:   comment.Timestamp,
+			Timestamp:   models.NewBabelTime(comment.Timestamp),
 			LastUpdated: comment.Timestamp,
 		}
 
@@ -162,12 +219,14 @@ func CommentContentHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gi
 		// Broadcast comment to all users viewing the content
 		hub.BroadcastUpdate(websocket.EventContentComment, comment)
 
+		federateContent(federator, db, content)
+
 		c.JSON(http.StatusOK, comment)
 	}
 }
 
 // AddContextHandler handles adding context posts to content
-func AddContextHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.HandlerFunc {
+func AddContextHandler(hub *websocket.WebSocketHub, db db.Repository, federator *federation.Federator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		parentID := c.Param("id")
 		var contextPost models.Content
@@ -189,7 +248,7 @@ func AddContextHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.Ha
 		contextPost.ContentType = "post"
 		contextPost.ParentID = &parentID
 		contextPost.IsContext = true // Mark as context post
-		contextPost.Timestamp = time.Now()
+		contextPost.Timestamp = models.Now()
 		contextPost.LastUpdated = time.Now()
 		contextPost.ProcessingStatus = "pending"
 
@@ -210,12 +269,14 @@ func AddContextHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.Ha
 			"timestamp":  contextPost.Timestamp,
 		})
 
+		federateContent(federator, db, &contextPost)
+
 		c.JSON(http.StatusOK, contextPost)
 	}
 }
 
 // SendDirectMessageHandler handles sending direct messages between users
-func SendDirectMessageHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.HandlerFunc {
+func SendDirectMessageHandler(hub *websocket.WebSocketHub, db db.Repository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		targetUserID := c.Param("user_id")
 		var message models.DirectMessage
@@ -244,7 +305,7 @@ func SendDirectMessageHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager)
 }
 
 // UpdateConsensusHandler handles consensus updates
-func UpdateConsensusHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.HandlerFunc {
+func UpdateConsensusHandler(hub *websocket.WebSocketHub, db db.Repository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		contentID := c.Param("id")
 		var consensus models.ConsensusUpdate
@@ -262,12 +323,12 @@ func UpdateConsensusHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) g
 
 		// Set consensus metadata
 		consensus.ContentID = contentID
-		consensus.Timestamp = time.Now()
+		consensus.Timestamp = models.Now()
 
 		// Update content consensus state
 		content.Consensus.State = consensus.State
 		content.Consensus.Score = consensus.Score
-		content.Consensus.LastUpdated = consensus.Timestamp
+		content.Consensus.LastUpdated = consensus.Timestamp.Canonical()
 		content.Consensus.ValidatorCount = consensus.Participants
 
 		// Save updated content
@@ -287,7 +348,7 @@ func UpdateConsensusHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) g
 }
 
 // UpdateReputationHandler handles reputation updates
-func UpdateReputationHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager) gin.HandlerFunc {
+func UpdateReputationHandler(hub *websocket.WebSocketHub, db db.Repository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := c.Param("id")
 		var repUpdate models.ReputationUpdate
@@ -328,25 +389,116 @@ func UpdateReputationHandler(hub *websocket.WebSocketHub, db *sqlite.DBManager)
 // GetUserProfileHandler handles retrieving user profiles
 func GetUserProfileHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement user profile retrieval logic
-		c.JSON(http.StatusOK, gin.H{"message": "Profile retrieved"})
+		id := c.Param("id")
+		ldrs := loaders.FromContext(c)
+
+		user, err := ldrs.UsersByID.Load(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+
+		verifications, err := ldrs.VerificationsByUserID.Load(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"user":          user,
+			"verifications": verifications,
+		})
 	}
 }
 
 // GetUserReputationHandler handles retrieving user reputation
 func GetUserReputationHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// TODO: Implement reputation retrieval logic
-		c.JSON(http.StatusOK, gin.H{"message": "Reputation retrieved"})
+		id := c.Param("id")
+
+		user, err := loaders.FromContext(c).UsersByID.Load(id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if user == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"user_id":            user.ID,
+			"reputation_score":   user.ReputationScore,
+			"authenticity_score": user.AuthenticityScore,
+			"truth_accuracy":     user.TruthAccuracy,
+			"evidence_quality":   user.EvidenceQuality,
+			"engagement_quality": user.EngagementQuality,
+			"community_score":    user.CommunityScore,
+		})
 	}
 }
 
-// GetContentAnalyticsHandler handles retrieving content analytics
-func GetContentAnalyticsHandler() gin.HandlerFunc {
+// VoteFinalityHandler reports whether a vote has reached HCS consensus,
+// and the consensus timestamp/sequence number HCS assigned it. It
+// requires the active backend to satisfy hcs.Store; only sqlite.DBManager
+// does, so this returns 501 against postgres/mongo.
+func VoteFinalityHandler(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		contentID := c.Param("id")
+		voterID := c.Param("voter_id")
+
+		store, ok := repo.(hcs.Store)
+		if !ok {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": "HCS finality is not available on this database backend"})
+			return
+		}
+
+		hederaTimestamp, consensusSeq, err := hcs.VerifyVoteFinality(store, contentID, voterID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		final := !hederaTimestamp.IsZero()
+		resp := gin.H{
+			"content_id": contentID,
+			"voter_id":   voterID,
+			"final":      final,
+		}
+		if final {
+			resp["hedera_timestamp"] = hederaTimestamp
+			resp["consensus_seq"] = consensusSeq
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// GetContentAnalyticsHandler handles retrieving content analytics,
+// including a virality breakdown computed over the content's reshare
+// graph. Virality requires the active backend to satisfy virality.Store;
+// only sqlite.DBManager does, so that part of the response is omitted
+// (not a 501 - the rest of the analytics payload is still useful) against
+// postgres/mongo.
+func GetContentAnalyticsHandler(repo db.Repository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
-		// TODO: Implement content analytics logic
-		c.JSON(http.StatusOK, gin.H{"id": id})
+		resp := gin.H{"id": id}
+
+		if store, ok := repo.(virality.Store); ok {
+			calc := virality.NewCalculator(repo, store, virality.DefaultConfig())
+			breakdown, err := calc.Calculate(id)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			resp["virality"] = breakdown
+		}
+
+		c.JSON(http.StatusOK, resp)
 	}
 }
 
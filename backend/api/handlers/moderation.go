@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/moderation"
+)
+
+// moderationService gates a handler on moderation being available - only
+// when the active backend satisfies moderation.Store was a Service built
+// for it in main.go, the same gate VoteFinalityHandler uses for hcs.Store.
+func moderationService(c *gin.Context, service *moderation.Service) (*moderation.Service, bool) {
+	if service == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "moderation is not available on this database backend"})
+		return nil, false
+	}
+	return service, true
+}
+
+// flagContentRequest is the body POST /api/moderation/flag expects.
+type flagContentRequest struct {
+	ContentID string   `json:"content_id" binding:"required"`
+	Category  string   `json:"category" binding:"required"`
+	Evidence  []string `json:"evidence"`
+}
+
+// FlagContentHandler reports a piece of content for review, convening its
+// first jury.
+func FlagContentHandler(service *moderation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		svc, ok := moderationService(c, service)
+		if !ok {
+			return
+		}
+
+		var req flagContentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		reporterID := c.GetString("user_id")
+		flag, jury, err := svc.FlagContent(req.ContentID, reporterID, req.Category, req.Evidence)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"flag": flag, "jury": jury})
+	}
+}
+
+// castJuryVoteRequest is the body POST /api/moderation/juries/:id/vote
+// expects. Weight's sign is the verdict: positive upholds the flag,
+// negative dismisses it.
+type castJuryVoteRequest struct {
+	Weight         float64  `json:"weight" binding:"required"`
+	CertaintyLevel int      `json:"certainty_level"`
+	EvidenceIDs    []string `json:"evidence_ids"`
+}
+
+// CastJuryVoteHandler records an invited juror's moderation vote, and
+// resolves the jury's verdict once quorum is reached.
+func CastJuryVoteHandler(service *moderation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		svc, ok := moderationService(c, service)
+		if !ok {
+			return
+		}
+
+		juryID := c.Param("id")
+		var req castJuryVoteRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		jurorID := c.GetString("user_id")
+		verdict, err := svc.CastJuryVote(juryID, jurorID, req.Weight, req.CertaintyLevel, req.EvidenceIDs)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if verdict == nil {
+			c.JSON(http.StatusOK, gin.H{"status": "recorded"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "resolved", "verdict": verdict})
+	}
+}
+
+// AppealVerdictHandler lets a content's author request re-review of a
+// resolved jury's verdict by a larger jury.
+func AppealVerdictHandler(service *moderation.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		svc, ok := moderationService(c, service)
+		if !ok {
+			return
+		}
+
+		juryID := c.Param("id")
+		requesterID := c.GetString("user_id")
+
+		appealJury, err := svc.Appeal(juryID, requesterID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"jury": appealJury})
+	}
+}
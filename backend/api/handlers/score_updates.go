@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/content"
+)
+
+// StreamScoreUpdatesHandler streams live ScoreUpdate events over
+// Server-Sent Events, so a frontend can render consensus movement as
+// processBatch/AddContext settle scores instead of polling
+// GetContentHandler every few seconds. With no :id param it streams every
+// content item's updates; with one, it streams only that content ID's.
+func StreamScoreUpdatesHandler(mgr *content.Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var updates <-chan content.ScoreUpdate
+		var unsubscribe func()
+		if id := c.Param("id"); id != "" {
+			updates, unsubscribe = mgr.Subscribe(id)
+		} else {
+			updates, unsubscribe = mgr.SubscribeAll()
+		}
+		defer unsubscribe()
+
+		c.Writer.Header().Set("Cache-Control", "no-cache")
+		c.Writer.Header().Set("Connection", "keep-alive")
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case update, ok := <-updates:
+				if !ok {
+					return false
+				}
+				c.SSEvent("score_update", update)
+				return true
+			case <-c.Request.Context().Done():
+				return false
+			}
+		})
+	}
+}
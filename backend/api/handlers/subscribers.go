@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// CreateEventSubscriberHandler registers a new third-party event subscriber
+// and returns it with its generated shared secret. The secret is stored on
+// the subscriber and is also part of the Get, List and Update responses.
+func CreateEventSubscriberHandler(db db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.EventSubscriber
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		secret, err := generateSecret()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		now := time.Now()
+		sub := &models.EventSubscriber{
+			ID:          uuid.New().String(),
+			OwnerID:     c.GetString("user_id"),
+			Secret:      secret,
+			Topics:      req.Topics,
+			DeliveryURL: req.DeliveryURL,
+			Transport:   req.Transport,
+			Active:      true,
+			CreatedAt:   now,
+			LastUpdated: now,
+		}
+		if sub.Transport == "" {
+			sub.Transport = models.SubscriberTransportWebhook
+		}
+
+		if err := db.CreateEventSubscriber(sub); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusCreated, sub)
+	}
+}
+
+// GetEventSubscriberHandler retrieves a subscriber by ID.
+func GetEventSubscriberHandler(db db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sub, err := db.GetEventSubscriber(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if sub == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "subscriber not found"})
+			return
+		}
+		c.JSON(http.StatusOK, sub)
+	}
+}
+
+// ListEventSubscribersHandler returns all active subscribers.
+func ListEventSubscribersHandler(db db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		subs, err := db.ListEventSubscribers()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, subs)
+	}
+}
+
+// UpdateEventSubscriberHandler updates a subscriber's topic filter,
+// delivery URL, transport, or active state.
+func UpdateEventSubscriberHandler(db db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		existing, err := db.GetEventSubscriber(c.Param("id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if existing == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "subscriber not found"})
+			return
+		}
+
+		var req models.EventSubscriber
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		existing.Topics = req.Topics
+		existing.DeliveryURL = req.DeliveryURL
+		existing.Transport = req.Transport
+		existing.Active = req.Active
+		existing.LastUpdated = time.Now()
+
+		if err := db.UpdateEventSubscriber(existing); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, existing)
+	}
+}
+
+// DeleteEventSubscriberHandler removes a subscriber.
+func DeleteEventSubscriberHandler(db db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := db.DeleteEventSubscriber(c.Param("id")); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusNoContent, nil)
+	}
+}
+
+// generateSecret returns a random 32-byte hex-encoded shared secret.
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
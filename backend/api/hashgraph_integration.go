@@ -3,18 +3,43 @@
 package api
 
 import (
-    "github.com/gin-gonic/gin"
-    "net/http"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/saint/babel-protocol/backend/internal/observability"
 )
 
+// hashgraphTracer is the tracer these stub handlers open spans against. It
+// resolves against whatever TracerProvider observability.NewProvider
+// registered globally, so these handlers don't need one threaded in.
+var hashgraphTracer = otel.Tracer(observability.TracerName)
+
 // SubmitToHashgraph handles submitting data to the Hashgraph network
 func SubmitToHashgraph(c *gin.Context) {
-    // Implement logic to submit data to the Hashgraph network
-    c.JSON(http.StatusOK, gin.H{"message": "Submit to Hashgraph endpoint"})
+	ctx, span := observability.StartSpan(c.Request.Context(), hashgraphTracer, "hashgraph.submit",
+		attribute.String("content.id", c.Query("content_id")),
+		attribute.String("hedera.topic_id", c.Query("topic_id")),
+		attribute.String("evidence.type", c.Query("evidence_type")),
+	)
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	// Implement logic to submit data to the Hashgraph network
+	c.JSON(http.StatusOK, gin.H{"message": "Submit to Hashgraph endpoint"})
 }
 
 // RetrieveFromHashgraph handles retrieving data from the Hashgraph network
 func RetrieveFromHashgraph(c *gin.Context) {
-    // Implement logic to retrieve data from the Hashgraph network
-    c.JSON(http.StatusOK, gin.H{"message": "Retrieve from Hashgraph endpoint"})
+	ctx, span := observability.StartSpan(c.Request.Context(), hashgraphTracer, "hashgraph.retrieve",
+		attribute.String("content.id", c.Query("content_id")),
+		attribute.String("hedera.topic_id", c.Query("topic_id")),
+	)
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	// Implement logic to retrieve data from the Hashgraph network
+	c.JSON(http.StatusOK, gin.H{"message": "Retrieve from Hashgraph endpoint"})
 }
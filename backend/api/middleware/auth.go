@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/auth"
+)
+
+const bearerPrefix = "Bearer "
+
+// RequireAuth validates the bearer token on incoming requests via
+// authenticator and injects the resulting auth.Identity into context as
+// "identity", plus "user_id" for the handlers written before this
+// middleware existed - replacing AuthMiddleware's placeholder user_id on
+// every route it's mounted on.
+func RequireAuth(authenticator auth.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, bearerPrefix) {
+			recordAuthFailure(c.ClientIP())
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization required"})
+			c.Abort()
+			return
+		}
+
+		identity, err := authenticator.Validate(strings.TrimPrefix(header, bearerPrefix))
+		if err != nil {
+			recordAuthFailure(c.ClientIP())
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			c.Abort()
+			return
+		}
+
+		c.Set("identity", identity)
+		c.Set("user_id", identity.UserID)
+		c.Next()
+	}
+}
+
+// RequireRole gates a route on the caller's Identity carrying role. Mount
+// it after RequireAuth(authenticator) - it reads the Identity that
+// middleware set, it doesn't validate the token itself.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		val, ok := c.Get("identity")
+		identity, isIdentity := val.(auth.Identity)
+		if !ok || !isIdentity || identity.Role != role {
+			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient privileges"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+	"github.com/saint/babel-protocol/backend/internal/ratelimit"
+)
+
+func TestRateLimitMiddlewareConcurrencyLimitReturns429(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 100,
+		Burst:             100,
+		RouteOverrides: map[string]config.RouteLimit{
+			"ping": {RequestsPerSecond: 100, Burst: 100, ConcurrencyLimit: 1},
+		},
+	}, ratelimit.NewInMemoryBackend())
+
+	release, ok := limiter.AcquireConcurrency("ping")
+	if !ok {
+		t.Fatal("failed to pre-acquire the route's only concurrency slot")
+	}
+	defer release()
+
+	router := newTestRouter(limiter, &stubRepo{}, "")
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = "203.0.113.3:1234"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("got status %d, want 429 (concurrency slot held elsewhere)", w.Code)
+	}
+}
@@ -1,13 +1,53 @@
 package middleware
 
 import (
+	"fmt"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/alert"
+)
+
+// authFailureWindow and authFailureThreshold bound how many 401s from a
+// single IP within the window trigger a critical alert (a burst usually
+// means credential stuffing or a broken client retrying blindly).
+const (
+	authFailureWindow    = time.Minute
+	authFailureThreshold = 10
 )
 
+var authFailures = struct {
+	mu   sync.Mutex
+	byIP map[string][]time.Time
+}{byIP: make(map[string][]time.Time)}
+
+// recordAuthFailure tracks a 401 from clientIP and raises an alert once the
+// count within authFailureWindow crosses authFailureThreshold.
+func recordAuthFailure(clientIP string) {
+	authFailures.mu.Lock()
+	defer authFailures.mu.Unlock()
+
+	cutoff := time.Now().Add(-authFailureWindow)
+	recent := authFailures.byIP[clientIP][:0]
+	for _, t := range authFailures.byIP[clientIP] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+	recent = append(recent, time.Now())
+	authFailures.byIP[clientIP] = recent
+
+	if len(recent) >= authFailureThreshold {
+		alert.Raise(alert.SeverityCritical, "auth.failure_burst",
+			fmt.Sprintf("%d auth failures from %s in the last %s", len(recent), clientIP, authFailureWindow),
+			map[string]interface{}{"ip": clientIP, "count": len(recent)})
+	}
+}
+
 // LoggerMiddleware logs request details
 func LoggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -50,6 +90,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		// TODO: Implement proper authentication
 		token := c.GetHeader("Authorization")
 		if token == "" {
+			recordAuthFailure(c.ClientIP())
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization required"})
 			c.Abort()
 			return
@@ -60,3 +101,18 @@ func AuthMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// BearerTokenMiddleware gates a route behind a fixed bearer token, for
+// management endpoints (e.g. /sys/metrics) that need to stay reachable
+// even when AuthMiddleware's end-user auth is unavailable. An empty token
+// rejects every request rather than allowing the route open by accident.
+func BearerTokenMiddleware(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" || c.GetHeader("Authorization") != "Bearer "+token {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
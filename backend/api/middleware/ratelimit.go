@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/decisions"
+	"github.com/saint/babel-protocol/backend/internal/ratelimit"
+)
+
+// RateLimitMiddleware enforces limiter's token bucket for routeKey, keyed
+// by the authenticated user_id set in context (AuthMiddleware must run
+// first) and falling back to the client IP for unauthenticated routes. The
+// caller's ReputationScore - fetched from repo, so a stale or missing
+// user just gets the un-tiered base limit - scales how many tokens they
+// get relative to a brand-new account.
+//
+// decisionSvc, if non-nil, is consulted before the token bucket: an active
+// "ban" decision on the caller's IP or user ID short-circuits with 403, a
+// "captcha" decision short-circuits with 403 and a challenge_url pointing
+// at the challenge handler stub, and a
+// "throttle" decision shrinks the effective bucket by
+// decisions.ThrottleFraction rather than denying outright. Pass nil where
+// the active backend doesn't support decisions.Store.
+func RateLimitMiddleware(limiter *ratelimit.Limiter, repo db.Repository, routeKey string, decisionSvc *decisions.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clientIP := c.ClientIP()
+		if limiter.IsWhitelisted(clientIP) {
+			c.Next()
+			return
+		}
+
+		userID := c.GetString("user_id")
+
+		if decisionSvc != nil {
+			d, blocked := activeDecision(decisionSvc, clientIP, userID)
+			if blocked {
+				switch d.Action {
+				case decisions.ActionBan:
+					c.JSON(http.StatusForbidden, gin.H{"error": "forbidden", "reason": d.Reason})
+					c.Abort()
+					return
+				case decisions.ActionCaptcha:
+					c.JSON(http.StatusForbidden, gin.H{
+						"error":         "captcha required",
+						"challenge_url": "/api/captcha/challenge",
+					})
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		release, ok := limiter.AcquireConcurrency(routeKey)
+		if !ok {
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "concurrency limit exceeded"})
+			c.Abort()
+			return
+		}
+		defer release()
+
+		key := userID
+		var reputation float64
+		if key != "" {
+			if user, err := repo.GetUser(key); err == nil && user != nil {
+				reputation = user.ReputationScore
+			}
+		} else {
+			key = clientIP
+		}
+
+		throttleFraction := 1.0
+		if decisionSvc != nil {
+			if d, ok := decisionSvc.Lookup(decisions.ScopeUser, userID); ok && d.Action == decisions.ActionThrottle {
+				throttleFraction = decisions.ThrottleFraction
+			} else if d, ok := decisionSvc.Lookup(decisions.ScopeIP, clientIP); ok && d.Action == decisions.ActionThrottle {
+				throttleFraction = decisions.ThrottleFraction
+			}
+		}
+
+		allowed, retryAfter, err := limiter.CheckThrottled(c.Request.Context(), key, routeKey, reputation, throttleFraction)
+		if err != nil {
+			// Rate limiting must not become a single point of failure for
+			// the API it's protecting - a broken backend (e.g. Redis down)
+			// fails open rather than blocking every request.
+			c.Next()
+			return
+		}
+		if !allowed {
+			seconds := int(retryAfter.Seconds())
+			if seconds < 1 {
+				seconds = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":       "rate limit exceeded",
+				"retry_after": seconds,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// activeDecision returns the first active ban or captcha decision found for
+// clientIP or userID (IP checked first), so a banned/challenged caller is
+// turned away before a concurrency slot or rate-limit token is spent on
+// them. Throttle decisions aren't handled here since they don't block the
+// request - they're applied as a fraction further down in the middleware.
+func activeDecision(svc *decisions.Service, clientIP, userID string) (decisions.Decision, bool) {
+	if d, ok := svc.Lookup(decisions.ScopeIP, clientIP); ok && d.Action != decisions.ActionThrottle {
+		return d, true
+	}
+	if userID != "" {
+		if d, ok := svc.Lookup(decisions.ScopeUser, userID); ok && d.Action != decisions.ActionThrottle {
+			return d, true
+		}
+	}
+	return decisions.Decision{}, false
+}
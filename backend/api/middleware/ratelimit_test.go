@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/config"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/ratelimit"
+)
+
+// stubRepo embeds db.Repository so it satisfies the interface without
+// implementing every method - GetUser is the only one RateLimitMiddleware
+// ever calls.
+type stubRepo struct {
+	db.Repository
+	user *models.User
+}
+
+func (s *stubRepo) GetUser(id string) (*models.User, error) {
+	return s.user, nil
+}
+
+func newTestRouter(limiter *ratelimit.Limiter, repo db.Repository, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/ping", func(c *gin.Context) {
+		if userID != "" {
+			c.Set("user_id", userID)
+		}
+		c.Next()
+	}, RateLimitMiddleware(limiter, repo, "ping", nil), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestRateLimitMiddlewareAllowsThenDenies(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 1,
+		Burst:             1,
+	}, ratelimit.NewInMemoryBackend())
+
+	router := newTestRouter(limiter, &stubRepo{}, "")
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = "203.0.113.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("first request: got status %d, want 200", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request: got status %d, want 429", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header on a 429 response")
+	}
+}
+
+func TestRateLimitMiddlewareWhitelistBypassesLimit(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 1,
+		Burst:             1,
+		WhiteList:         []string{"203.0.113.1"},
+	}, ratelimit.NewInMemoryBackend())
+
+	router := newTestRouter(limiter, &stubRepo{}, "")
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = "203.0.113.1:1234"
+
+	for i := 0; i < 5; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d from whitelisted IP: got status %d, want 200", i, w.Code)
+		}
+	}
+}
+
+func TestRateLimitMiddlewareReputationGrantsHigherLimit(t *testing.T) {
+	limiter := ratelimit.NewLimiter(config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 1,
+		Burst:             1,
+		ReputationTiers: []config.ReputationTier{
+			{MinReputation: 0.5, RequestsPerSecond: 10, Burst: 5},
+		},
+	}, ratelimit.NewInMemoryBackend())
+
+	repo := &stubRepo{user: &models.User{ID: "trusted-user", ReputationScore: 0.9}}
+	router := newTestRouter(limiter, repo, "trusted-user")
+
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = "203.0.113.2:1234"
+
+	for i := 0; i < 5; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d for a high-reputation user: got status %d, want 200 (tier burst=5)", i, w.Code)
+		}
+	}
+}
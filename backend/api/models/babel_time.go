@@ -0,0 +1,110 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BabelTime wraps time.Time with a lenient JSON decoder that accepts the
+// formats the protocol actually sees in the wild: strict clients sending
+// RFC3339(Nano), federated peers and older services sending a bare
+// date/time with no offset, and mobile clients sending Unix epoch numbers.
+// Whatever comes in, MarshalJSON always re-emits RFC3339Nano in UTC so
+// content hashing/signing stays deterministic regardless of which format
+// the original sender used.
+type BabelTime struct {
+	time.Time
+}
+
+// babelTimeLayouts are tried in order against a quoted JSON string value.
+var babelTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// Now returns the current time as a BabelTime.
+func Now() BabelTime {
+	return NewBabelTime(time.Now())
+}
+
+// NewBabelTime wraps t as a BabelTime.
+func NewBabelTime(t time.Time) BabelTime {
+	return BabelTime{Time: t}
+}
+
+// Canonical returns the normalized UTC time.Time used for hashing/signing.
+func (t BabelTime) Canonical() time.Time {
+	return t.Time.UTC()
+}
+
+// MarshalJSON always emits RFC3339Nano in UTC.
+func (t BabelTime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.Canonical().Format(time.RFC3339Nano) + `"`), nil
+}
+
+// UnmarshalJSON accepts, in order: RFC3339Nano, RFC3339, a timezone-less
+// "2006-01-02T15:04:05" (treated as UTC), Unix seconds, Unix milliseconds
+// (values greater than 1e12), and a plain "2006-01-02" date.
+func (t *BabelTime) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if !strings.HasPrefix(s, `"`) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("babel time: not a quoted string or integer: %s", s)
+		}
+		if n > 1e12 {
+			t.Time = time.UnixMilli(n).UTC()
+		} else {
+			t.Time = time.Unix(n, 0).UTC()
+		}
+		return nil
+	}
+
+	s = strings.Trim(s, `"`)
+	for _, layout := range babelTimeLayouts {
+		parsed, err := time.Parse(layout, s)
+		if err == nil {
+			t.Time = parsed.UTC()
+			return nil
+		}
+	}
+	return fmt.Errorf("babel time: unrecognized time format: %s", s)
+}
+
+// Scan implements database/sql.Scanner so BabelTime fields can be read
+// directly out of driver rows alongside the plain time.Time columns the
+// storage layer already scans.
+func (t *BabelTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case time.Time:
+		t.Time = v
+		return nil
+	case int64:
+		t.Time = time.Unix(v, 0).UTC()
+		return nil
+	case string:
+		return t.UnmarshalJSON([]byte(`"` + v + `"`))
+	case []byte:
+		return t.UnmarshalJSON([]byte(`"` + string(v) + `"`))
+	default:
+		return fmt.Errorf("babel time: unsupported Scan source %T", src)
+	}
+}
+
+// Value implements database/sql/driver.Valuer.
+func (t BabelTime) Value() (driver.Value, error) {
+	return t.Canonical(), nil
+}
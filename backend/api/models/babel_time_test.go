@@ -0,0 +1,110 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBabelTimeUnmarshalAcceptedFormats(t *testing.T) {
+	want := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+
+	cases := map[string]string{
+		"RFC3339Nano":       `"2024-03-15T09:30:00.000000000Z"`,
+		"RFC3339":           `"2024-03-15T09:30:00Z"`,
+		"no timezone":       `"2024-03-15T09:30:00"`,
+		"unix seconds":      `1710495000`,
+		"unix milliseconds": `1710495000000`,
+		"plain date":        `"2024-03-15"`,
+	}
+
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			var bt BabelTime
+			if err := json.Unmarshal([]byte(input), &bt); err != nil {
+				t.Fatalf("unmarshal %s: %v", input, err)
+			}
+
+			if name == "plain date" {
+				if !bt.Time.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+					t.Errorf("got %v, want 2024-03-15 00:00:00 UTC", bt.Time)
+				}
+				return
+			}
+			if !bt.Time.Equal(want) {
+				t.Errorf("got %v, want %v", bt.Time, want)
+			}
+		})
+	}
+}
+
+func TestBabelTimeUnmarshalRejectsGarbage(t *testing.T) {
+	var bt BabelTime
+	if err := bt.UnmarshalJSON([]byte(`"not a time"`)); err == nil {
+		t.Error("expected an error for an unrecognized time format, got nil")
+	}
+}
+
+func TestBabelTimeMarshalAlwaysRFC3339NanoUTC(t *testing.T) {
+	bt := NewBabelTime(time.Date(2024, 3, 15, 9, 30, 0, 0, time.FixedZone("EST", -5*60*60)))
+
+	out, err := json.Marshal(bt)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	want := `"2024-03-15T14:30:00Z"`
+	if string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+}
+
+// TestBabelTimeSignatureStability shows that two clients sending the same
+// instant in different formats produce an Activity with the same
+// signature, since signActivity keys off Canonical() rather than whatever
+// format the original timestamp happened to arrive in.
+func TestBabelTimeSignatureStability(t *testing.T) {
+	actor := &User{ID: "user-1", PublicKey: "test-key"}
+	content := &Content{ID: "content-1", ContentText: "hello"}
+	const secret = "follower-shared-secret"
+
+	var rfc3339Client BabelTime
+	if err := json.Unmarshal([]byte(`"2024-03-15T09:30:00Z"`), &rfc3339Client); err != nil {
+		t.Fatalf("unmarshal rfc3339: %v", err)
+	}
+
+	var unixClient BabelTime
+	if err := json.Unmarshal([]byte(`1710495000`), &unixClient); err != nil {
+		t.Fatalf("unmarshal unix: %v", err)
+	}
+
+	if !rfc3339Client.Time.Equal(unixClient.Time) {
+		t.Fatalf("test setup invalid: the two inputs don't represent the same instant (%v vs %v)", rfc3339Client.Time, unixClient.Time)
+	}
+
+	activityA, err := newActivityAt(content, actor, rfc3339Client, secret)
+	if err != nil {
+		t.Fatalf("newActivityAt (rfc3339): %v", err)
+	}
+	activityB, err := newActivityAt(content, actor, unixClient, secret)
+	if err != nil {
+		t.Fatalf("newActivityAt (unix): %v", err)
+	}
+
+	if activityA.Signature != activityB.Signature {
+		t.Errorf("signatures diverged for the same instant in different wire formats: %s vs %s", activityA.Signature, activityB.Signature)
+	}
+}
+
+// newActivityAt is newActivity with an injected Published time, so the test
+// above can hold the instant fixed while varying only the wire format it
+// arrived in.
+func newActivityAt(content *Content, actor *User, published BabelTime, secret string) (*Activity, error) {
+	activity, err := NewContentCreate(content, actor)
+	if err != nil {
+		return nil, err
+	}
+	activity.Published = published
+	activity.SignWith(secret)
+	return activity, nil
+}
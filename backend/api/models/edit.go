@@ -0,0 +1,57 @@
+package models
+
+// EditContent is a partial-update request for a Content. Every field is an
+// Optional so the handler can tell "client didn't send this" apart from
+// "client explicitly cleared this to its zero value".
+type EditContent struct {
+	ContentText     Optional[string]                 `json:"content_text"`
+	MediaURLs       Optional[[]string]                `json:"media_urls"`
+	IsContext       Optional[bool]                    `json:"is_context"`
+	Metadata        Optional[map[string]interface{}]  `json:"metadata"`
+	TruthScore      Optional[float64]                 `json:"truth_score"`
+	VisibilityScore Optional[float64]                 `json:"visibility_score"`
+	Topics          Optional[[]string]                `json:"topics"`
+}
+
+// ApplyTo patches the set fields of e onto c, leaving unset fields alone.
+func (e *EditContent) ApplyTo(c *Content) {
+	Patch(&c.ContentText, e.ContentText)
+	Patch(&c.MediaURLs, e.MediaURLs)
+	Patch(&c.IsContext, e.IsContext)
+	Patch(&c.Metadata, e.Metadata)
+	Patch(&c.TruthScore, e.TruthScore)
+	Patch(&c.VisibilityScore, e.VisibilityScore)
+	Patch(&c.Topics, e.Topics)
+}
+
+// EditVote is a partial-update request for a Vote.
+type EditVote struct {
+	Weight         Optional[float64]  `json:"weight"`
+	CertaintyLevel Optional[int]      `json:"certainty_level"`
+	EvidenceIDs    Optional[[]string] `json:"evidence_ids"`
+	Explanation    Optional[string]   `json:"explanation"`
+	ContextScore   Optional[float64]  `json:"context_score"`
+}
+
+// ApplyTo patches the set fields of e onto v, leaving unset fields alone.
+func (e *EditVote) ApplyTo(v *Vote) {
+	Patch(&v.Weight, e.Weight)
+	Patch(&v.CertaintyLevel, e.CertaintyLevel)
+	Patch(&v.EvidenceIDs, e.EvidenceIDs)
+	Patch(&v.Explanation, e.Explanation)
+	Patch(&v.ContextScore, e.ContextScore)
+}
+
+// EditUser is a partial-update request for a User.
+type EditUser struct {
+	Username    Optional[string]           `json:"username"`
+	Preferences Optional[*UserPreferences] `json:"preferences"`
+	StakeAmount Optional[float64]          `json:"stake_amount"`
+}
+
+// ApplyTo patches the set fields of e onto u, leaving unset fields alone.
+func (e *EditUser) ApplyTo(u *User) {
+	Patch(&u.Username, e.Username)
+	Patch(&u.Preferences, e.Preferences)
+	Patch(&u.StakeAmount, e.StakeAmount)
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Experiment statuses.
+const (
+	ExperimentStatusActive  = "active"
+	ExperimentStatusPaused  = "paused"
+	ExperimentStatusStopped = "stopped"
+)
+
+// Experiment is a named A/B (or multi-armed) test backing the
+// FeedbackLoopOptimization/ABTesting endpoints.
+type Experiment struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExperimentArm is one variant of an Experiment, with a Beta(Alpha, Beta)
+// posterior over its reward rate used by the Thompson-sampling bandit.
+type ExperimentArm struct {
+	ID           string  `json:"id"`
+	ExperimentID string  `json:"experiment_id"`
+	Name         string  `json:"name"`
+	Alpha        float64 `json:"alpha"`
+	Beta         float64 `json:"beta"`
+}
+
+// ArmStats summarizes an arm's current posterior for GetArmStats.
+type ArmStats struct {
+	ArmID           string  `json:"arm_id"`
+	ArmName         string  `json:"arm_name"`
+	Alpha           float64 `json:"alpha"`
+	Beta            float64 `json:"beta"`
+	Mean            float64 `json:"mean"`
+	AssignmentCount int     `json:"assignment_count"`
+	EventCount      int     `json:"event_count"`
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Activity types.
+const (
+	ActivityTypeCreate = "Create"
+	ActivityTypeAffirm = "Affirm"
+	ActivityTypeAttach = "Attach"
+)
+
+// Activity object types.
+const (
+	ObjectTypeContent  = "Content"
+	ObjectTypeVote     = "Vote"
+	ObjectTypeEvidence = "Evidence"
+)
+
+// Activity is an ActivityPub-style envelope for pushing Content, Vote, and
+// Evidence records to peer Babel instances. Object carries the canonical
+// JSON of the underlying payload rather than a typed field so new object
+// types don't require changing the envelope.
+type Activity struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type"`
+	ActorID    string           `json:"actor_id"`
+	ObjectType string           `json:"object_type"`
+	ObjectID   string           `json:"object_id"`
+	Object     json.RawMessage  `json:"object"`
+	Published  BabelTime        `json:"published"`
+	Updated    BabelTime        `json:"updated"`
+	APID       string           `json:"ap_id"`
+	Sensitive  Optional[bool]   `json:"sensitive"`
+	Signature  string           `json:"signature"`
+	PublicKey  string           `json:"public_key"`
+	InReplyTo  Optional[string] `json:"in_reply_to"`
+	To         []string         `json:"to"`
+	Cc         []string         `json:"cc"`
+}
+
+// NewContentCreate builds a Create/Content activity for content, attributed
+// to actor. The returned Activity is unsigned - call SignWith once per
+// recipient before delivering it.
+func NewContentCreate(content *Content, actor *User) (*Activity, error) {
+	return newActivity(ActivityTypeCreate, ObjectTypeContent, content.ID, actor, content)
+}
+
+// NewVoteAffirm builds an Affirm/Vote activity for vote, attributed to
+// actor. The returned Activity is unsigned - call SignWith once per
+// recipient before delivering it.
+func NewVoteAffirm(vote *Vote, actor *User) (*Activity, error) {
+	return newActivity(ActivityTypeAffirm, ObjectTypeVote, vote.ID, actor, vote)
+}
+
+// NewEvidenceAttach builds an Attach/Evidence activity for evidence,
+// attributed to actor. The returned Activity is unsigned - call SignWith
+// once per recipient before delivering it.
+func NewEvidenceAttach(evidence *Evidence, actor *User) (*Activity, error) {
+	return newActivity(ActivityTypeAttach, ObjectTypeEvidence, evidence.ID, actor, evidence)
+}
+
+func newActivity(activityType, objectType, objectID string, actor *User, payload interface{}) (*Activity, error) {
+	object, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("models: marshaling %s object: %w", objectType, err)
+	}
+
+	now := Now()
+
+	return &Activity{
+		ID:         fmt.Sprintf("%s-%s", activityType, objectID),
+		Type:       activityType,
+		ActorID:    actor.ID,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Object:     object,
+		Published:  now,
+		Updated:    now,
+		PublicKey:  actor.PublicKey,
+	}, nil
+}
+
+// SignWith signs a for one recipient, using secret - the value shared
+// between the local actor and that recipient alone (Follower.SharedSecret
+// on the sending side), never actor.PublicKey. PublicKey is served back
+// out in plain JSON by GetActorHandler, so keying the signature off it
+// would let anyone who fetches an actor's profile forge activities on
+// their behalf; a federation delivery is signed once per follower instead,
+// so each copy verifies against only that follower's secret.
+func (a *Activity) SignWith(secret string) {
+	a.Signature = signActivity(secret, a.Object, a.Published, a.ActorID)
+}
+
+// VerifyActivity recomputes a's signature using secret - the value shared
+// between the local and remote actor for the follow relationship a claims
+// to come from - and rejects the activity if it doesn't match.
+func VerifyActivity(a *Activity, secret string) error {
+	expected := signActivity(secret, a.Object, a.Published, a.ActorID)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(a.Signature)) != 1 {
+		return fmt.Errorf("models: activity signature invalid for actor %s", a.ActorID)
+	}
+	return nil
+}
+
+// signActivity computes the hex-encoded HMAC-SHA256, keyed by a secret
+// shared with exactly one recipient, of the SHA-256 digest of object
+// concatenated with the canonical published timestamp and the actor ID.
+func signActivity(key string, object json.RawMessage, published BabelTime, actorID string) string {
+	digest := sha256.Sum256(object)
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(digest[:])
+	mac.Write([]byte(published.Canonical().Format(time.RFC3339Nano)))
+	mac.Write([]byte(actorID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,33 @@
+package models
+
+// ListEvidence requests the evidence attached to a piece of content. Like
+// FeedRequest and AlgorithmRequest, it carries both json and url tags so the
+// same struct can back a JSON POST body or a query-string GET (see the
+// models/query package).
+type ListEvidence struct {
+	ContentID         string            `json:"content_id" url:"content_id"`
+	VerificationState Optional[string]  `json:"verification_state" url:"verification_state,omitempty"`
+	MinQualityScore   Optional[float64] `json:"min_quality_score" url:"min_quality_score,omitempty"`
+	PageSize          int               `json:"page_size" url:"page_size"`
+	LastID            string            `json:"last_id,omitempty" url:"last_id,omitempty"`
+}
+
+// ListVotes requests the votes cast on a piece of content.
+type ListVotes struct {
+	ContentID string           `json:"content_id" url:"content_id"`
+	Type      Optional[string] `json:"type" url:"type,omitempty"`
+	UserID    Optional[string] `json:"user_id" url:"user_id,omitempty"`
+	PageSize  int              `json:"page_size" url:"page_size"`
+	LastID    string           `json:"last_id,omitempty" url:"last_id,omitempty"`
+}
+
+// SearchContent searches content by free-text query plus structured filters.
+type SearchContent struct {
+	Query       string                 `json:"query" url:"query"`
+	Topics      []string               `json:"topics,omitempty" url:"topics,omitempty"`
+	AuthorID    Optional[string]       `json:"author_id" url:"author_id,omitempty"`
+	MinTruth    Optional[float64]      `json:"min_truth_score" url:"min_truth_score,omitempty"`
+	Filters     map[string]interface{} `json:"filters,omitempty" url:"filters,omitempty"`
+	PageSize    int                    `json:"page_size" url:"page_size"`
+	LastID      string                 `json:"last_id,omitempty" url:"last_id,omitempty"`
+}
@@ -15,7 +15,7 @@ type Content struct {
 	MediaURLs        []string               `json:"media_urls"`
 	ParentID         *string                `json:"parent_id,omitempty"`
 	IsContext        bool                   `json:"is_context"`
-	Timestamp        time.Time              `json:"timestamp"`
+	Timestamp        BabelTime              `json:"timestamp"`
 	Signature        string                 `json:"signature"`
 	Hash             string                 `json:"hash"`
 	ProcessingStatus string                 `json:"processing_status"`
@@ -28,6 +28,11 @@ type Content struct {
 	Entities         []string               `json:"entities"`
 	ContextRefs      []string               `json:"context_refs"`
 	Consensus        ConsensusInfo          `json:"consensus"`
+	// Priority hints content.Manager's batch scheduler to flush this item
+	// sooner than default-priority content (e.g. breaking news, or a post
+	// whose VisibilityScore already crossed a high-visibility threshold).
+	// Zero is normal priority; higher values flush sooner.
+	Priority int `json:"priority,omitempty"`
 }
 
 // Vote types
@@ -38,6 +43,12 @@ const (
 	VoteTypeDeny     = "deny"
 	VoteTypeEngage   = "engage"
 	VoteTypeUnengage = "unengage"
+
+	// VoteTypeModeration is cast by a juror reviewing a flagged content
+	// item. It carries no direction of its own (unlike upvote/downvote or
+	// affirm/deny), so Validate treats Weight's sign as the verdict: a
+	// positive weight upholds the flag, a negative one dismisses it.
+	VoteTypeModeration = "moderation"
 )
 
 // Vote represents a vote on a piece of content
@@ -49,7 +60,7 @@ type Vote struct {
 	Weight         float64   `json:"weight"`          // Base weight of the vote
 	CertaintyLevel int       `json:"certainty_level"` // 1-3 for affirm/deny votes
 	EvidenceIDs    []string  `json:"evidence_ids"`
-	Timestamp      time.Time `json:"timestamp"`
+	Timestamp      BabelTime `json:"timestamp"`
 	LastUpdated    time.Time `json:"last_updated"`
 	Explanation    string    `json:"explanation,omitempty"`
 	ContextScore   float64   `json:"context_score"`
@@ -70,6 +81,13 @@ func (v *Vote) Validate() error {
 		if v.CertaintyLevel != 0 {
 			return fmt.Errorf("certainty level should not be set for engage/unengage")
 		}
+	case VoteTypeModeration:
+		if v.CertaintyLevel < 1 || v.CertaintyLevel > 3 {
+			return fmt.Errorf("certainty level must be between 1 and 3 for moderation votes")
+		}
+		if v.Weight == 0 {
+			return fmt.Errorf("weight must be nonzero to indicate a verdict direction for moderation votes")
+		}
 	default:
 		return fmt.Errorf("invalid vote type: %s", v.Type)
 	}
@@ -93,7 +111,7 @@ type Evidence struct {
 	ContextScore      float64                `json:"context_score"`
 	VerificationState string                 `json:"verification_state"`
 	ContextData       map[string]interface{} `json:"context_data,omitempty"`
-	Timestamp         time.Time              `json:"timestamp"`
+	Timestamp         BabelTime              `json:"timestamp"`
 	LastUpdated       time.Time              `json:"last_updated"`
 	Metadata          map[string]interface{} `json:"metadata"`
 }
@@ -224,12 +242,12 @@ type NotificationPrefs struct {
 
 // FeedRequest represents a request for content feed
 type FeedRequest struct {
-	UserID      string                 `json:"user_id"`
-	PageSize    int                    `json:"page_size"`
-	LastID      string                 `json:"last_id,omitempty"`
-	Filters     map[string]interface{} `json:"filters,omitempty"`
-	SortBy      string                 `json:"sort_by"`
-	ContextData map[string]interface{} `json:"context_data,omitempty"`
+	UserID      string                 `json:"user_id" url:"user_id"`
+	PageSize    int                    `json:"page_size" url:"page_size"`
+	LastID      string                 `json:"last_id,omitempty" url:"last_id,omitempty"`
+	Filters     map[string]interface{} `json:"filters,omitempty" url:"filters,omitempty"`
+	SortBy      string                 `json:"sort_by" url:"sort_by"`
+	ContextData map[string]interface{} `json:"context_data,omitempty" url:"context_data,omitempty"`
 }
 
 // FeedResponse represents a response containing feed content
@@ -243,11 +261,11 @@ type FeedResponse struct {
 
 // AlgorithmRequest represents a request to the algorithm service
 type AlgorithmRequest struct {
-	Type       string                 `json:"type"`
-	ContentID  string                 `json:"content_id,omitempty"`
-	UserID     string                 `json:"user_id,omitempty"`
-	Parameters map[string]interface{} `json:"parameters,omitempty"`
-	Timestamp  time.Time              `json:"timestamp"`
+	Type       string                 `json:"type" url:"type"`
+	ContentID  string                 `json:"content_id,omitempty" url:"content_id,omitempty"`
+	UserID     string                 `json:"user_id,omitempty" url:"user_id,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty" url:"parameters,omitempty"`
+	Timestamp  time.Time              `json:"timestamp" url:"timestamp"`
 }
 
 // AlgorithmResponse represents a response from the algorithm service
@@ -405,7 +423,41 @@ type ConsensusUpdate struct {
 	State        string    `json:"state"` // FORMING, REACHED, CHALLENGED
 	Score        float64   `json:"score"` // Current consensus score
 	Participants int       `json:"participants"`
-	Timestamp    time.Time `json:"timestamp"`
+	Timestamp    BabelTime `json:"timestamp"`
+}
+
+// EventSubscriber represents a third-party service (moderation bot,
+// analytics pipeline, push gateway) registered to receive signed outbound
+// events over webhook or WebSocket delivery.
+type EventSubscriber struct {
+	ID          string    `json:"id"`
+	OwnerID     string    `json:"owner_id"`
+	Secret      string    `json:"secret"`
+	Topics      []string  `json:"topics"`       // Event types to deliver, e.g. CONTENT_UPDATE
+	DeliveryURL string    `json:"delivery_url"` // Webhook endpoint; ws:// or wss:// for outbound WebSocket delivery
+	Transport   string    `json:"transport"`    // webhook or websocket
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// Subscriber delivery transports
+const (
+	SubscriberTransportWebhook   = "webhook"
+	SubscriberTransportWebSocket = "websocket"
+)
+
+// DeadLetterEvent records an outbound event delivery that exhausted its
+// retries so operators can inspect and optionally replay it.
+type DeadLetterEvent struct {
+	ID            string    `json:"id"`
+	SubscriberID  string    `json:"subscriber_id"`
+	EventType     string    `json:"event_type"`
+	Payload       string    `json:"payload"`
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"last_error"`
+	FirstAttempt  time.Time `json:"first_attempt"`
+	LastAttempt   time.Time `json:"last_attempt"`
 }
 
 // ReputationUpdate represents a change in user reputation
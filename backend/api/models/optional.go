@@ -0,0 +1,75 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+var jsonNull = []byte("null")
+
+// Optional wraps a value that may be present-but-zero, present, or entirely
+// absent from a JSON payload. This disambiguates partial-update ("PATCH
+// style") requests: a model like Content or Vote mixes pointers, omitempty,
+// and zero values to mean "unset", which makes it impossible to tell a
+// deliberate zero value (e.g. TruthScore: 0.0) apart from a field the caller
+// never sent. Optional[T] fixes that by tracking whether the field was set
+// at all, independent of the value itself.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// NewOptional returns an Optional wrapping v with Set true.
+func NewOptional[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// IsSet reports whether the field was present in the original payload.
+func (o Optional[T]) IsSet() bool {
+	return o.Set
+}
+
+// ValueOr returns the wrapped value if set, otherwise def.
+func (o Optional[T]) ValueOr(def T) T {
+	if o.Set {
+		return o.Value
+	}
+	return def
+}
+
+// Patch writes o's value onto dst when o is set, and is a no-op otherwise.
+// It's the single-field building block EditContent/EditUser/EditVote use to
+// apply themselves onto the underlying model.
+func Patch[T any](dst *T, o Optional[T]) {
+	if o.Set {
+		*dst = o.Value
+	}
+}
+
+// MarshalJSON emits the raw value when Set is true, and null otherwise so
+// that omitted and explicitly-null fields still round-trip through
+// encoding/json without an extra omitempty tag.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set {
+		return jsonNull, nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON marks the field unset on a JSON null and parses the value
+// otherwise. UnmarshalJSON is only invoked when the key is present in the
+// payload at all, so a field omitted entirely from the JSON object leaves
+// the zero-value Optional (Set: false) untouched.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), jsonNull) {
+		o.Set = false
+		var zero T
+		o.Value = zero
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.Value); err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptionalRoundTripMixedFields(t *testing.T) {
+	type payload struct {
+		Set     Optional[string]  `json:"set"`
+		Zero    Optional[float64] `json:"zero"`
+		Null    Optional[string]  `json:"null"`
+		Omitted Optional[string]  `json:"omitted"`
+	}
+
+	input := `{"set":"hello","zero":0,"null":null}`
+
+	var p payload
+	if err := json.Unmarshal([]byte(input), &p); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if !p.Set.IsSet() || p.Set.Value != "hello" {
+		t.Errorf("Set: got %+v, want Set=true Value=hello", p.Set)
+	}
+	if !p.Zero.IsSet() || p.Zero.Value != 0 {
+		t.Errorf("Zero: got %+v, want Set=true Value=0 (explicit zero must be preserved)", p.Zero)
+	}
+	if p.Null.IsSet() {
+		t.Errorf("Null: got Set=true, want Set=false for an explicit JSON null")
+	}
+	if p.Omitted.IsSet() {
+		t.Errorf("Omitted: got Set=true, want Set=false for a key never present in the payload")
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var roundTripped payload
+	if err := json.Unmarshal(out, &roundTripped); err != nil {
+		t.Fatalf("re-unmarshal: %v", err)
+	}
+	if roundTripped != p {
+		t.Errorf("round-trip mismatch: got %+v, want %+v", roundTripped, p)
+	}
+}
+
+func TestOptionalValueOr(t *testing.T) {
+	var unset Optional[int]
+	if got := unset.ValueOr(42); got != 42 {
+		t.Errorf("ValueOr on unset: got %d, want 42", got)
+	}
+
+	set := NewOptional(7)
+	if got := set.ValueOr(42); got != 7 {
+		t.Errorf("ValueOr on set: got %d, want 7", got)
+	}
+}
+
+func TestPatchAppliesOnlySetFields(t *testing.T) {
+	var truthScore float64 = 0.5
+	Patch(&truthScore, NewOptional(0.9))
+	if truthScore != 0.9 {
+		t.Errorf("Patch with set Optional: got %v, want 0.9", truthScore)
+	}
+
+	var unchanged = "original"
+	Patch(&unchanged, Optional[string]{})
+	if unchanged != "original" {
+		t.Errorf("Patch with unset Optional: got %q, want unchanged %q", unchanged, "original")
+	}
+}
+
+func TestEditContentApplyTo(t *testing.T) {
+	c := &Content{
+		ContentText: "before",
+		TruthScore:  0.1,
+		Topics:      []string{"old"},
+	}
+
+	edit := EditContent{
+		ContentText: NewOptional("after"),
+		TruthScore:  NewOptional(0.0), // explicit zero, must still apply
+	}
+	edit.ApplyTo(c)
+
+	if c.ContentText != "after" {
+		t.Errorf("ContentText: got %q, want %q", c.ContentText, "after")
+	}
+	if c.TruthScore != 0.0 {
+		t.Errorf("TruthScore: got %v, want explicit zero 0.0", c.TruthScore)
+	}
+	if len(c.Topics) != 1 || c.Topics[0] != "old" {
+		t.Errorf("Topics: got %v, want unchanged [old] since it was never set on the edit", c.Topics)
+	}
+}
@@ -0,0 +1,328 @@
+// Package query encodes and decodes the request structs in models (e.g.
+// FeedRequest, AlgorithmRequest, ListEvidence) to and from url.Values,
+// following the `url:"name,omitempty"` tags that sit alongside their `json`
+// tags. This lets the same struct back both a POST body and a GET query
+// string: POST /feed sends JSON, GET /feed?... sends the same fields
+// through Encode/DecodeFromValues.
+package query
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Encode converts a request struct (or pointer to one) into url.Values.
+// Map fields are flattened with dotted keys (filters.topic=go), slice
+// fields are repeated (topics=a&topics=b), and Optional[T] fields are
+// omitted entirely when unset.
+func Encode(v interface{}) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query: Encode requires a struct, got %s", rv.Kind())
+	}
+
+	values := url.Values{}
+	if err := encodeStruct(values, "", rv); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func encodeStruct(values url.Values, prefix string, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+		name, omitempty := parseTag(tag, field.Name)
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		fv := rv.Field(i)
+		if ov, ok := optionalValue(fv); ok {
+			if !ov.IsValid() {
+				continue // unset Optional, omit entirely
+			}
+			fv = ov
+		} else if omitempty && fv.IsZero() {
+			continue
+		}
+
+		if err := encodeValue(values, key, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeValue(values url.Values, key string, fv reflect.Value) error {
+	switch fv.Kind() {
+	case reflect.Map:
+		keys := fv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+		for _, mk := range keys {
+			if err := encodeValue(values, key+"."+fmt.Sprint(mk.Interface()), indirectInterface(fv.MapIndex(mk))); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for j := 0; j < fv.Len(); j++ {
+			if err := encodeValue(values, key, indirectInterface(fv.Index(j))); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		if fv.IsNil() {
+			return nil
+		}
+		return encodeValue(values, key, fv.Elem())
+	case reflect.Interface:
+		if fv.IsNil() {
+			return nil
+		}
+		return encodeValue(values, key, fv.Elem())
+	case reflect.Struct:
+		return encodeStruct(values, key, fv)
+	default:
+		values.Add(key, scalarToString(fv))
+	}
+	return nil
+}
+
+// DecodeFromValues is the inverse of Encode: it populates dst (a pointer to
+// a request struct) from url.Values using the same `url` tags.
+func DecodeFromValues(v url.Values, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("query: DecodeFromValues requires a non-nil pointer")
+	}
+	return decodeStruct(v, "", rv.Elem())
+}
+
+func decodeStruct(v url.Values, prefix string, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		tag := field.Tag.Get("url")
+		if tag == "-" {
+			continue
+		}
+		name, _ := parseTag(tag, field.Name)
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		fv := rv.Field(i)
+		if setter, ok := optionalSetter(fv); ok {
+			if !anyKeyWithPrefix(v, key) {
+				continue
+			}
+			if err := decodeValue(v, key, setter); err != nil {
+				return err
+			}
+			markOptionalSet(fv)
+			continue
+		}
+
+		if err := decodeValue(v, key, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeValue(v url.Values, key string, fv reflect.Value) error {
+	switch fv.Kind() {
+	case reflect.Map:
+		prefix := key + "."
+		if fv.IsNil() {
+			fv.Set(reflect.MakeMap(fv.Type()))
+		}
+		seen := map[string]bool{}
+		for k := range v {
+			if !strings.HasPrefix(k, prefix) || seen[k] {
+				continue
+			}
+			mapKey := strings.TrimPrefix(k, prefix)
+			fv.SetMapIndex(reflect.ValueOf(mapKey), reflect.ValueOf(inferScalar(v.Get(k))))
+		}
+		return nil
+	case reflect.Slice:
+		raw, ok := v[key]
+		if !ok {
+			return nil
+		}
+		elemType := fv.Type().Elem()
+		slice := reflect.MakeSlice(fv.Type(), len(raw), len(raw))
+		for i, s := range raw {
+			ev, err := parseScalar(s, elemType)
+			if err != nil {
+				return err
+			}
+			slice.Index(i).Set(ev)
+		}
+		fv.Set(slice)
+		return nil
+	default:
+		raw, ok := v[key]
+		if !ok || len(raw) == 0 {
+			return nil
+		}
+		ev, err := parseScalar(raw[0], fv.Type())
+		if err != nil {
+			return err
+		}
+		fv.Set(ev)
+		return nil
+	}
+}
+
+// optionalValue returns the underlying Value field of an Optional[T] if fv
+// is set, ok=false if fv isn't an Optional at all, and a zero
+// reflect.Value (invalid) if it's an unset Optional.
+func optionalValue(fv reflect.Value) (reflect.Value, bool) {
+	if !isOptional(fv.Type()) {
+		return reflect.Value{}, false
+	}
+	if !fv.FieldByName("Set").Bool() {
+		return reflect.Value{}, true
+	}
+	return fv.FieldByName("Value"), true
+}
+
+// optionalSetter returns the addressable Value field to decode into, for
+// Optional[T] fields being populated by DecodeFromValues.
+func optionalSetter(fv reflect.Value) (reflect.Value, bool) {
+	if !isOptional(fv.Type()) {
+		return reflect.Value{}, false
+	}
+	return fv.FieldByName("Value"), true
+}
+
+func markOptionalSet(fv reflect.Value) {
+	fv.FieldByName("Set").SetBool(true)
+}
+
+func isOptional(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct &&
+		t.NumField() == 2 &&
+		t.Field(0).Name == "Value" &&
+		t.Field(1).Name == "Set" &&
+		t.Field(1).Type.Kind() == reflect.Bool
+}
+
+func anyKeyWithPrefix(v url.Values, key string) bool {
+	if _, ok := v[key]; ok {
+		return true
+	}
+	prefix := key + "."
+	for k := range v {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func indirectInterface(rv reflect.Value) reflect.Value {
+	if rv.Kind() == reflect.Interface && !rv.IsNil() {
+		return rv.Elem()
+	}
+	return rv
+}
+
+func parseTag(tag, fallback string) (name string, omitempty bool) {
+	if tag == "" {
+		return fallback, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = fallback
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty
+}
+
+func scalarToString(fv reflect.Value) string {
+	switch fv.Kind() {
+	case reflect.String:
+		return fv.String()
+	case reflect.Bool:
+		return strconv.FormatBool(fv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(fv.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(fv.Interface())
+	}
+}
+
+func parseScalar(s string, t reflect.Type) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(s).Convert(t), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(b), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(n).Convert(t), nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(f).Convert(t), nil
+	case reflect.Interface:
+		return reflect.ValueOf(inferScalar(s)), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("query: unsupported scalar kind %s", t.Kind())
+	}
+}
+
+// inferScalar parses a raw query value into a bool, float64, or string, for
+// map[string]interface{} fields where we don't know the declared type.
+func inferScalar(s string) interface{} {
+	if b, err := strconv.ParseBool(s); err == nil {
+		return b
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
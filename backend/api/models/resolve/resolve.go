@@ -0,0 +1,184 @@
+// Package resolve fans a single ambiguous query string (an ID, a username,
+// a content hash, a text fragment) out across a pool of candidates,
+// walking a priority-ordered list of checkers until exactly one match is
+// found. Modeled on the Discord bot RoleCheckFuncs/MemberCheckFuncs
+// pattern: each checker is tried in order from most to least exact, so
+// "does this look like an ID" is never beaten out by "does this look like
+// a substring".
+package resolve
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// UserCheck is a single user-matching rule: does u match query q.
+type UserCheck func(u *models.User, q string) bool
+
+// UserChecks bitmask flags, one bit per entry in UserCheckFuncs, in the
+// same priority order. Pass 0 (or AllUserChecks) to try every rule.
+type UserChecks uint
+
+const (
+	CheckUserID UserChecks = 1 << iota
+	CheckUserPublicKey
+	CheckUserUsername
+	CheckUserUsernameLower
+	CheckUserPrefix
+	CheckUserSubstring
+
+	AllUserChecks = CheckUserID | CheckUserPublicKey | CheckUserUsername |
+		CheckUserUsernameLower | CheckUserPrefix | CheckUserSubstring
+)
+
+// UserCheckFuncs holds the resolution rules in priority order: exact ID,
+// exact public key, exact username, case-insensitive username, username
+// prefix, then username substring. ResolveUser walks them in this order
+// and stops at the first rule that matches anyone: exactly one match is
+// returned, more than one is an ambiguity error.
+var UserCheckFuncs = []UserCheck{
+	func(u *models.User, q string) bool { return u.ID == q },
+	func(u *models.User, q string) bool { return u.PublicKey == q },
+	func(u *models.User, q string) bool { return u.Username == q },
+	func(u *models.User, q string) bool { return strings.EqualFold(u.Username, q) },
+	func(u *models.User, q string) bool { return strings.HasPrefix(strings.ToLower(u.Username), strings.ToLower(q)) },
+	func(u *models.User, q string) bool { return strings.Contains(strings.ToLower(u.Username), strings.ToLower(q)) },
+}
+
+// ResolveUser walks UserCheckFuncs (restricted to the rules set in
+// allowed, or all of them if allowed is 0) in priority order and stops at
+// the first rule that matches any user in pool: a single match is returned
+// along with the rule's bit, several matches return an ambiguity error and
+// later rules are not tried. Moderation-style callers that must not fuzzy-match
+// should pass CheckUserID (or CheckUserID|CheckUserPublicKey) as allowed.
+func ResolveUser(pool []*models.User, q string, allowed UserChecks) (*models.User, UserChecks, error) {
+	for i, check := range UserCheckFuncs {
+		bit := UserChecks(1) << uint(i)
+		if allowed != 0 && allowed&bit == 0 {
+			continue
+		}
+		var match *models.User
+		count := 0
+		for _, u := range pool {
+			if check(u, q) {
+				match = u
+				count++
+			}
+		}
+		switch count {
+		case 0:
+			continue
+		case 1:
+			return match, bit, nil
+		default:
+			return nil, bit, fmt.Errorf("resolve: %q is ambiguous (%d users matched rule %d)", q, count, i)
+		}
+	}
+	return nil, 0, fmt.Errorf("resolve: no user found matching %q", q)
+}
+
+// ResolveUsers returns every user matching q under the first rule (in
+// priority order, restricted to allowed) that matches anyone at all.
+func ResolveUsers(pool []*models.User, q string, allowed UserChecks) ([]*models.User, UserChecks, error) {
+	for i, check := range UserCheckFuncs {
+		bit := UserChecks(1) << uint(i)
+		if allowed != 0 && allowed&bit == 0 {
+			continue
+		}
+		var matches []*models.User
+		for _, u := range pool {
+			if check(u, q) {
+				matches = append(matches, u)
+			}
+		}
+		if len(matches) > 0 {
+			return matches, bit, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("resolve: no user found matching %q", q)
+}
+
+// ContentCheck is a single content-matching rule: does c match query q.
+type ContentCheck func(c *models.Content, q string) bool
+
+// ContentChecks bitmask flags, one bit per entry in ContentCheckFuncs.
+type ContentChecks uint
+
+const (
+	CheckContentID ContentChecks = 1 << iota
+	CheckContentHash
+	CheckContentTextPrefix
+	CheckContentTopic
+
+	AllContentChecks = CheckContentID | CheckContentHash | CheckContentTextPrefix | CheckContentTopic
+)
+
+// ContentCheckFuncs holds the resolution rules in priority order: exact
+// ID, exact hash, a prefix of ContentText, then topic membership.
+var ContentCheckFuncs = []ContentCheck{
+	func(c *models.Content, q string) bool { return c.ID == q },
+	func(c *models.Content, q string) bool { return c.Hash == q },
+	func(c *models.Content, q string) bool { return strings.HasPrefix(c.ContentText, q) },
+	func(c *models.Content, q string) bool {
+		for _, topic := range c.Topics {
+			if strings.EqualFold(topic, q) {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// ResolveContent walks ContentCheckFuncs (restricted to allowed, or all of
+// them if allowed is 0) in priority order and stops at the first rule that
+// matches any content: a single match is returned along with the rule's
+// bit, several matches return an ambiguity error.
+func ResolveContent(pool []*models.Content, q string, allowed ContentChecks) (*models.Content, ContentChecks, error) {
+	for i, check := range ContentCheckFuncs {
+		bit := ContentChecks(1) << uint(i)
+		if allowed != 0 && allowed&bit == 0 {
+			continue
+		}
+		var match *models.Content
+		count := 0
+		for _, c := range pool {
+			if check(c, q) {
+				match = c
+				count++
+			}
+		}
+		switch count {
+		case 0:
+			continue
+		case 1:
+			return match, bit, nil
+		default:
+			return nil, bit, fmt.Errorf("resolve: %q is ambiguous (%d content items matched rule %d)", q, count, i)
+		}
+	}
+	return nil, 0, fmt.Errorf("resolve: no content found matching %q", q)
+}
+
+// ResolveContents returns every piece of content matching q under the
+// first rule (in priority order, restricted to allowed) that matches
+// anything at all.
+func ResolveContents(pool []*models.Content, q string, allowed ContentChecks) ([]*models.Content, ContentChecks, error) {
+	for i, check := range ContentCheckFuncs {
+		bit := ContentChecks(1) << uint(i)
+		if allowed != 0 && allowed&bit == 0 {
+			continue
+		}
+		var matches []*models.Content
+		for _, c := range pool {
+			if check(c, q) {
+				matches = append(matches, c)
+			}
+		}
+		if len(matches) > 0 {
+			return matches, bit, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("resolve: no content found matching %q", q)
+}
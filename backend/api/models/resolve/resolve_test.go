@@ -0,0 +1,117 @@
+package resolve
+
+import (
+	"testing"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+func users() []*models.User {
+	return []*models.User{
+		{ID: "u1", PublicKey: "pk1", Username: "Alice"},
+		{ID: "u2", PublicKey: "pk2", Username: "alice_2"},
+		{ID: "u3", PublicKey: "pk3", Username: "Bob"},
+	}
+}
+
+func TestResolveUserPriorityOrder(t *testing.T) {
+	pool := users()
+
+	// Exact ID is the highest-priority rule, so an ID query resolves under
+	// CheckUserID.
+	match, rule, err := ResolveUser(pool, "u1", 0)
+	if err != nil {
+		t.Fatalf("ResolveUser(u1): %v", err)
+	}
+	if match.ID != "u1" || rule != CheckUserID {
+		t.Errorf("got match=%v rule=%v, want u1/CheckUserID", match, rule)
+	}
+
+	// No exact ID/username match for "ALICE", but case-insensitive username
+	// matches exactly one user.
+	match, rule, err = ResolveUser(pool, "ALICE", 0)
+	if err != nil {
+		t.Fatalf("ResolveUser(ALICE): %v", err)
+	}
+	if match.ID != "u1" || rule != CheckUserUsernameLower {
+		t.Errorf("got match=%v rule=%v, want u1/CheckUserUsernameLower", match, rule)
+	}
+}
+
+func TestResolveUserAmbiguous(t *testing.T) {
+	pool := users()
+
+	// "ali" isn't an exact (case-insensitive) username match for either
+	// user, but prefix-matches both Alice and alice_2.
+	_, _, err := ResolveUser(pool, "ali", 0)
+	if err == nil {
+		t.Fatal("expected an ambiguous-match error, got nil")
+	}
+}
+
+func TestResolveUserAllowedChecksRestrictsFuzzyMatching(t *testing.T) {
+	pool := users()
+
+	// With only CheckUserID allowed, a username-only query must fail
+	// outright rather than falling through to prefix/substring rules -
+	// the guarantee moderation-style callers depend on.
+	_, _, err := ResolveUser(pool, "Alice", CheckUserID)
+	if err == nil {
+		t.Fatal("expected no match with AllowedChecks=CheckUserID for a username query, got a match")
+	}
+}
+
+func TestResolveUsersReturnsAllMatchesUnderFirstMatchingRule(t *testing.T) {
+	pool := users()
+
+	matches, rule, err := ResolveUsers(pool, "ali", 0)
+	if err != nil {
+		t.Fatalf("ResolveUsers(ali): %v", err)
+	}
+	if rule != CheckUserPrefix {
+		t.Errorf("got rule=%v, want CheckUserPrefix", rule)
+	}
+	if len(matches) != 2 {
+		t.Errorf("got %d matches, want 2 (Alice and alice_2)", len(matches))
+	}
+}
+
+func contentPool() []*models.Content {
+	return []*models.Content{
+		{ID: "c1", Hash: "h1", ContentText: "breaking news today", Topics: []string{"politics"}},
+		{ID: "c2", Hash: "h2", ContentText: "breaking weather update", Topics: []string{"weather"}},
+	}
+}
+
+func TestResolveContentPriorityOrder(t *testing.T) {
+	pool := contentPool()
+
+	match, rule, err := ResolveContent(pool, "h2", 0)
+	if err != nil {
+		t.Fatalf("ResolveContent(h2): %v", err)
+	}
+	if match.ID != "c2" || rule != CheckContentHash {
+		t.Errorf("got match=%v rule=%v, want c2/CheckContentHash", match, rule)
+	}
+}
+
+func TestResolveContentAmbiguousPrefix(t *testing.T) {
+	pool := contentPool()
+
+	_, _, err := ResolveContent(pool, "breaking", 0)
+	if err == nil {
+		t.Fatal("expected an ambiguous-match error for a prefix matching both content items, got nil")
+	}
+}
+
+func TestResolveContentTopicMatch(t *testing.T) {
+	pool := contentPool()
+
+	match, rule, err := ResolveContent(pool, "Weather", 0)
+	if err != nil {
+		t.Fatalf("ResolveContent(Weather): %v", err)
+	}
+	if match.ID != "c2" || rule != CheckContentTopic {
+		t.Errorf("got match=%v rule=%v, want c2/CheckContentTopic", match, rule)
+	}
+}
@@ -3,12 +3,43 @@
 package api
 
 import (
-    "github.com/gin-gonic/gin"
+    "bytes"
+    "encoding/json"
     "net/http"
+
+    "github.com/gin-gonic/gin"
+
+    "github.com/saint/babel-protocol/backend/internal/metrics"
 )
 
+// sendNotificationRequest is the body of a SendNotification request.
+type sendNotificationRequest struct {
+    PushURL string `json:"push_url" binding:"required"`
+    UserID  string `json:"user_id"`
+    Message string `json:"message"`
+}
+
 // SendNotification handles sending notifications to users
 func SendNotification(c *gin.Context) {
-    // Implement logic to send notifications to users
+    var req sendNotificationRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        return
+    }
+
+    body, err := json.Marshal(gin.H{"user_id": req.UserID, "message": req.Message})
+    if err != nil {
+        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        return
+    }
+
+    client := metrics.Default().NewInstrumentedClient("notifications_push")
+    resp, err := client.Post(req.PushURL, "application/json", bytes.NewReader(body))
+    if err != nil {
+        c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+        return
+    }
+    defer resp.Body.Close()
+
     c.JSON(http.StatusOK, gin.H{"message": "Send notification endpoint"})
 }
@@ -5,16 +5,20 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+
+	"github.com/saint/babel-protocol/backend/internal/metrics"
 )
 
 // EstablishConsensus handles establishing consensus on the credibility of content
 func EstablishConsensus(c *gin.Context) {
 	// Implement logic to establish consensus on the credibility of content
+	metrics.ConsensusEstablishedTotal.Inc()
 	c.JSON(http.StatusOK, gin.H{"message": "Establish consensus endpoint"})
 }
 
 // VerifyContent handles allowing users to verify the authenticity of content
 func VerifyContent(c *gin.Context) {
 	// Implement logic for users to verify the authenticity of content
+	metrics.ContentVerifiedTotal.WithLabelValues("ok").Inc()
 	c.JSON(http.StatusOK, gin.H{"message": "Verify content endpoint"})
 }
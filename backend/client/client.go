@@ -0,0 +1,77 @@
+// Package client is the Babel HTTP client SDK. client.gen.go (generated by
+// cmd/gen from the schema document) adds one method per route; this file
+// holds the hand-written runtime those generated methods dispatch through.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/saint/babel-protocol/backend/api/models/query"
+)
+
+// Client is a Babel HTTP client SDK instance.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// New returns a Client targeting baseURL, using httpClient for transport.
+// Callers instrumenting outbound calls should pass
+// metrics.NewInstrumentedClient("<sdk-name>") here.
+func New(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{baseURL: strings.TrimRight(baseURL, "/"), httpClient: httpClient}
+}
+
+// req dispatches a single request: GET requests encode data as a query
+// string, everything else sends it as a JSON body. resp is decoded from
+// the JSON response body.
+func (c *Client) req(ctx context.Context, method, path string, data, resp interface{}) error {
+	reqURL := c.baseURL + path
+
+	var body bytes.Reader
+	if method == http.MethodGet {
+		values, err := query.Encode(data)
+		if err != nil {
+			return fmt.Errorf("client: encoding query: %w", err)
+		}
+		if encoded := values.Encode(); encoded != "" {
+			reqURL += "?" + encoded
+		}
+	} else {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("client: encoding body: %w", err)
+		}
+		body = *bytes.NewReader(payload)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, reqURL, &body)
+	if err != nil {
+		return fmt.Errorf("client: building request: %w", err)
+	}
+	if method != http.MethodGet {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("client: %s %s: %w", method, path, err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("client: %s %s: unexpected status %d", method, path, httpResp.StatusCode)
+	}
+	if resp == nil {
+		return nil
+	}
+	return json.NewDecoder(httpResp.Body).Decode(resp)
+}
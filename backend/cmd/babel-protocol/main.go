@@ -0,0 +1,95 @@
+// Command babel-protocol is an operational CLI for tasks that don't belong
+// behind an HTTP endpoint, starting with database schema migrations:
+//
+//	go run ./cmd/babel-protocol db migrate [up|down|status]
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+	"github.com/saint/babel-protocol/backend/internal/db/sqlite"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "db":
+		err = runDB(os.Args[2:])
+	default:
+		usage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "babel-protocol: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: babel-protocol db migrate [up|down|status]")
+}
+
+func runDB(args []string) error {
+	if len(args) < 1 || args[0] != "migrate" {
+		usage()
+		return fmt.Errorf("unknown db subcommand")
+	}
+
+	direction := "up"
+	if len(args) > 1 {
+		direction = args[1]
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %v", err)
+	}
+
+	store, err := sqlite.NewDBManager(cfg.DatabasePath, cfg.HCS.NodeKey)
+	if err != nil {
+		return fmt.Errorf("opening database: %v", err)
+	}
+
+	ctx := context.Background()
+
+	switch direction {
+	case "up":
+		if err := store.Migrate(ctx); err != nil {
+			return fmt.Errorf("migrating up: %v", err)
+		}
+		version, err := store.SchemaVersion()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("migrated to schema version %d\n", version)
+	case "down":
+		if err := store.MigrateDown(ctx); err != nil {
+			return fmt.Errorf("migrating down: %v", err)
+		}
+		version, err := store.SchemaVersion()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted to schema version %d\n", version)
+	case "status":
+		version, err := store.SchemaVersion()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("schema version %d\n", version)
+	default:
+		usage()
+		return fmt.Errorf("unknown migrate direction %q", direction)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Command conformance loads a versioned directory of scoring conformance
+// vectors (see internal/conformance) and runs them against the content
+// package's scoring functions, failing with a non-zero exit code if any
+// vector drifts beyond epsilon - the same role Lotus's test-conformance
+// job plays for chain state transitions.
+//
+//	go run ./cmd/conformance -vectors internal/conformance/vectors/v1 -epsilon 0.0001
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/saint/babel-protocol/backend/internal/conformance"
+)
+
+func main() {
+	vectorsDir := flag.String("vectors", "internal/conformance/vectors/v1", "directory of conformance vector JSON files")
+	epsilon := flag.Float64("epsilon", 1e-9, "maximum allowed absolute drift between expected and actual scores")
+	flag.Parse()
+
+	vectors, err := conformance.LoadVectors(*vectorsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "conformance: %v\n", err)
+		os.Exit(1)
+	}
+
+	var failed int
+	for _, v := range vectors {
+		drifts := conformance.Evaluate(v, *epsilon)
+		if len(drifts) == 0 {
+			continue
+		}
+		failed++
+		for _, d := range drifts {
+			fmt.Fprintf(os.Stderr, "DRIFT %s: %s expected=%s actual=%s\n", d.Vector, d.Output, d.Expected, d.Actual)
+		}
+	}
+
+	fmt.Printf("conformance: %d/%d vectors passed\n", len(vectors)-failed, len(vectors))
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const genHeader = "// Code generated by cmd/gen from the schema document. DO NOT EDIT.\n// Hand-written helpers for these types belong in the sibling *.go file.\n\n"
+
+// GenerateModels renders models/models.gen.go: one struct per StructDef,
+// with required fields as their declared type and optional fields wrapped
+// in models.Optional[T], each tagged with both json and url names.
+func GenerateModels(schema *Schema) string {
+	var b strings.Builder
+	b.WriteString(genHeader)
+	b.WriteString("package models\n\n")
+	for _, s := range schema.Structs {
+		fmt.Fprintf(&b, "type %s struct {\n", s.Name)
+		for _, f := range s.Fields {
+			typ := f.Type
+			if !f.Required {
+				typ = fmt.Sprintf("Optional[%s]", typ)
+			}
+			omitempty := ""
+			if !f.Required {
+				omitempty = ",omitempty"
+			}
+			fmt.Fprintf(&b, "\t%s %s `json:\"%s%s\" url:\"%s%s\"`\n", f.Name, typ, f.JSON, omitempty, f.JSON, omitempty)
+		}
+		b.WriteString("}\n\n")
+	}
+	return b.String()
+}
+
+// GenerateValidate renders models/validate.gen.go: a Validate() error
+// method per StructDef that checks schema-declared constraints (required
+// non-empty fields, enum membership, numeric min/max).
+func GenerateValidate(schema *Schema) string {
+	var b strings.Builder
+	b.WriteString(genHeader)
+	b.WriteString("package models\n\n")
+	b.WriteString("import \"fmt\"\n\n")
+	for _, s := range schema.Structs {
+		fmt.Fprintf(&b, "func (v *%s) Validate() error {\n", s.Name)
+		for _, f := range s.Fields {
+			accessor := "v." + f.Name
+			if !f.Required {
+				accessor += ".Value"
+				fmt.Fprintf(&b, "\tif v.%s.IsSet() {\n", f.Name)
+			}
+			indent := "\t"
+			if !f.Required {
+				indent = "\t\t"
+			}
+			if f.Required && (f.Type == "string" || strings.HasPrefix(f.Type, "[]")) {
+				fmt.Fprintf(&b, "%sif len(%s) == 0 {\n%s\treturn fmt.Errorf(\"%s: %s is required\")\n%s}\n",
+					indent, accessor, indent, s.Name, f.JSON, indent)
+			}
+			if len(f.Enum) > 0 {
+				quoted := make([]string, len(f.Enum))
+				for i, e := range f.Enum {
+					quoted[i] = fmt.Sprintf("%q", e)
+				}
+				fmt.Fprintf(&b, "%sswitch %s {\n%scase %s:\n%sdefault:\n%s\treturn fmt.Errorf(\"%s: invalid %s: %%v\", %s)\n%s}\n",
+					indent, accessor, indent, strings.Join(quoted, ", "), indent, indent, s.Name, f.JSON, accessor, indent)
+			}
+			if f.Min != nil || f.Max != nil {
+				lo, hi := "", ""
+				if f.Min != nil {
+					lo = fmt.Sprintf("%v", *f.Min)
+				}
+				if f.Max != nil {
+					hi = fmt.Sprintf("%v", *f.Max)
+				}
+				switch {
+				case f.Min != nil && f.Max != nil:
+					fmt.Fprintf(&b, "%sif %s < %s || %s > %s {\n%s\treturn fmt.Errorf(\"%s: %s must be between %s and %s\")\n%s}\n",
+						indent, accessor, lo, accessor, hi, indent, s.Name, f.JSON, lo, hi, indent)
+				case f.Min != nil:
+					fmt.Fprintf(&b, "%sif %s < %s {\n%s\treturn fmt.Errorf(\"%s: %s must be >= %s\")\n%s}\n",
+						indent, accessor, lo, indent, s.Name, f.JSON, lo, indent)
+				case f.Max != nil:
+					fmt.Fprintf(&b, "%sif %s > %s {\n%s\treturn fmt.Errorf(\"%s: %s must be <= %s\")\n%s}\n",
+						indent, accessor, hi, indent, s.Name, f.JSON, hi, indent)
+				}
+			}
+			if !f.Required {
+				b.WriteString("\t}\n")
+			}
+		}
+		b.WriteString("\treturn nil\n}\n\n")
+	}
+	return b.String()
+}
+
+// GenerateClient renders client/client.gen.go: one method per RouteDef,
+// dispatching through the hand-written Client.req runtime. GET routes
+// encode their param struct as a query string; every other method sends
+// it as a JSON body.
+func GenerateClient(schema *Schema) string {
+	routes := append([]RouteDef(nil), schema.Routes...)
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Name < routes[j].Name })
+
+	var b strings.Builder
+	b.WriteString(genHeader)
+	b.WriteString("package client\n\n")
+	b.WriteString("import (\n\t\"context\"\n\n\t\"github.com/saint/babel-protocol/backend/api/models\"\n)\n\n")
+	for _, r := range routes {
+		fmt.Fprintf(&b, "func (c *Client) %s(ctx context.Context, data *models.%s) (*models.%s, error) {\n", r.Name, r.Param, r.Return)
+		fmt.Fprintf(&b, "\tvar resp models.%s\n", r.Return)
+		fmt.Fprintf(&b, "\tif err := c.req(ctx, %q, %q, data, &resp); err != nil {\n\t\treturn nil, err\n\t}\n", r.Method, r.Path)
+		b.WriteString("\treturn &resp, nil\n}\n\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,53 @@
+// Command gen reads a schema document (see Schema in schema.go) and emits
+// the generated model, client, and validation files that other SDKs can
+// regenerate from the same source of truth:
+//
+//	go run ./cmd/gen -schema schema.json -models-out api/models/models.gen.go \
+//		-client-out client/client.gen.go -validate-out api/models/validate.gen.go
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	schemaPath := flag.String("schema", "schema.json", "path to the schema JSON document")
+	modelsOut := flag.String("models-out", "", "output path for the generated models file")
+	clientOut := flag.String("client-out", "", "output path for the generated client file")
+	validateOut := flag.String("validate-out", "", "output path for the generated validation file")
+	flag.Parse()
+
+	data, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gen: reading schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	var schema Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		fmt.Fprintf(os.Stderr, "gen: parsing schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	outputs := []struct {
+		path string
+		gen  func(*Schema) string
+	}{
+		{*modelsOut, GenerateModels},
+		{*clientOut, GenerateClient},
+		{*validateOut, GenerateValidate},
+	}
+
+	for _, o := range outputs {
+		if o.path == "" {
+			continue
+		}
+		if err := os.WriteFile(o.path, []byte(o.gen(&schema)), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "gen: writing %s: %v\n", o.path, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+// Schema is the single source of truth this tool generates from: a list
+// of struct definitions (shared by models, the client, and validation) and
+// a list of routes that wire a param struct and a return struct to an HTTP
+// method + path. The Python/TypeScript SDKs are expected to grow their own
+// readers for the same document so every language stays in sync.
+type Schema struct {
+	Structs []StructDef `json:"structs"`
+	Routes  []RouteDef  `json:"routes"`
+}
+
+// FieldDef describes one struct field. Type is a Go type expression
+// (string, float64, []string, map[string]interface{}, or another
+// StructDef's Name). Fields are required unless Required is false, in
+// which case the generated field is wrapped in models.Optional[T].
+type FieldDef struct {
+	Name     string   `json:"name"`
+	JSON     string   `json:"json"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Enum     []string `json:"enum,omitempty"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+}
+
+// StructDef describes one generated model.
+type StructDef struct {
+	Name   string     `json:"name"`
+	Fields []FieldDef `json:"fields"`
+}
+
+// RouteDef describes one client method / HTTP endpoint.
+type RouteDef struct {
+	Name   string `json:"name"`   // generated method name, e.g. GetFeed
+	Method string `json:"method"` // GET, POST, ...
+	Path   string `json:"path"`   // /feed
+	Param  string `json:"param"`  // StructDef.Name of the request body/query
+	Return string `json:"return"` // StructDef.Name of the response body
+}
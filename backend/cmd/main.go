@@ -3,14 +3,16 @@ package main
 import (
 	"log"
 
+	"github.com/saint/babel-protocol/backend/internal/bus"
 	"github.com/saint/babel-protocol/backend/internal/websocket"
 
 	"github.com/gin-gonic/gin"
 )
 
 func main() {
-	// Initialize WebSocket hub
-	hub := websocket.NewWebSocketHub()
+	// Initialize WebSocket hub. Swap in bus.NewRedisBus(cfg.Redis) once the
+	// service runs behind a load balancer with multiple replicas.
+	hub := websocket.NewWebSocketHub(bus.NewInMemoryBus())
 	go hub.Run()
 
 	// Set up Gin router
@@ -0,0 +1,315 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/google/uuid"
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/bandit"
+)
+
+type experimentDoc struct {
+	ID          string `bson:"_id"`
+	Name        string `bson:"name"`
+	Description string `bson:"description,omitempty"`
+	Status      string `bson:"status"`
+	CreatedAt   int64  `bson:"created_at"`
+}
+
+type experimentArmDoc struct {
+	ID           string  `bson:"_id"`
+	ExperimentID string  `bson:"experiment_id"`
+	Name         string  `bson:"name"`
+	Alpha        float64 `bson:"alpha"`
+	Beta         float64 `bson:"beta"`
+}
+
+func (d *experimentArmDoc) toModel() *models.ExperimentArm {
+	return &models.ExperimentArm{
+		ID:           d.ID,
+		ExperimentID: d.ExperimentID,
+		Name:         d.Name,
+		Alpha:        d.Alpha,
+		Beta:         d.Beta,
+	}
+}
+
+type experimentAssignmentDoc struct {
+	ID           string `bson:"_id"`
+	ExperimentID string `bson:"experiment_id"`
+	UserID       string `bson:"user_id"`
+	ArmID        string `bson:"arm_id"`
+	AssignedAt   int64  `bson:"assigned_at"`
+}
+
+type experimentEventDoc struct {
+	ID           string  `bson:"_id"`
+	ExperimentID string  `bson:"experiment_id"`
+	ArmID        string  `bson:"arm_id"`
+	UserID       string  `bson:"user_id"`
+	Reward       float64 `bson:"reward"`
+	Timestamp    int64   `bson:"timestamp"`
+}
+
+// CreateExperiment inserts experiment and its arms, seeding each arm with
+// a uniform Beta(1, 1) prior if Alpha/Beta aren't already set.
+func (m *Manager) CreateExperiment(experiment *models.Experiment, arms []*models.ExperimentArm) error {
+	ctx := context.Background()
+
+	_, err := m.coll(collExperiments).InsertOne(ctx, &experimentDoc{
+		ID:          experiment.ID,
+		Name:        experiment.Name,
+		Description: experiment.Description,
+		Status:      experiment.Status,
+		CreatedAt:   experiment.CreatedAt.Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create experiment: %v", err)
+	}
+
+	for _, arm := range arms {
+		if arm.Alpha == 0 {
+			arm.Alpha = 1
+		}
+		if arm.Beta == 0 {
+			arm.Beta = 1
+		}
+		arm.ExperimentID = experiment.ID
+
+		_, err := m.coll(collExperimentArms).InsertOne(ctx, &experimentArmDoc{
+			ID:           arm.ID,
+			ExperimentID: arm.ExperimentID,
+			Name:         arm.Name,
+			Alpha:        arm.Alpha,
+			Beta:         arm.Beta,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create experiment arm %s: %v", arm.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// AssignArm returns the arm experimentName has assigned userID, assigning
+// one first if this is their first time in the experiment. Assignment is
+// sticky: once made, repeat calls always return the same arm.
+//
+// A brand-new experiment (no arm has collected any reward data yet) picks
+// via a deterministic hash of userID+experimentID rather than Thompson
+// sampling, so concurrent cold-start assignments split evenly instead of
+// every uninformative Beta(1,1) draw coinciding on the same arm.
+func (m *Manager) AssignArm(experimentName, userID string) (*models.ExperimentArm, error) {
+	experimentID, err := m.experimentIDByName(experimentName)
+	if err != nil {
+		return nil, err
+	}
+
+	if arm, err := m.assignedArm(experimentID, userID); err != nil {
+		return nil, err
+	} else if arm != nil {
+		return arm, nil
+	}
+
+	arms, err := m.experimentArms(experimentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(arms) == 0 {
+		return nil, fmt.Errorf("experiment %q has no arms", experimentName)
+	}
+
+	var armID string
+	if experimentIsWarm(arms) {
+		armID = hashAssign(experimentID, userID, arms)
+	} else {
+		posteriors := make([]bandit.ArmPosterior, len(arms))
+		for i, arm := range arms {
+			posteriors[i] = bandit.ArmPosterior{ArmID: arm.ID, Alpha: arm.Alpha, Beta: arm.Beta}
+		}
+		armID = bandit.SelectArm(posteriors)
+	}
+
+	var chosen *models.ExperimentArm
+	for _, arm := range arms {
+		if arm.ID == armID {
+			chosen = arm
+			break
+		}
+	}
+
+	_, err = m.coll(collExperimentAssignments).InsertOne(context.Background(), &experimentAssignmentDoc{
+		ID:           uuid.New().String(),
+		ExperimentID: experimentID,
+		UserID:       userID,
+		ArmID:        chosen.ID,
+		AssignedAt:   time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record experiment assignment: %v", err)
+	}
+
+	return chosen, nil
+}
+
+// RecordExperimentEvent folds a reward observation (expected in [0, 1])
+// into the posterior of the arm userID was assigned in experimentName.
+func (m *Manager) RecordExperimentEvent(experimentName, userID string, reward float64) error {
+	experimentID, err := m.experimentIDByName(experimentName)
+	if err != nil {
+		return err
+	}
+
+	arm, err := m.assignedArm(experimentID, userID)
+	if err != nil {
+		return err
+	}
+	if arm == nil {
+		return fmt.Errorf("user %s has no assignment in experiment %q", userID, experimentName)
+	}
+
+	newAlpha, newBeta := bandit.UpdatePosterior(arm.Alpha, arm.Beta, reward)
+
+	ctx := context.Background()
+
+	_, err = m.coll(collExperimentEvents).InsertOne(ctx, &experimentEventDoc{
+		ID:           uuid.New().String(),
+		ExperimentID: experimentID,
+		ArmID:        arm.ID,
+		UserID:       userID,
+		Reward:       reward,
+		Timestamp:    time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record experiment event: %v", err)
+	}
+
+	_, err = m.coll(collExperimentArms).UpdateOne(ctx,
+		bson.M{"_id": arm.ID},
+		bson.M{"$set": bson.M{"alpha": newAlpha, "beta": newBeta}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update arm posterior: %v", err)
+	}
+
+	return nil
+}
+
+// GetArmStats returns every arm of experimentName with its current
+// posterior, assignment count, and event count, feeding the dashboards
+// that watch algorithm_metrics.
+func (m *Manager) GetArmStats(experimentName string) ([]*models.ArmStats, error) {
+	experimentID, err := m.experimentIDByName(experimentName)
+	if err != nil {
+		return nil, err
+	}
+
+	arms, err := m.experimentArms(experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	var stats []*models.ArmStats
+	for _, arm := range arms {
+		assignmentCount, err := m.coll(collExperimentAssignments).CountDocuments(ctx, bson.M{"arm_id": arm.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count experiment assignments: %v", err)
+		}
+		eventCount, err := m.coll(collExperimentEvents).CountDocuments(ctx, bson.M{"arm_id": arm.ID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count experiment events: %v", err)
+		}
+
+		stats = append(stats, &models.ArmStats{
+			ArmID:           arm.ID,
+			ArmName:         arm.Name,
+			Alpha:           arm.Alpha,
+			Beta:            arm.Beta,
+			Mean:            arm.Alpha / (arm.Alpha + arm.Beta),
+			AssignmentCount: int(assignmentCount),
+			EventCount:      int(eventCount),
+		})
+	}
+
+	return stats, nil
+}
+
+func (m *Manager) experimentIDByName(name string) (string, error) {
+	var doc experimentDoc
+	err := m.coll(collExperiments).FindOne(context.Background(), bson.M{"name": name}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return "", fmt.Errorf("experiment %q not found", name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up experiment %q: %v", name, err)
+	}
+	return doc.ID, nil
+}
+
+func (m *Manager) experimentArms(experimentID string) ([]*models.ExperimentArm, error) {
+	cursor, err := m.coll(collExperimentArms).Find(context.Background(), bson.M{"experiment_id": experimentID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query experiment arms: %v", err)
+	}
+	defer cursor.Close(context.Background())
+
+	var arms []*models.ExperimentArm
+	for cursor.Next(context.Background()) {
+		var doc experimentArmDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode experiment arm: %v", err)
+		}
+		arms = append(arms, doc.toModel())
+	}
+	return arms, cursor.Err()
+}
+
+// assignedArm returns userID's existing arm assignment in experimentID,
+// or nil if they haven't been assigned one yet.
+func (m *Manager) assignedArm(experimentID, userID string) (*models.ExperimentArm, error) {
+	var assignment experimentAssignmentDoc
+	err := m.coll(collExperimentAssignments).FindOne(context.Background(),
+		bson.M{"experiment_id": experimentID, "user_id": userID},
+	).Decode(&assignment)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up experiment assignment: %v", err)
+	}
+
+	var arm experimentArmDoc
+	err = m.coll(collExperimentArms).FindOne(context.Background(), bson.M{"_id": assignment.ArmID}).Decode(&arm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up assigned arm: %v", err)
+	}
+
+	return arm.toModel(), nil
+}
+
+// experimentIsWarm reports whether every arm is still at its initial
+// uniform prior, i.e. no reward data has been collected yet.
+func experimentIsWarm(arms []*models.ExperimentArm) bool {
+	for _, arm := range arms {
+		if arm.Alpha != 1 || arm.Beta != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// hashAssign deterministically splits userID across arms, keyed by
+// experimentID so the same user lands on different arms in different
+// experiments.
+func hashAssign(experimentID, userID string, arms []*models.ExperimentArm) string {
+	h := fnv.New32a()
+	h.Write([]byte(experimentID + ":" + userID))
+	return arms[int(h.Sum32())%len(arms)].ID
+}
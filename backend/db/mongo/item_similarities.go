@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// itemSimilarityDoc is one (item_id, neighbor_id) row. _id is derived from
+// both so re-running UpsertItemSimilarities for an item is a clean
+// delete-then-insert rather than an accumulating set.
+type itemSimilarityDoc struct {
+	ID         string  `bson:"_id"`
+	ItemID     string  `bson:"item_id"`
+	NeighborID string  `bson:"neighbor_id"`
+	Similarity float64 `bson:"similarity"`
+	ComputedAt int64   `bson:"computed_at"`
+}
+
+func itemSimilarityDocID(itemID, neighborID string) string {
+	return itemID + ":" + neighborID
+}
+
+// UpsertItemSimilarities replaces itemID's stored neighbor list with
+// neighbors, so a background refresh never leaves a stale neighbor behind
+// once it drops out of the top-K.
+func (m *Manager) UpsertItemSimilarities(itemID string, neighbors []db.ItemSimilarity) error {
+	ctx := context.Background()
+
+	if _, err := m.coll(collItemSimilarities).DeleteMany(ctx, bson.M{"item_id": itemID}); err != nil {
+		return fmt.Errorf("failed to clear item similarities for %s: %v", itemID, err)
+	}
+	if len(neighbors) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(neighbors))
+	for i, n := range neighbors {
+		computedAt := n.ComputedAt
+		if computedAt.IsZero() {
+			computedAt = time.Now()
+		}
+		docs[i] = itemSimilarityDoc{
+			ID:         itemSimilarityDocID(itemID, n.NeighborID),
+			ItemID:     itemID,
+			NeighborID: n.NeighborID,
+			Similarity: n.Similarity,
+			ComputedAt: computedAt.Unix(),
+		}
+	}
+
+	if _, err := m.coll(collItemSimilarities).InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("failed to insert item similarities for %s: %v", itemID, err)
+	}
+	return nil
+}
+
+// GetItemSimilarities returns itemID's stored neighbors in similarity
+// descending order, as last written by UpsertItemSimilarities.
+func (m *Manager) GetItemSimilarities(itemID string) ([]db.ItemSimilarity, error) {
+	ctx := context.Background()
+	cursor, err := m.coll(collItemSimilarities).Find(ctx,
+		bson.M{"item_id": itemID},
+		options.Find().SetSort(bson.D{{Key: "similarity", Value: -1}}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query item similarities for %s: %v", itemID, err)
+	}
+	defer cursor.Close(ctx)
+
+	var neighbors []db.ItemSimilarity
+	for cursor.Next(ctx) {
+		var doc itemSimilarityDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		neighbors = append(neighbors, db.ItemSimilarity{
+			NeighborID: doc.NeighborID,
+			Similarity: doc.Similarity,
+			ComputedAt: time.Unix(doc.ComputedAt, 0),
+		})
+	}
+	return neighbors, cursor.Err()
+}
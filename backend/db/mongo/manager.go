@@ -0,0 +1,84 @@
+// Package mongo is a MongoDB-backed implementation of db.Repository using
+// go.mongodb.org/mongo-driver. Unlike the sqlite/postgres backends, which
+// store evidence_ids, references, metadata, media_urls and session_data as
+// JSON-in-TEXT (or JSONB) blobs, this backend stores them as native BSON
+// documents/arrays so they can be filtered and indexed directly - e.g.
+// `{"evidence_ids": evidenceID}` to find every vote citing a piece of
+// evidence, or `{"topics": "climate"}` to find content by topic, without
+// ever deserializing a blob application-side.
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// Collection names
+const (
+	collContent          = "content"
+	collUsers            = "users"
+	collVotes            = "votes"
+	collEvidence         = "evidence"
+	collAlgorithmCache   = "algorithm_cache"
+	collAlgorithmErrors  = "algorithm_errors"
+	collAlgorithmMetrics = "algorithm_metrics"
+	collUserActivity     = "user_activity"
+	collUserVerification = "user_verification"
+	collDirectMessages   = "direct_messages"
+	collEventSubscribers = "event_subscribers"
+	collEventDeadLetters = "event_dead_letters"
+
+	collExperiments           = "experiments"
+	collExperimentArms        = "experiment_arms"
+	collExperimentAssignments = "experiment_assignments"
+	collExperimentEvents      = "experiment_events"
+
+	collVoteEvents            = "vote_events"
+	collReputationEvents      = "reputation_events"
+	collProjectionCheckpoints = "projection_checkpoints"
+
+	collItemSimilarities = "item_similarities"
+)
+
+// Manager handles database operations against MongoDB.
+type Manager struct {
+	client *mongo.Client
+	db     *mongo.Database
+}
+
+var _ db.Repository = (*Manager)(nil)
+
+// Open connects to uri and wraps the named database. Connection and ping
+// are both bounded by a short timeout since this runs at startup.
+func Open(uri, database string) (*Manager, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mongo: %v", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, fmt.Errorf("failed to ping mongo: %v", err)
+	}
+
+	return &Manager{client: client, db: client.Database(database)}, nil
+}
+
+// Close disconnects the underlying client.
+func (m *Manager) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return m.client.Disconnect(ctx)
+}
+
+func (m *Manager) coll(name string) *mongo.Collection {
+	return m.db.Collection(name)
+}
@@ -0,0 +1,1169 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// contentDoc mirrors models.Content but keeps media_urls, metadata, topics,
+// entities and context_refs as native BSON arrays/documents instead of the
+// JSON-in-TEXT blobs the SQL backends store, so they're directly queryable
+// (e.g. {"topics": "climate"} or {"metadata.source": "reuters"}).
+type contentDoc struct {
+	ID                      string                 `bson:"_id"`
+	AuthorID                string                 `bson:"author_id"`
+	ContentType             string                 `bson:"content_type"`
+	ContentText             string                 `bson:"content_text"`
+	MediaURLs               []string               `bson:"media_urls"`
+	ParentID                *string                `bson:"parent_id,omitempty"`
+	Timestamp               int64                  `bson:"timestamp"`
+	Signature               string                 `bson:"signature"`
+	Hash                    string                 `bson:"hash"`
+	ProcessingStatus        string                 `bson:"processing_status"`
+	LastUpdated             int64                  `bson:"last_updated"`
+	Metadata                map[string]interface{} `bson:"metadata,omitempty"`
+	TruthScore              float64                `bson:"truth_score"`
+	VisibilityScore         float64                `bson:"visibility_score"`
+	EvidenceChains          []string               `bson:"evidence_chains"`
+	Topics                  []string               `bson:"topics"`
+	Entities                []string               `bson:"entities"`
+	ContextRefs             []string               `bson:"context_refs"`
+	ConsensusState          string                 `bson:"consensus_state"`
+	ConsensusScore          float64                `bson:"consensus_score"`
+	ConsensusValidatorCount int                    `bson:"consensus_validator_count"`
+	ConsensusTemporalWeight float64                `bson:"consensus_temporal_weight"`
+}
+
+func toContentDoc(c *models.Content) *contentDoc {
+	return &contentDoc{
+		ID:                      c.ID,
+		AuthorID:                c.AuthorID,
+		ContentType:             c.ContentType,
+		ContentText:             c.ContentText,
+		MediaURLs:               c.MediaURLs,
+		ParentID:                c.ParentID,
+		Timestamp:               c.Timestamp.Unix(),
+		Signature:               c.Signature,
+		Hash:                    c.Hash,
+		ProcessingStatus:        c.ProcessingStatus,
+		LastUpdated:             c.LastUpdated.Unix(),
+		Metadata:                c.Metadata,
+		TruthScore:              c.TruthScore,
+		VisibilityScore:         c.VisibilityScore,
+		EvidenceChains:          c.EvidenceChains,
+		Topics:                  c.Topics,
+		Entities:                c.Entities,
+		ContextRefs:             c.ContextRefs,
+		ConsensusState:          c.Consensus.State,
+		ConsensusScore:          c.Consensus.Score,
+		ConsensusValidatorCount: c.Consensus.ValidatorCount,
+		ConsensusTemporalWeight: c.Consensus.TemporalWeight,
+	}
+}
+
+func (d *contentDoc) toModel() *models.Content {
+	return &models.Content{
+		ID:               d.ID,
+		AuthorID:         d.AuthorID,
+		ContentType:      d.ContentType,
+		ContentText:      d.ContentText,
+		MediaURLs:        d.MediaURLs,
+		ParentID:         d.ParentID,
+		Timestamp:        models.NewBabelTime(time.Unix(d.Timestamp, 0)),
+		Signature:        d.Signature,
+		Hash:             d.Hash,
+		ProcessingStatus: d.ProcessingStatus,
+		LastUpdated:      time.Unix(d.LastUpdated, 0),
+		Metadata:         d.Metadata,
+		TruthScore:       d.TruthScore,
+		VisibilityScore:  d.VisibilityScore,
+		EvidenceChains:   d.EvidenceChains,
+		Topics:           d.Topics,
+		Entities:         d.Entities,
+		ContextRefs:      d.ContextRefs,
+		Consensus: models.ConsensusInfo{
+			State:          d.ConsensusState,
+			Score:          d.ConsensusScore,
+			ValidatorCount: d.ConsensusValidatorCount,
+			TemporalWeight: d.ConsensusTemporalWeight,
+		},
+	}
+}
+
+func (m *Manager) CreateContent(content *models.Content) error {
+	_, err := m.coll(collContent).InsertOne(context.Background(), toContentDoc(content))
+	return err
+}
+
+func (m *Manager) GetContent(id string) (*models.Content, error) {
+	var doc contentDoc
+	err := m.coll(collContent).FindOne(context.Background(), bson.M{"_id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.toModel(), nil
+}
+
+func (m *Manager) UpdateContent(content *models.Content) error {
+	_, err := m.coll(collContent).UpdateOne(context.Background(),
+		bson.M{"_id": content.ID, "author_id": content.AuthorID},
+		bson.M{"$set": bson.M{
+			"content_type":      content.ContentType,
+			"content_text":      content.ContentText,
+			"media_urls":        content.MediaURLs,
+			"parent_id":         content.ParentID,
+			"signature":         content.Signature,
+			"hash":              content.Hash,
+			"processing_status": content.ProcessingStatus,
+			"last_updated":      time.Now().Unix(),
+		}},
+	)
+	return err
+}
+
+// UpdateContentBatch updates multiple content entries. Mongo has no
+// multi-document transaction requirement here since each document update is
+// independently atomic; an unordered bulk write keeps this a single round
+// trip.
+func (m *Manager) UpdateContentBatch(contents []*models.Content) error {
+	if len(contents) == 0 {
+		return nil
+	}
+
+	models_ := make([]mongo.WriteModel, 0, len(contents))
+	for _, content := range contents {
+		update := bson.M{"$set": bson.M{
+			"truth_score":               content.TruthScore,
+			"visibility_score":          content.VisibilityScore,
+			"processing_status":         content.ProcessingStatus,
+			"last_updated":              content.LastUpdated.Unix(),
+			"topics":                    content.Topics,
+			"entities":                  content.Entities,
+			"consensus_state":           content.Consensus.State,
+			"consensus_score":           content.Consensus.Score,
+			"consensus_validator_count": content.Consensus.ValidatorCount,
+			"consensus_temporal_weight": content.Consensus.TemporalWeight,
+			"metadata":                  content.Metadata,
+		}}
+		models_ = append(models_, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": content.ID}).
+			SetUpdate(update))
+	}
+
+	_, err := m.coll(collContent).BulkWrite(context.Background(), models_, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (m *Manager) GetUserContent(userID string, since time.Time) ([]*models.Content, error) {
+	cursor, err := m.coll(collContent).Find(context.Background(),
+		bson.M{"author_id": userID, "timestamp": bson.M{"$gte": since.Unix()}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var contents []*models.Content
+	for cursor.Next(context.Background()) {
+		var doc contentDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		contents = append(contents, doc.toModel())
+	}
+	return contents, cursor.Err()
+}
+
+// GetRecentContent returns every content item across all authors created
+// since the given time (by its creation timestamp), for callers (the item-based CF
+// recommender) that need a corpus-wide view rather than one user's.
+func (m *Manager) GetRecentContent(since time.Time) ([]*models.Content, error) {
+	cursor, err := m.coll(collContent).Find(context.Background(),
+		bson.M{"timestamp": bson.M{"$gte": since.Unix()}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var contents []*models.Content
+	for cursor.Next(context.Background()) {
+		var doc contentDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		contents = append(contents, doc.toModel())
+	}
+	return contents, cursor.Err()
+}
+
+// GetUserContexts retrieves a user's context posts since a given time
+func (m *Manager) GetUserContexts(userID string, since time.Time) ([]*models.Content, error) {
+	cursor, err := m.coll(collContent).Find(context.Background(),
+		bson.M{"author_id": userID, "content_type": "context", "timestamp": bson.M{"$gt": since.Unix()}},
+		options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var contexts []*models.Content
+	for cursor.Next(context.Background()) {
+		var doc contentDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		contexts = append(contexts, doc.toModel())
+	}
+	return contexts, cursor.Err()
+}
+
+// userDoc keeps SessionData as a native subdocument rather than a
+// marshaled JSON string.
+type userDoc struct {
+	ID                 string                          `bson:"_id"`
+	PublicKey          string                          `bson:"public_key"`
+	Username           string                          `bson:"username"`
+	CreatedAt          int64                           `bson:"created_at"`
+	AuthenticityScore  float64                         `bson:"authenticity_score"`
+	ReputationScore    float64                         `bson:"reputation_score"`
+	TruthAccuracy      float64                         `bson:"truth_accuracy"`
+	EvidenceQuality    float64                         `bson:"evidence_quality"`
+	EngagementQuality  float64                         `bson:"engagement_quality"`
+	CommunityScore     float64                         `bson:"community_score"`
+	LastActive         int64                           `bson:"last_active"`
+	SessionData        *models.UserSession             `bson:"session_data,omitempty"`
+	StakeAmount        float64                         `bson:"stake_amount"`
+	StakeLockedUntil   *time.Time                      `bson:"stake_locked_until,omitempty"`
+	VerificationLevel  int                             `bson:"verification_level"`
+	TotalContributions int                             `bson:"total_contributions"`
+	DomainExpertise    map[string]models.ExpertiseInfo `bson:"domain_expertise,omitempty"`
+}
+
+func toUserDoc(u *models.User) *userDoc {
+	return &userDoc{
+		ID:                 u.ID,
+		PublicKey:          u.PublicKey,
+		Username:           u.Username,
+		CreatedAt:          u.CreatedAt.Unix(),
+		AuthenticityScore:  u.AuthenticityScore,
+		ReputationScore:    u.ReputationScore,
+		TruthAccuracy:      u.TruthAccuracy,
+		EvidenceQuality:    u.EvidenceQuality,
+		EngagementQuality:  u.EngagementQuality,
+		CommunityScore:     u.CommunityScore,
+		LastActive:         u.LastActive.Unix(),
+		SessionData:        u.SessionData,
+		StakeAmount:        u.StakeAmount,
+		StakeLockedUntil:   u.StakeLockedUntil,
+		VerificationLevel:  u.VerificationLevel,
+		TotalContributions: u.TotalContributions,
+		DomainExpertise:    u.DomainExpertise,
+	}
+}
+
+func (d *userDoc) toModel() *models.User {
+	return &models.User{
+		ID:                 d.ID,
+		PublicKey:          d.PublicKey,
+		Username:           d.Username,
+		CreatedAt:          time.Unix(d.CreatedAt, 0),
+		AuthenticityScore:  d.AuthenticityScore,
+		ReputationScore:    d.ReputationScore,
+		TruthAccuracy:      d.TruthAccuracy,
+		EvidenceQuality:    d.EvidenceQuality,
+		EngagementQuality:  d.EngagementQuality,
+		CommunityScore:     d.CommunityScore,
+		LastActive:         time.Unix(d.LastActive, 0),
+		SessionData:        d.SessionData,
+		StakeAmount:        d.StakeAmount,
+		StakeLockedUntil:   d.StakeLockedUntil,
+		VerificationLevel:  d.VerificationLevel,
+		TotalContributions: d.TotalContributions,
+		DomainExpertise:    d.DomainExpertise,
+	}
+}
+
+func (m *Manager) CreateUser(user *models.User) error {
+	_, err := m.coll(collUsers).InsertOne(context.Background(), toUserDoc(user))
+	return err
+}
+
+func (m *Manager) GetUser(id string) (*models.User, error) {
+	var doc userDoc
+	err := m.coll(collUsers).FindOne(context.Background(), bson.M{"_id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.toModel(), nil
+}
+
+// UpdateUser persists the non-reputation fields on user directly, and
+// appends a reputation event for the scoring fields rather than writing
+// them as authoritative state; see reputation_events.go.
+func (m *Manager) UpdateUser(user *models.User) error {
+	_, err := m.coll(collUsers).UpdateOne(context.Background(),
+		bson.M{"_id": user.ID},
+		bson.M{"$set": bson.M{
+			"last_active":         user.LastActive.Unix(),
+			"session_data":        user.SessionData,
+			"stake_amount":        user.StakeAmount,
+			"stake_locked_until":  user.StakeLockedUntil,
+			"total_contributions": user.TotalContributions,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := m.appendReputationEvent(user); err != nil {
+		return fmt.Errorf("failed to append reputation event: %v", err)
+	}
+	return m.replayReputationEvents(user.ID)
+}
+
+// Algorithm Cache Operations
+
+type cacheDoc struct {
+	Key          string `bson:"_id"`
+	Value        string `bson:"value"`
+	Expiry       int64  `bson:"expiry"`
+	CreatedAt    int64  `bson:"created_at"`
+	LastAccessed int64  `bson:"last_accessed"`
+}
+
+func (m *Manager) SetCache(key string, value string, expiry time.Time) error {
+	now := time.Now().Unix()
+	opts := options.Update().SetUpsert(true)
+	_, err := m.coll(collAlgorithmCache).UpdateOne(context.Background(),
+		bson.M{"_id": key},
+		bson.M{"$set": bson.M{"value": value, "expiry": expiry.Unix(), "last_accessed": now},
+			"$setOnInsert": bson.M{"created_at": now}},
+		opts,
+	)
+	return err
+}
+
+func (m *Manager) GetCache(key string) (string, error) {
+	var doc cacheDoc
+	err := m.coll(collAlgorithmCache).FindOne(context.Background(),
+		bson.M{"_id": key, "expiry": bson.M{"$gt": time.Now().Unix()}},
+	).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return doc.Value, nil
+}
+
+// Consensus Operations
+
+// voteDoc is keyed by contentID:voterID since a user may cast at most one
+// current vote per piece of content; evidence_ids is a native array so
+// "find every vote citing evidence X" is a single indexed query instead of
+// a LIKE scan over a JSON blob.
+type voteDoc struct {
+	ID             string   `bson:"_id"`
+	ContentID      string   `bson:"content_id"`
+	VoterID        string   `bson:"voter_id"`
+	VoteType       string   `bson:"vote_type"`
+	VoteWeight     float64  `bson:"vote_weight"`
+	CertaintyLevel int      `bson:"certainty_level"`
+	EvidenceIDs    []string `bson:"evidence_ids"`
+	Explanation    string   `bson:"explanation,omitempty"`
+	ContextScore   float64  `bson:"context_score"`
+	Timestamp      int64    `bson:"timestamp"`
+	LastUpdated    int64    `bson:"last_updated"`
+}
+
+func voteDocID(contentID, voterID string) string {
+	return contentID + ":" + voterID
+}
+
+// RecordVote appends an immutable vote event and replays it into the
+// votes projection; see vote_events.go.
+func (m *Manager) RecordVote(contentID, voterID, voteType string, voteWeight float64, certaintyLevel int, evidenceIDs []string) error {
+	if _, _, err := m.appendVoteEvent(contentID, voterID, voteType, voteWeight, certaintyLevel, evidenceIDs); err != nil {
+		return fmt.Errorf("failed to append vote event: %v", err)
+	}
+	return m.replayVoteEvents(contentID)
+}
+
+func (m *Manager) GetContentVotes(contentID string) ([]*db.VoteInfo, error) {
+	cursor, err := m.coll(collVotes).Find(context.Background(), bson.M{"content_id": contentID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var votes []*db.VoteInfo
+	for cursor.Next(context.Background()) {
+		var doc voteDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		votes = append(votes, &db.VoteInfo{
+			ContentID:      doc.ContentID,
+			VoterID:        doc.VoterID,
+			VoteType:       doc.VoteType,
+			VoteWeight:     doc.VoteWeight,
+			CertaintyLevel: doc.CertaintyLevel,
+			EvidenceIDs:    doc.EvidenceIDs,
+			Timestamp:      time.Unix(doc.Timestamp, 0),
+			LastUpdated:    time.Unix(doc.LastUpdated, 0),
+		})
+	}
+	return votes, cursor.Err()
+}
+
+func (m *Manager) GetUserVotes(userID string, since time.Time) ([]*models.Vote, error) {
+	cursor, err := m.coll(collVotes).Find(context.Background(),
+		bson.M{"voter_id": userID, "timestamp": bson.M{"$gte": since.Unix()}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var votes []*models.Vote
+	for cursor.Next(context.Background()) {
+		var doc voteDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		votes = append(votes, &models.Vote{
+			ID:             doc.ID,
+			ContentID:      doc.ContentID,
+			UserID:         doc.VoterID,
+			Type:           doc.VoteType,
+			Weight:         doc.VoteWeight,
+			CertaintyLevel: doc.CertaintyLevel,
+			EvidenceIDs:    doc.EvidenceIDs,
+			Timestamp:      models.NewBabelTime(time.Unix(doc.Timestamp, 0)),
+			LastUpdated:    time.Unix(doc.LastUpdated, 0),
+			Explanation:    doc.Explanation,
+			ContextScore:   doc.ContextScore,
+		})
+	}
+	return votes, cursor.Err()
+}
+
+// Error Logging Operations
+
+type algorithmErrorDoc struct {
+	AlgorithmName   string                 `bson:"algorithm_name"`
+	ErrorType       string                 `bson:"error_type"`
+	ErrorMessage    string                 `bson:"error_message"`
+	Context         map[string]interface{} `bson:"context,omitempty"`
+	Timestamp       int64                  `bson:"timestamp"`
+	Resolved        bool                   `bson:"resolved"`
+	ResolutionNotes string                 `bson:"resolution_notes,omitempty"`
+}
+
+func (m *Manager) LogError(algErr *models.AlgorithmError) error {
+	_, err := m.coll(collAlgorithmErrors).InsertOne(context.Background(), &algorithmErrorDoc{
+		AlgorithmName:   algErr.AlgorithmName,
+		ErrorType:       algErr.ErrorType,
+		ErrorMessage:    algErr.ErrorMessage,
+		Context:         algErr.Context,
+		Timestamp:       algErr.Timestamp.Unix(),
+		Resolved:        algErr.Resolved,
+		ResolutionNotes: algErr.ResolutionNotes,
+	})
+	return err
+}
+
+// Metrics Operations
+
+type algorithmMetricDoc struct {
+	AlgorithmName string                 `bson:"algorithm_name"`
+	MetricName    string                 `bson:"metric_name"`
+	Value         float64                `bson:"value"`
+	Timestamp     int64                  `bson:"timestamp"`
+	Metadata      map[string]interface{} `bson:"metadata,omitempty"`
+}
+
+func (m *Manager) RecordMetric(metric *models.AlgorithmMetric) error {
+	return m.LogMetric(metric)
+}
+
+// LogMetric stores an algorithm metric in the database
+func (m *Manager) LogMetric(metric *models.AlgorithmMetric) error {
+	_, err := m.coll(collAlgorithmMetrics).InsertOne(context.Background(), &algorithmMetricDoc{
+		AlgorithmName: metric.AlgorithmName,
+		MetricName:    metric.MetricName,
+		Value:         metric.Value,
+		Timestamp:     metric.Timestamp.Unix(),
+		Metadata:      metric.Metadata,
+	})
+	return err
+}
+
+// User Activity Operations
+
+type userActivityDoc struct {
+	ID           string                 `bson:"_id"`
+	UserID       string                 `bson:"user_id"`
+	ActivityType string                 `bson:"activity_type"`
+	TargetID     string                 `bson:"target_id,omitempty"`
+	Timestamp    int64                  `bson:"timestamp"`
+	ImpactScore  float64                `bson:"impact_score"`
+	Metadata     map[string]interface{} `bson:"metadata,omitempty"`
+}
+
+// GetUserActivities retrieves user activities since a given time
+func (m *Manager) GetUserActivities(userID string, since time.Time) ([]*models.UserActivity, error) {
+	cursor, err := m.coll(collUserActivity).Find(context.Background(),
+		bson.M{"user_id": userID, "timestamp": bson.M{"$gte": since.Unix()}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var activities []*models.UserActivity
+	for cursor.Next(context.Background()) {
+		var doc userActivityDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		activities = append(activities, &models.UserActivity{
+			ID:           doc.ID,
+			UserID:       doc.UserID,
+			ActivityType: doc.ActivityType,
+			TargetID:     doc.TargetID,
+			Timestamp:    time.Unix(doc.Timestamp, 0),
+			ImpactScore:  doc.ImpactScore,
+			Metadata:     doc.Metadata,
+		})
+	}
+	return activities, cursor.Err()
+}
+
+// userVerificationDoc mirrors models.UserVerification
+type userVerificationDoc struct {
+	ID               string                 `bson:"_id"`
+	UserID           string                 `bson:"user_id"`
+	VerificationType string                 `bson:"verification_type"`
+	Status           string                 `bson:"status"`
+	VerifiedAt       *int64                 `bson:"verified_at,omitempty"`
+	VerifierID       string                 `bson:"verifier_id,omitempty"`
+	ProofData        map[string]interface{} `bson:"proof_data,omitempty"`
+	Metadata         map[string]interface{} `bson:"metadata,omitempty"`
+}
+
+// GetUserVerifications retrieves verification records for a user
+func (m *Manager) GetUserVerifications(userID string) ([]*models.UserVerification, error) {
+	cursor, err := m.coll(collUserVerification).Find(context.Background(), bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var verifications []*models.UserVerification
+	for cursor.Next(context.Background()) {
+		var doc userVerificationDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		v := &models.UserVerification{
+			ID:               doc.ID,
+			UserID:           doc.UserID,
+			VerificationType: doc.VerificationType,
+			Status:           doc.Status,
+			VerifierID:       doc.VerifierID,
+			ProofData:        doc.ProofData,
+			Metadata:         doc.Metadata,
+		}
+		if doc.VerifiedAt != nil {
+			t := time.Unix(*doc.VerifiedAt, 0)
+			v.VerifiedAt = &t
+		}
+		verifications = append(verifications, v)
+	}
+	return verifications, cursor.Err()
+}
+
+// evidenceDoc keeps references and context_data as native BSON rather than
+// the sql backends' JSON-in-TEXT columns.
+type evidenceDoc struct {
+	ID                string                 `bson:"_id"`
+	ContentID         string                 `bson:"content_id"`
+	SubmitterID       string                 `bson:"submitter_id"`
+	ContentAuthorID   string                 `bson:"content_author_id"`
+	EvidenceType      string                 `bson:"evidence_type,omitempty"`
+	URL               string                 `bson:"url,omitempty"`
+	Text              string                 `bson:"text,omitempty"`
+	MediaHash         string                 `bson:"media_hash,omitempty"`
+	Description       string                 `bson:"description,omitempty"`
+	EvidenceText      string                 `bson:"evidence_text"`
+	References        []string               `bson:"references"`
+	QualityScore      float64                `bson:"quality_score"`
+	ContextScore      float64                `bson:"context_score"`
+	VerificationState string                 `bson:"verification_state,omitempty"`
+	ContextData       map[string]interface{} `bson:"context_data,omitempty"`
+	Timestamp         int64                  `bson:"timestamp"`
+	LastUpdated       int64                  `bson:"last_updated"`
+	Metadata          map[string]interface{} `bson:"metadata,omitempty"`
+}
+
+func toEvidenceDoc(e *models.Evidence) *evidenceDoc {
+	return &evidenceDoc{
+		ID:                e.ID,
+		ContentID:         e.ContentID,
+		SubmitterID:       e.SubmitterID,
+		ContentAuthorID:   e.ContentAuthorID,
+		EvidenceType:      e.EvidenceType,
+		URL:               e.URL,
+		Text:              e.Text,
+		MediaHash:         e.MediaHash,
+		Description:       e.Description,
+		EvidenceText:      e.EvidenceText,
+		References:        e.References,
+		QualityScore:      e.QualityScore,
+		ContextScore:      e.ContextScore,
+		VerificationState: e.VerificationState,
+		ContextData:       e.ContextData,
+		Timestamp:         e.Timestamp.Unix(),
+		LastUpdated:       e.LastUpdated.Unix(),
+		Metadata:          e.Metadata,
+	}
+}
+
+func (d *evidenceDoc) toModel() *models.Evidence {
+	return &models.Evidence{
+		ID:                d.ID,
+		ContentID:         d.ContentID,
+		SubmitterID:       d.SubmitterID,
+		ContentAuthorID:   d.ContentAuthorID,
+		EvidenceType:      d.EvidenceType,
+		URL:               d.URL,
+		Text:              d.Text,
+		MediaHash:         d.MediaHash,
+		Description:       d.Description,
+		EvidenceText:      d.EvidenceText,
+		References:        d.References,
+		QualityScore:      d.QualityScore,
+		ContextScore:      d.ContextScore,
+		VerificationState: d.VerificationState,
+		ContextData:       d.ContextData,
+		Timestamp:         models.NewBabelTime(time.Unix(d.Timestamp, 0)),
+		LastUpdated:       time.Unix(d.LastUpdated, 0),
+		Metadata:          d.Metadata,
+	}
+}
+
+// CreateEvidence stores new evidence in the database
+func (m *Manager) CreateEvidence(evidence *models.Evidence) error {
+	_, err := m.coll(collEvidence).InsertOne(context.Background(), toEvidenceDoc(evidence))
+	return err
+}
+
+// GetEvidence retrieves evidence by ID
+func (m *Manager) GetEvidence(id string) (*models.Evidence, error) {
+	var doc evidenceDoc
+	err := m.coll(collEvidence).FindOne(context.Background(), bson.M{"_id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.toModel(), nil
+}
+
+// GetContentEvidence retrieves all evidence for a piece of content
+func (m *Manager) GetContentEvidence(contentID string) ([]*models.Evidence, error) {
+	cursor, err := m.coll(collEvidence).Find(context.Background(),
+		bson.M{"content_id": contentID},
+		options.Find().SetSort(bson.D{{Key: "quality_score", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var evidence []*models.Evidence
+	for cursor.Next(context.Background()) {
+		var doc evidenceDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, doc.toModel())
+	}
+	return evidence, cursor.Err()
+}
+
+// UpdateEvidence updates evidence in the database
+func (m *Manager) UpdateEvidence(evidence *models.Evidence) error {
+	_, err := m.coll(collEvidence).UpdateOne(context.Background(),
+		bson.M{"_id": evidence.ID},
+		bson.M{"$set": bson.M{
+			"evidence_text": evidence.EvidenceText,
+			"references":    evidence.References,
+			"quality_score": evidence.QualityScore,
+			"last_updated":  evidence.LastUpdated.Unix(),
+			"metadata":      evidence.Metadata,
+		}},
+	)
+	return err
+}
+
+func (m *Manager) GetUserEvidence(userID string, since time.Time) ([]*models.Evidence, error) {
+	cursor, err := m.coll(collEvidence).Find(context.Background(),
+		bson.M{"submitter_id": userID, "timestamp": bson.M{"$gte": since.Unix()}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var evidences []*models.Evidence
+	for cursor.Next(context.Background()) {
+		var doc evidenceDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		evidences = append(evidences, doc.toModel())
+	}
+	return evidences, cursor.Err()
+}
+
+// Content Bundle Operations
+
+// bundleDoc is the shape of one $lookup-joined aggregation result: a
+// content document with its author, all votes (for the tally and the
+// viewer's own vote), and its top evidence by quality score inlined.
+type bundleDoc struct {
+	contentDoc  `bson:",inline"`
+	Author      *userDoc      `bson:"author,omitempty"`
+	Votes       []voteDoc     `bson:"votes"`
+	TopEvidence []evidenceDoc `bson:"top_evidence"`
+}
+
+// GetContentBundle loads a single feed-ready view of content - the content
+// itself, its author, an aggregated vote tally, its top evidence by
+// quality score, and viewerID's own vote on it, if any - in one query.
+// viewerID may be empty if there is no authenticated viewer.
+func (m *Manager) GetContentBundle(contentID, viewerID string) (*db.ContentBundle, error) {
+	bundles, err := m.GetContentBundles([]string{contentID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, nil
+	}
+	return bundles[0], nil
+}
+
+// GetContentBundles is the batched form of GetContentBundle: a single
+// aggregation pipeline $lookups the author, every vote, and a quality-sorted,
+// limit-capped slice of evidence for each content document, so a feed of any
+// page size costs one round trip instead of four per item.
+func (m *Manager) GetContentBundles(ids []string, viewerID string) ([]*db.ContentBundle, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": ids}}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         collUsers,
+			"localField":   "author_id",
+			"foreignField": "_id",
+			"as":           "author",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$author", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         collVotes,
+			"localField":   "_id",
+			"foreignField": "content_id",
+			"as":           "votes",
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": collEvidence,
+			"let":  bson.M{"cid": "$_id"},
+			"pipeline": mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"$expr": bson.M{"$eq": bson.A{"$content_id", "$$cid"}}}}},
+				{{Key: "$sort", Value: bson.M{"quality_score": -1}}},
+				{{Key: "$limit", Value: db.BundleEvidenceLimit}},
+			},
+			"as": "top_evidence",
+		}}},
+	}
+
+	cursor, err := m.coll(collContent).Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate content bundles: %v", err)
+	}
+	defer cursor.Close(context.Background())
+
+	var bundles []*db.ContentBundle
+	for cursor.Next(context.Background()) {
+		var doc bundleDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to decode content bundle: %v", err)
+		}
+
+		bundle := &db.ContentBundle{Content: doc.contentDoc.toModel()}
+		if doc.Author != nil {
+			bundle.Author = &db.AuthorSummary{
+				ID:                doc.Author.ID,
+				Username:          doc.Author.Username,
+				AuthenticityScore: doc.Author.AuthenticityScore,
+				ReputationScore:   doc.Author.ReputationScore,
+			}
+		}
+
+		for _, v := range doc.Votes {
+			bundle.VoteTally.Total++
+			switch v.VoteType {
+			case models.VoteTypeUpvote:
+				bundle.VoteTally.Upvotes++
+			case models.VoteTypeDownvote:
+				bundle.VoteTally.Downvotes++
+			case models.VoteTypeAffirm:
+				bundle.VoteTally.Affirms++
+			case models.VoteTypeDeny:
+				bundle.VoteTally.Denies++
+			case models.VoteTypeEngage:
+				bundle.VoteTally.Engages++
+			case models.VoteTypeUnengage:
+				bundle.VoteTally.Unengages++
+			}
+			if viewerID != "" && v.VoterID == viewerID {
+				bundle.ViewerVote = &db.VoteInfo{
+					ContentID:      v.ContentID,
+					VoterID:        v.VoterID,
+					VoteType:       v.VoteType,
+					VoteWeight:     v.VoteWeight,
+					CertaintyLevel: v.CertaintyLevel,
+					EvidenceIDs:    v.EvidenceIDs,
+					Timestamp:      time.Unix(v.Timestamp, 0),
+					LastUpdated:    time.Unix(v.LastUpdated, 0),
+				}
+			}
+		}
+
+		for _, e := range doc.TopEvidence {
+			bundle.TopEvidence = append(bundle.TopEvidence, e.toModel())
+		}
+
+		bundles = append(bundles, bundle)
+	}
+
+	return bundles, cursor.Err()
+}
+
+// Bulk-by-key Operations
+//
+// These back the internal/loaders batching layer: each collects a request's
+// worth of keys into a single {"$in": [...]} query instead of one round trip
+// per key.
+
+func (m *Manager) GetUsersByIDs(ids []string) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := m.coll(collUsers).Find(context.Background(), bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var users []*models.User
+	for cursor.Next(context.Background()) {
+		var doc userDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		users = append(users, doc.toModel())
+	}
+	return users, cursor.Err()
+}
+
+func (m *Manager) GetContentByIDs(ids []string) ([]*models.Content, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := m.coll(collContent).Find(context.Background(), bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var contents []*models.Content
+	for cursor.Next(context.Background()) {
+		var doc contentDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		contents = append(contents, doc.toModel())
+	}
+	return contents, cursor.Err()
+}
+
+func (m *Manager) GetContentVotesByContentIDs(contentIDs []string) (map[string][]*db.VoteInfo, error) {
+	if len(contentIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := m.coll(collVotes).Find(context.Background(), bson.M{"content_id": bson.M{"$in": contentIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	votes := make(map[string][]*db.VoteInfo, len(contentIDs))
+	for cursor.Next(context.Background()) {
+		var doc voteDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		vote := &db.VoteInfo{
+			ContentID:      doc.ContentID,
+			VoterID:        doc.VoterID,
+			VoteType:       doc.VoteType,
+			VoteWeight:     doc.VoteWeight,
+			CertaintyLevel: doc.CertaintyLevel,
+			EvidenceIDs:    doc.EvidenceIDs,
+			Timestamp:      time.Unix(doc.Timestamp, 0),
+			LastUpdated:    time.Unix(doc.LastUpdated, 0),
+		}
+		votes[vote.ContentID] = append(votes[vote.ContentID], vote)
+	}
+	return votes, cursor.Err()
+}
+
+func (m *Manager) GetContentEvidenceByContentIDs(contentIDs []string) (map[string][]*models.Evidence, error) {
+	if len(contentIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := m.coll(collEvidence).Find(context.Background(),
+		bson.M{"content_id": bson.M{"$in": contentIDs}},
+		options.Find().SetSort(bson.D{{Key: "quality_score", Value: -1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	evidence := make(map[string][]*models.Evidence, len(contentIDs))
+	for cursor.Next(context.Background()) {
+		var doc evidenceDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		e := doc.toModel()
+		evidence[e.ContentID] = append(evidence[e.ContentID], e)
+	}
+	return evidence, cursor.Err()
+}
+
+func (m *Manager) GetUserVerificationsByUserIDs(userIDs []string) (map[string][]*models.UserVerification, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	cursor, err := m.coll(collUserVerification).Find(context.Background(), bson.M{"user_id": bson.M{"$in": userIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	verifications := make(map[string][]*models.UserVerification, len(userIDs))
+	for cursor.Next(context.Background()) {
+		var doc userVerificationDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		v := &models.UserVerification{
+			ID:               doc.ID,
+			UserID:           doc.UserID,
+			VerificationType: doc.VerificationType,
+			Status:           doc.Status,
+			VerifierID:       doc.VerifierID,
+			ProofData:        doc.ProofData,
+			Metadata:         doc.Metadata,
+		}
+		if doc.VerifiedAt != nil {
+			t := time.Unix(*doc.VerifiedAt, 0)
+			v.VerifiedAt = &t
+		}
+		verifications[v.UserID] = append(verifications[v.UserID], v)
+	}
+	return verifications, cursor.Err()
+}
+
+// Direct Message Operations
+
+type directMessageDoc struct {
+	ID         string `bson:"_id"`
+	SenderID   string `bson:"sender_id"`
+	ReceiverID string `bson:"receiver_id"`
+	Text       string `bson:"text"`
+	Timestamp  int64  `bson:"timestamp"`
+	ReadAt     int64  `bson:"read_at,omitempty"`
+}
+
+func (m *Manager) CreateDirectMessage(message *models.DirectMessage) error {
+	_, err := m.coll(collDirectMessages).InsertOne(context.Background(), &directMessageDoc{
+		ID:         message.ID,
+		SenderID:   message.SenderID,
+		ReceiverID: message.ReceiverID,
+		Text:       message.Text,
+		Timestamp:  message.Timestamp.Unix(),
+	})
+	return err
+}
+
+// Event Subscriber Operations
+
+type eventSubscriberDoc struct {
+	ID          string   `bson:"_id"`
+	OwnerID     string   `bson:"owner_id"`
+	Secret      string   `bson:"secret"`
+	Topics      []string `bson:"topics"`
+	DeliveryURL string   `bson:"delivery_url"`
+	Transport   string   `bson:"transport"`
+	Active      bool     `bson:"active"`
+	CreatedAt   int64    `bson:"created_at"`
+	LastUpdated int64    `bson:"last_updated"`
+}
+
+func toEventSubscriberDoc(s *models.EventSubscriber) *eventSubscriberDoc {
+	return &eventSubscriberDoc{
+		ID:          s.ID,
+		OwnerID:     s.OwnerID,
+		Secret:      s.Secret,
+		Topics:      s.Topics,
+		DeliveryURL: s.DeliveryURL,
+		Transport:   s.Transport,
+		Active:      s.Active,
+		CreatedAt:   s.CreatedAt.Unix(),
+		LastUpdated: s.LastUpdated.Unix(),
+	}
+}
+
+func (d *eventSubscriberDoc) toModel() *models.EventSubscriber {
+	return &models.EventSubscriber{
+		ID:          d.ID,
+		OwnerID:     d.OwnerID,
+		Secret:      d.Secret,
+		Topics:      d.Topics,
+		DeliveryURL: d.DeliveryURL,
+		Transport:   d.Transport,
+		Active:      d.Active,
+		CreatedAt:   time.Unix(d.CreatedAt, 0),
+		LastUpdated: time.Unix(d.LastUpdated, 0),
+	}
+}
+
+func (m *Manager) CreateEventSubscriber(sub *models.EventSubscriber) error {
+	_, err := m.coll(collEventSubscribers).InsertOne(context.Background(), toEventSubscriberDoc(sub))
+	return err
+}
+
+func (m *Manager) GetEventSubscriber(id string) (*models.EventSubscriber, error) {
+	var doc eventSubscriberDoc
+	err := m.coll(collEventSubscribers).FindOne(context.Background(), bson.M{"_id": id}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return doc.toModel(), nil
+}
+
+func (m *Manager) ListEventSubscribers() ([]*models.EventSubscriber, error) {
+	cursor, err := m.coll(collEventSubscribers).Find(context.Background(), bson.M{"active": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var subs []*models.EventSubscriber
+	for cursor.Next(context.Background()) {
+		var doc eventSubscriberDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		subs = append(subs, doc.toModel())
+	}
+	return subs, cursor.Err()
+}
+
+func (m *Manager) UpdateEventSubscriber(sub *models.EventSubscriber) error {
+	result, err := m.coll(collEventSubscribers).UpdateOne(context.Background(),
+		bson.M{"_id": sub.ID},
+		bson.M{"$set": bson.M{
+			"secret":       sub.Secret,
+			"topics":       sub.Topics,
+			"delivery_url": sub.DeliveryURL,
+			"transport":    sub.Transport,
+			"active":       sub.Active,
+			"last_updated": time.Now().Unix(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (m *Manager) DeleteEventSubscriber(id string) error {
+	result, err := m.coll(collEventSubscribers).DeleteOne(context.Background(), bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+type eventDeadLetterDoc struct {
+	ID           string `bson:"_id"`
+	SubscriberID string `bson:"subscriber_id"`
+	EventType    string `bson:"event_type"`
+	Payload      string `bson:"payload"`
+	Attempts     int    `bson:"attempts"`
+	LastError    string `bson:"last_error"`
+	FirstAttempt int64  `bson:"first_attempt"`
+	LastAttempt  int64  `bson:"last_attempt"`
+}
+
+// LogDeadLetterEvent persists a failed delivery for operator inspection and
+// replay once its retries are exhausted.
+func (m *Manager) LogDeadLetterEvent(event *models.DeadLetterEvent) error {
+	_, err := m.coll(collEventDeadLetters).InsertOne(context.Background(), &eventDeadLetterDoc{
+		ID:           event.ID,
+		SubscriberID: event.SubscriberID,
+		EventType:    event.EventType,
+		Payload:      event.Payload,
+		Attempts:     event.Attempts,
+		LastError:    event.LastError,
+		FirstAttempt: event.FirstAttempt.Unix(),
+		LastAttempt:  event.LastAttempt.Unix(),
+	})
+	return err
+}
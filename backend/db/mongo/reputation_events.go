@@ -0,0 +1,108 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/google/uuid"
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// reputation_events is the append-only ledger backing a user's scoring
+// fields; the users collection's score fields are a projection rebuilt by
+// replayReputationEvents. See internal/db/sqlite/reputation_events.go.
+
+const projectionReputation = "reputation"
+
+type reputationEventDoc struct {
+	ID                string  `bson:"_id"`
+	UserID            string  `bson:"user_id"`
+	AuthenticityScore float64 `bson:"authenticity_score"`
+	ReputationScore   float64 `bson:"reputation_score"`
+	TruthAccuracy     float64 `bson:"truth_accuracy"`
+	EvidenceQuality   float64 `bson:"evidence_quality"`
+	EngagementQuality float64 `bson:"engagement_quality"`
+	CommunityScore    float64 `bson:"community_score"`
+	VerificationLevel int     `bson:"verification_level"`
+	Timestamp         int64   `bson:"timestamp"`
+	PrevEventHash     string  `bson:"prev_event_hash"`
+	Signature         string  `bson:"signature"`
+}
+
+func (m *Manager) appendReputationEvent(user *models.User) (string, int64, error) {
+	prevHash, err := m.lastEventHash(collReputationEvents, "user_id", user.ID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to load prior reputation event: %v", err)
+	}
+
+	eventID := uuid.New().String()
+	ts := time.Now().Unix()
+	signature := signEvent(eventID, user.ID,
+		fmt.Sprintf("%f", user.AuthenticityScore), fmt.Sprintf("%f", user.ReputationScore),
+		fmt.Sprintf("%f", user.TruthAccuracy), fmt.Sprintf("%f", user.EvidenceQuality),
+		fmt.Sprintf("%f", user.EngagementQuality), fmt.Sprintf("%f", user.CommunityScore),
+		fmt.Sprintf("%d", user.VerificationLevel), fmt.Sprintf("%d", ts), prevHash)
+
+	_, err = m.coll(collReputationEvents).InsertOne(context.Background(), reputationEventDoc{
+		ID:                eventID,
+		UserID:            user.ID,
+		AuthenticityScore: user.AuthenticityScore,
+		ReputationScore:   user.ReputationScore,
+		TruthAccuracy:     user.TruthAccuracy,
+		EvidenceQuality:   user.EvidenceQuality,
+		EngagementQuality: user.EngagementQuality,
+		CommunityScore:    user.CommunityScore,
+		VerificationLevel: user.VerificationLevel,
+		Timestamp:         ts,
+		PrevEventHash:     prevHash,
+		Signature:         signature,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	return eventID, ts, nil
+}
+
+func (m *Manager) replayReputationEvents(userID string) error {
+	checkpoint, err := m.checkpoint(projectionReputation, userID)
+	if err != nil {
+		return err
+	}
+
+	opts := options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}})
+	var latest reputationEventDoc
+	err = m.coll(collReputationEvents).FindOne(context.Background(), bson.M{
+		"user_id":   userID,
+		"timestamp": bson.M{"$gte": checkpoint},
+	}, opts).Decode(&latest)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = m.coll(collUsers).UpdateOne(context.Background(),
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{
+			"authenticity_score": latest.AuthenticityScore,
+			"reputation_score":   latest.ReputationScore,
+			"truth_accuracy":     latest.TruthAccuracy,
+			"evidence_quality":   latest.EvidenceQuality,
+			"engagement_quality": latest.EngagementQuality,
+			"community_score":    latest.CommunityScore,
+			"verification_level": latest.VerificationLevel,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+
+	return m.setCheckpoint(projectionReputation, userID, latest.ID, latest.Timestamp)
+}
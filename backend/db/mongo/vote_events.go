@@ -0,0 +1,254 @@
+package mongo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/google/uuid"
+)
+
+// vote_events is the append-only ledger RecordVote writes to; the votes
+// collection is a materialized projection rebuilt from it by
+// replayVoteEvents. See internal/db/sqlite/vote_events.go for the backend
+// this mirrors.
+
+const projectionVote = "vote"
+
+type voteEventDoc struct {
+	ID             string   `bson:"_id"`
+	ContentID      string   `bson:"content_id"`
+	VoterID        string   `bson:"voter_id"`
+	VoteType       string   `bson:"vote_type"`
+	VoteWeight     float64  `bson:"vote_weight"`
+	CertaintyLevel int      `bson:"certainty_level"`
+	EvidenceIDs    []string `bson:"evidence_ids"`
+	Timestamp      int64    `bson:"timestamp"`
+	PrevEventHash  string   `bson:"prev_event_hash"`
+	Signature      string   `bson:"signature"`
+}
+
+type projectionCheckpointDoc struct {
+	ID          string `bson:"_id"`
+	Projection  string `bson:"projection"`
+	Key         string `bson:"key"`
+	LastEventID string `bson:"last_event_id"`
+	LastEventTS int64  `bson:"last_event_ts"`
+}
+
+func checkpointDocID(projection, key string) string {
+	return projection + ":" + key
+}
+
+func (m *Manager) appendVoteEvent(contentID, voterID, voteType string, voteWeight float64, certaintyLevel int, evidenceIDs []string) (string, int64, error) {
+	prevHash, err := m.lastEventHash(collVoteEvents, "content_id", contentID)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to load prior vote event: %v", err)
+	}
+
+	eventID := uuid.New().String()
+	ts := time.Now().Unix()
+	signature := signEvent(eventID, contentID, voterID, voteType,
+		fmt.Sprintf("%f", voteWeight), fmt.Sprintf("%d", certaintyLevel), fmt.Sprintf("%v", evidenceIDs),
+		fmt.Sprintf("%d", ts), prevHash)
+
+	_, err = m.coll(collVoteEvents).InsertOne(context.Background(), voteEventDoc{
+		ID:             eventID,
+		ContentID:      contentID,
+		VoterID:        voterID,
+		VoteType:       voteType,
+		VoteWeight:     voteWeight,
+		CertaintyLevel: certaintyLevel,
+		EvidenceIDs:    evidenceIDs,
+		Timestamp:      ts,
+		PrevEventHash:  prevHash,
+		Signature:      signature,
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	return eventID, ts, nil
+}
+
+// replayVoteEvents rebuilds the votes projection for every voter on
+// contentID from vote_events, applying only events at or after the
+// checkpoint timestamp.
+func (m *Manager) replayVoteEvents(contentID string) error {
+	checkpoint, err := m.checkpoint(projectionVote, contentID)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := m.coll(collVoteEvents).Find(context.Background(), bson.M{
+		"content_id": contentID,
+		"timestamp":  bson.M{"$gte": checkpoint},
+	}, options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.Background())
+
+	var lastEventID string
+	var lastEventTS int64
+	opts := options.Update().SetUpsert(true)
+	for cursor.Next(context.Background()) {
+		var doc voteEventDoc
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		_, err := m.coll(collVotes).UpdateOne(context.Background(),
+			bson.M{"_id": voteDocID(doc.ContentID, doc.VoterID)},
+			bson.M{"$set": bson.M{
+				"content_id":      doc.ContentID,
+				"voter_id":        doc.VoterID,
+				"vote_type":       doc.VoteType,
+				"vote_weight":     doc.VoteWeight,
+				"certainty_level": doc.CertaintyLevel,
+				"evidence_ids":    doc.EvidenceIDs,
+				"last_updated":    time.Now().Unix(),
+			}, "$setOnInsert": bson.M{"timestamp": doc.Timestamp}},
+			opts,
+		)
+		if err != nil {
+			return err
+		}
+
+		lastEventID, lastEventTS = doc.ID, doc.Timestamp
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if lastEventID == "" {
+		return nil
+	}
+	return m.setCheckpoint(projectionVote, contentID, lastEventID, lastEventTS)
+}
+
+// ReplayVoteEvents rebuilds the votes projection for contentID from
+// scratch from vote_events, ignoring any checkpoint.
+func (m *Manager) ReplayVoteEvents(contentID string) error {
+	if err := m.clearCheckpoint(projectionVote, contentID); err != nil {
+		return err
+	}
+	return m.replayVoteEvents(contentID)
+}
+
+// RebuildAllProjections replays every content_id's vote events and every
+// user_id's reputation events from scratch, ignoring checkpoints.
+func (m *Manager) RebuildAllProjections() error {
+	contentIDs, err := distinctValues(m.coll(collVoteEvents), "content_id")
+	if err != nil {
+		return err
+	}
+	for _, contentID := range contentIDs {
+		if err := m.clearCheckpoint(projectionVote, contentID); err != nil {
+			return err
+		}
+		if err := m.replayVoteEvents(contentID); err != nil {
+			return fmt.Errorf("failed to replay vote events for %s: %v", contentID, err)
+		}
+	}
+
+	userIDs, err := distinctValues(m.coll(collReputationEvents), "user_id")
+	if err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := m.clearCheckpoint(projectionReputation, userID); err != nil {
+			return err
+		}
+		if err := m.replayReputationEvents(userID); err != nil {
+			return fmt.Errorf("failed to replay reputation events for %s: %v", userID, err)
+		}
+	}
+
+	return nil
+}
+
+func distinctValues(coll *mongo.Collection, field string) ([]string, error) {
+	raw, err := coll.Distinct(context.Background(), field, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values, nil
+}
+
+func (m *Manager) lastEventHash(coll, keyField, key string) (string, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}})
+	var doc struct {
+		Signature string `bson:"signature"`
+	}
+	err := m.coll(coll).FindOne(context.Background(), bson.M{keyField: key}, opts).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return doc.Signature, nil
+}
+
+// signEvent computes the hex-encoded HMAC-SHA256 chain link for an event's
+// fields in order. This backend has no HCS node key to sign with (HCS
+// mirroring is sqlite-only so far), so it signs with an empty key - still a
+// deterministic, tamper-evident chain, just not independently verifiable
+// against a shared secret.
+func signEvent(fields ...string) string {
+	mac := hmac.New(sha256.New, nil)
+	for _, field := range fields {
+		mac.Write([]byte(field))
+		mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (m *Manager) checkpoint(projection, key string) (int64, error) {
+	var doc projectionCheckpointDoc
+	err := m.coll(collProjectionCheckpoints).FindOne(context.Background(),
+		bson.M{"_id": checkpointDocID(projection, key)},
+	).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return doc.LastEventTS, nil
+}
+
+func (m *Manager) setCheckpoint(projection, key, lastEventID string, lastEventTS int64) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := m.coll(collProjectionCheckpoints).UpdateOne(context.Background(),
+		bson.M{"_id": checkpointDocID(projection, key)},
+		bson.M{"$set": bson.M{
+			"projection":    projection,
+			"key":           key,
+			"last_event_id": lastEventID,
+			"last_event_ts": lastEventTS,
+		}},
+		opts,
+	)
+	return err
+}
+
+func (m *Manager) clearCheckpoint(projection, key string) error {
+	_, err := m.coll(collProjectionCheckpoints).DeleteOne(context.Background(),
+		bson.M{"_id": checkpointDocID(projection, key)})
+	return err
+}
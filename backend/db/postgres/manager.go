@@ -0,0 +1,65 @@
+// Package postgres is a Postgres-backed implementation of db.Repository. It
+// uses database/sql with the "postgres" driver (e.g. github.com/lib/pq)
+// registered by the caller, and stores the blob-ish fields (media_urls,
+// metadata, topics, entities, session_data, evidence_ids, references) as
+// JSONB rather than TEXT so they stay queryable via Postgres's jsonb
+// operators - unlike the sqlite backend's opaque JSON-in-TEXT columns.
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// Manager handles database operations against Postgres.
+type Manager struct {
+	db *sql.DB
+}
+
+var _ db.Repository = (*Manager)(nil)
+
+// NewManager wraps an already-opened *sql.DB (driver "postgres").
+func NewManager(conn *sql.DB) *Manager {
+	conn.SetMaxOpenConns(25)
+	conn.SetMaxIdleConns(5)
+	conn.SetConnMaxLifetime(5 * time.Minute)
+
+	return &Manager{db: conn}
+}
+
+// Open opens a new Postgres connection pool from dsn and wraps it.
+func Open(dsn string) (*Manager, error) {
+	conn, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	return NewManager(conn), nil
+}
+
+// Close closes the underlying connection pool.
+func (m *Manager) Close() error {
+	return m.db.Close()
+}
+
+// Transaction executes fn within a database transaction, rolling back on
+// error and committing otherwise.
+func (m *Manager) Transaction(fn func(*sql.Tx) error) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	return nil
+}
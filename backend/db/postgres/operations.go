@@ -0,0 +1,1467 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// Content Operations
+
+func (m *Manager) CreateContent(content *models.Content) error {
+	mediaURLs, err := content.MarshalMediaURLs()
+	if err != nil {
+		return fmt.Errorf("failed to marshal media URLs: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO content (
+				id, author_id, content_type, content_text, media_urls,
+				parent_id, timestamp, signature, hash, processing_status, last_updated
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			content.ID, content.AuthorID, content.ContentType, content.ContentText, mediaURLs,
+			content.ParentID, content.Timestamp.Unix(), content.Signature, content.Hash,
+			content.ProcessingStatus, content.LastUpdated.Unix(),
+		)
+		return err
+	})
+}
+
+func (m *Manager) GetContent(id string) (*models.Content, error) {
+	var content models.Content
+	var timestamp, lastUpdated int64
+	var mediaURLs string
+
+	err := m.db.QueryRow(`
+		SELECT id, author_id, content_type, content_text, media_urls,
+		       parent_id, timestamp, signature, hash, processing_status, last_updated
+		FROM content WHERE id = $1`, id).Scan(
+		&content.ID, &content.AuthorID, &content.ContentType, &content.ContentText, &mediaURLs,
+		&content.ParentID, &timestamp, &content.Signature, &content.Hash,
+		&content.ProcessingStatus, &lastUpdated,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+	content.LastUpdated = time.Unix(lastUpdated, 0)
+	if err := content.UnmarshalMediaURLs(mediaURLs); err != nil {
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+func (m *Manager) UpdateContent(content *models.Content) error {
+	mediaURLs, err := content.MarshalMediaURLs()
+	if err != nil {
+		return fmt.Errorf("failed to marshal media URLs: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			UPDATE content SET
+				content_type = $1, content_text = $2, media_urls = $3,
+				parent_id = $4, signature = $5, hash = $6,
+				processing_status = $7, last_updated = $8
+			WHERE id = $9 AND author_id = $10`,
+			content.ContentType, content.ContentText, mediaURLs,
+			content.ParentID, content.Signature, content.Hash,
+			content.ProcessingStatus, time.Now().Unix(),
+			content.ID, content.AuthorID,
+		)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("content not found or user not authorized")
+		}
+
+		return nil
+	})
+}
+
+// UpdateContentBatch updates multiple content entries in a single transaction
+func (m *Manager) UpdateContentBatch(contents []*models.Content) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`
+			UPDATE content SET
+				truth_score = $1,
+				visibility_score = $2,
+				processing_status = $3,
+				last_updated = $4,
+				topics = $5,
+				entities = $6,
+				consensus_state = $7,
+				consensus_score = $8,
+				consensus_validator_count = $9,
+				consensus_temporal_weight = $10,
+				metadata = $11
+			WHERE id = $12
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to prepare statement: %v", err)
+		}
+		defer stmt.Close()
+
+		for _, content := range contents {
+			metadata, err := json.Marshal(content.Metadata)
+			if err != nil {
+				return fmt.Errorf("failed to marshal metadata: %v", err)
+			}
+
+			topics, err := json.Marshal(content.Topics)
+			if err != nil {
+				return fmt.Errorf("failed to marshal topics: %v", err)
+			}
+
+			entities, err := json.Marshal(content.Entities)
+			if err != nil {
+				return fmt.Errorf("failed to marshal entities: %v", err)
+			}
+
+			_, err = stmt.Exec(
+				content.TruthScore,
+				content.VisibilityScore,
+				content.ProcessingStatus,
+				content.LastUpdated.Unix(),
+				string(topics),
+				string(entities),
+				content.Consensus.State,
+				content.Consensus.Score,
+				content.Consensus.ValidatorCount,
+				content.Consensus.TemporalWeight,
+				string(metadata),
+				content.ID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to update content %s: %v", content.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (m *Manager) GetUserContent(userID string, since time.Time) ([]*models.Content, error) {
+	rows, err := m.db.Query(`
+		SELECT id, author_id, content_type, content_text, media_urls,
+		       parent_id, timestamp, signature, hash, processing_status,
+		       last_updated, metadata, truth_score, visibility_score,
+		       evidence_chains, topics, entities, context_refs
+		FROM content
+		WHERE author_id = $1 AND timestamp >= $2`,
+		userID, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		var timestamp, lastUpdated int64
+		var mediaURLsJSON, metadataJSON, evidenceChainsJSON string
+		var topicsJSON, entitiesJSON, contextRefsJSON string
+
+		err := rows.Scan(
+			&content.ID, &content.AuthorID, &content.ContentType,
+			&content.ContentText, &mediaURLsJSON, &content.ParentID,
+			&timestamp, &content.Signature, &content.Hash,
+			&content.ProcessingStatus, &lastUpdated, &metadataJSON,
+			&content.TruthScore, &content.VisibilityScore,
+			&evidenceChainsJSON, &topicsJSON, &entitiesJSON, &contextRefsJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		content.LastUpdated = time.Unix(lastUpdated, 0)
+
+		if err := json.Unmarshal([]byte(mediaURLsJSON), &content.MediaURLs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &content.Metadata); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(evidenceChainsJSON), &content.EvidenceChains); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(topicsJSON), &content.Topics); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(entitiesJSON), &content.Entities); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(contextRefsJSON), &content.ContextRefs); err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+// GetRecentContent returns every content item across all authors created
+// since the given time (by its creation timestamp), for callers (the item-based CF
+// recommender) that need a corpus-wide view rather than one user's.
+func (m *Manager) GetRecentContent(since time.Time) ([]*models.Content, error) {
+	rows, err := m.db.Query(`
+		SELECT id, author_id, content_type, content_text, media_urls,
+		       parent_id, timestamp, signature, hash, processing_status,
+		       last_updated, metadata, truth_score, visibility_score,
+		       evidence_chains, topics, entities, context_refs
+		FROM content
+		WHERE timestamp >= $1`,
+		since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		var timestamp, lastUpdated int64
+		var mediaURLsJSON, metadataJSON, evidenceChainsJSON string
+		var topicsJSON, entitiesJSON, contextRefsJSON string
+
+		err := rows.Scan(
+			&content.ID, &content.AuthorID, &content.ContentType,
+			&content.ContentText, &mediaURLsJSON, &content.ParentID,
+			&timestamp, &content.Signature, &content.Hash,
+			&content.ProcessingStatus, &lastUpdated, &metadataJSON,
+			&content.TruthScore, &content.VisibilityScore,
+			&evidenceChainsJSON, &topicsJSON, &entitiesJSON, &contextRefsJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		content.LastUpdated = time.Unix(lastUpdated, 0)
+
+		if err := json.Unmarshal([]byte(mediaURLsJSON), &content.MediaURLs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &content.Metadata); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(evidenceChainsJSON), &content.EvidenceChains); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(topicsJSON), &content.Topics); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(entitiesJSON), &content.Entities); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(contextRefsJSON), &content.ContextRefs); err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+// GetUserContexts retrieves a user's context posts since a given time
+func (m *Manager) GetUserContexts(userID string, since time.Time) ([]*models.Content, error) {
+	rows, err := m.db.Query(`
+		SELECT id, author_id, content_type, content_text, media_urls, truth_score,
+		       visibility_score, timestamp, last_updated, metadata, parent_id
+		FROM content
+		WHERE author_id = $1 AND content_type = 'context' AND timestamp > $2
+		ORDER BY timestamp DESC`, userID, since.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user contexts: %v", err)
+	}
+	defer rows.Close()
+
+	var contexts []*models.Content
+	for rows.Next() {
+		var c models.Content
+		var mediaURLsJSON, metadataJSON []byte
+		var parentID sql.NullString
+
+		err := rows.Scan(&c.ID, &c.AuthorID, &c.ContentType, &c.ContentText, &mediaURLsJSON,
+			&c.TruthScore, &c.VisibilityScore, &c.Timestamp, &c.LastUpdated, &metadataJSON, &parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan context: %v", err)
+		}
+
+		if err := json.Unmarshal(mediaURLsJSON, &c.MediaURLs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal media URLs: %v", err)
+		}
+		if err := json.Unmarshal(metadataJSON, &c.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+		}
+		if parentID.Valid {
+			c.ParentID = &parentID.String
+		}
+
+		contexts = append(contexts, &c)
+	}
+
+	return contexts, nil
+}
+
+// User Operations
+
+func (m *Manager) CreateUser(user *models.User) error {
+	sessionData, err := user.MarshalSessionData()
+	if err != nil {
+		return fmt.Errorf("failed to marshal session data: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO users (
+				id, public_key, username, created_at,
+				authenticity_score, reputation_score, last_active, session_data
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			user.ID, user.PublicKey, user.Username, user.CreatedAt.Unix(),
+			user.AuthenticityScore, user.ReputationScore, user.LastActive.Unix(), sessionData,
+		)
+		return err
+	})
+}
+
+func (m *Manager) GetUser(id string) (*models.User, error) {
+	var user models.User
+	var createdAt, lastActive int64
+	var sessionData string
+
+	err := m.db.QueryRow(`
+		SELECT id, public_key, username, created_at,
+		       authenticity_score, reputation_score, last_active, session_data
+		FROM users WHERE id = $1`, id).Scan(
+		&user.ID, &user.PublicKey, &user.Username, &createdAt,
+		&user.AuthenticityScore, &user.ReputationScore, &lastActive, &sessionData,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	user.CreatedAt = time.Unix(createdAt, 0)
+	user.LastActive = time.Unix(lastActive, 0)
+	if err := user.UnmarshalSessionData(sessionData); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateUser persists the non-reputation fields on user directly, and
+// appends a reputation event for the scoring fields rather than writing
+// them as authoritative state; see reputation_events.go.
+func (m *Manager) UpdateUser(user *models.User) error {
+	sessionData, err := user.MarshalSessionData()
+	if err != nil {
+		return fmt.Errorf("failed to marshal session data: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			UPDATE users SET
+				last_active = $1,
+				session_data = $2,
+				stake_amount = $3,
+				stake_locked_until = $4,
+				total_contributions = $5
+			WHERE id = $6`,
+			user.LastActive.Unix(),
+			sessionData,
+			user.StakeAmount,
+			user.StakeLockedUntil,
+			user.TotalContributions,
+			user.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if _, err := m.appendReputationEvent(tx, user); err != nil {
+			return fmt.Errorf("failed to append reputation event: %v", err)
+		}
+		return m.replayReputationEvents(tx, user.ID)
+	})
+}
+
+// Algorithm Cache Operations
+
+func (m *Manager) SetCache(key string, value string, expiry time.Time) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO algorithm_cache (key, value, expiry, created_at, last_accessed)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (key) DO UPDATE SET
+				value = EXCLUDED.value, expiry = EXCLUDED.expiry, last_accessed = EXCLUDED.last_accessed`,
+			key, value, expiry.Unix(), time.Now().Unix(), time.Now().Unix(),
+		)
+		return err
+	})
+}
+
+func (m *Manager) GetCache(key string) (string, error) {
+	var value string
+
+	err := m.db.QueryRow(`
+		SELECT value FROM algorithm_cache WHERE key = $1 AND expiry > $2`,
+		key, time.Now().Unix(),
+	).Scan(&value)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return value, nil
+}
+
+// Consensus Operations
+
+// RecordVote appends an immutable vote event and replays it into the
+// truth_consensus projection; see vote_events.go.
+func (m *Manager) RecordVote(contentID, voterID, voteType string, voteWeight float64, certaintyLevel int, evidenceIDs []string) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		if _, err := m.appendVoteEvent(tx, contentID, voterID, voteType, voteWeight, certaintyLevel, evidenceIDs); err != nil {
+			return fmt.Errorf("failed to append vote event: %v", err)
+		}
+		return m.replayVoteEvents(tx, contentID)
+	})
+}
+
+func (m *Manager) GetContentVotes(contentID string) ([]*db.VoteInfo, error) {
+	rows, err := m.db.Query(`
+		SELECT content_id, voter_id, vote_type, vote_weight,
+		       certainty_level, evidence_ids, timestamp, last_updated
+		FROM truth_consensus WHERE content_id = $1`, contentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []*db.VoteInfo
+	for rows.Next() {
+		var vote db.VoteInfo
+		var timestamp, lastUpdated int64
+		var evidenceIDsJSON string
+
+		err := rows.Scan(
+			&vote.ContentID, &vote.VoterID, &vote.VoteType, &vote.VoteWeight,
+			&vote.CertaintyLevel, &evidenceIDsJSON, &timestamp, &lastUpdated,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		vote.Timestamp = time.Unix(timestamp, 0)
+		vote.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := json.Unmarshal([]byte(evidenceIDsJSON), &vote.EvidenceIDs); err != nil {
+			return nil, err
+		}
+
+		votes = append(votes, &vote)
+	}
+
+	return votes, rows.Err()
+}
+
+func (m *Manager) GetUserVotes(userID string, since time.Time) ([]*models.Vote, error) {
+	rows, err := m.db.Query(`
+		SELECT id, content_id, voter_id, vote_type, vote_weight,
+		       evidence_ids, timestamp, last_updated, explanation, context_score
+		FROM truth_consensus
+		WHERE voter_id = $1 AND timestamp >= $2`,
+		userID, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []*models.Vote
+	for rows.Next() {
+		var vote models.Vote
+		var timestamp, lastUpdated int64
+		var evidenceIDsJSON string
+
+		err := rows.Scan(
+			&vote.ID, &vote.ContentID, &vote.UserID, &vote.Type, &vote.Weight,
+			&evidenceIDsJSON, &timestamp, &lastUpdated, &vote.Explanation, &vote.ContextScore,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		vote.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		vote.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := json.Unmarshal([]byte(evidenceIDsJSON), &vote.EvidenceIDs); err != nil {
+			return nil, err
+		}
+
+		votes = append(votes, &vote)
+	}
+
+	return votes, rows.Err()
+}
+
+// Error Logging Operations
+
+func (m *Manager) LogError(algErr *models.AlgorithmError) error {
+	contextJSON, err := json.Marshal(algErr.Context)
+	if err != nil {
+		return fmt.Errorf("failed to marshal error context: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, dbErr := tx.Exec(`
+			INSERT INTO algorithm_errors (
+				algorithm_name, error_type, error_message,
+				context, timestamp, resolved, resolution_notes
+			) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			algErr.AlgorithmName, algErr.ErrorType, algErr.ErrorMessage,
+			string(contextJSON), algErr.Timestamp.Unix(), algErr.Resolved, algErr.ResolutionNotes,
+		)
+		return dbErr
+	})
+}
+
+// Metrics Operations
+
+func (m *Manager) RecordMetric(metric *models.AlgorithmMetric) error {
+	return m.LogMetric(metric)
+}
+
+// LogMetric stores an algorithm metric in the database
+func (m *Manager) LogMetric(metric *models.AlgorithmMetric) error {
+	metadata, err := json.Marshal(metric.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO algorithm_metrics (
+				algorithm_name, metric_name, value, timestamp, metadata
+			) VALUES ($1, $2, $3, $4, $5)`,
+			metric.AlgorithmName, metric.MetricName, metric.Value,
+			metric.Timestamp.Unix(), string(metadata),
+		)
+		return err
+	})
+}
+
+// GetUserActivities retrieves user activities since a given time
+func (m *Manager) GetUserActivities(userID string, since time.Time) ([]*models.UserActivity, error) {
+	rows, err := m.db.Query(`
+		SELECT id, user_id, activity_type, target_id, timestamp, impact_score, metadata
+		FROM user_activity
+		WHERE user_id = $1 AND timestamp >= $2`,
+		userID, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activities []*models.UserActivity
+	for rows.Next() {
+		var activity models.UserActivity
+		var timestamp int64
+		var metadataJSON string
+
+		err := rows.Scan(
+			&activity.ID, &activity.UserID, &activity.ActivityType,
+			&activity.TargetID, &timestamp, &activity.ImpactScore,
+			&metadataJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		activity.Timestamp = time.Unix(timestamp, 0)
+		if err := json.Unmarshal([]byte(metadataJSON), &activity.Metadata); err != nil {
+			return nil, err
+		}
+
+		activities = append(activities, &activity)
+	}
+
+	return activities, rows.Err()
+}
+
+// GetUserVerifications retrieves verification records for a user
+func (m *Manager) GetUserVerifications(userID string) ([]*models.UserVerification, error) {
+	rows, err := m.db.Query(`
+		SELECT id, user_id, verification_type, status, verified_at,
+		       verifier_id, proof_data, metadata
+		FROM user_verification
+		WHERE user_id = $1`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var verifications []*models.UserVerification
+	for rows.Next() {
+		var verification models.UserVerification
+		var verifiedAt sql.NullInt64
+		var proofDataJSON, metadataJSON string
+
+		err := rows.Scan(
+			&verification.ID, &verification.UserID,
+			&verification.VerificationType, &verification.Status,
+			&verifiedAt, &verification.VerifierID,
+			&proofDataJSON, &metadataJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if verifiedAt.Valid {
+			t := time.Unix(verifiedAt.Int64, 0)
+			verification.VerifiedAt = &t
+		}
+
+		if err := json.Unmarshal([]byte(proofDataJSON), &verification.ProofData); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &verification.Metadata); err != nil {
+			return nil, err
+		}
+
+		verifications = append(verifications, &verification)
+	}
+
+	return verifications, rows.Err()
+}
+
+// CreateEvidence stores new evidence in the database
+func (m *Manager) CreateEvidence(evidence *models.Evidence) error {
+	metadata, err := json.Marshal(evidence.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %v", err)
+	}
+
+	references, err := json.Marshal(evidence.References)
+	if err != nil {
+		return fmt.Errorf("failed to marshal references: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO evidence (
+				id, content_id, submitter_id, content_author_id,
+				evidence_text, references, quality_score,
+				timestamp, last_updated, metadata
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			evidence.ID, evidence.ContentID, evidence.SubmitterID,
+			evidence.ContentAuthorID, evidence.EvidenceText,
+			string(references), evidence.QualityScore,
+			evidence.Timestamp.Unix(), evidence.LastUpdated.Unix(),
+			string(metadata),
+		)
+		return err
+	})
+}
+
+// GetEvidence retrieves evidence by ID
+func (m *Manager) GetEvidence(id string) (*models.Evidence, error) {
+	var evidence models.Evidence
+	var refsJSON, metadataJSON string
+	var timestamp, lastUpdated int64
+
+	err := m.db.QueryRow(`
+		SELECT id, content_id, submitter_id, content_author_id,
+		       evidence_text, references, quality_score,
+		       timestamp, last_updated, metadata
+		FROM evidence WHERE id = $1`, id).Scan(
+		&evidence.ID, &evidence.ContentID, &evidence.SubmitterID,
+		&evidence.ContentAuthorID, &evidence.EvidenceText,
+		&refsJSON, &evidence.QualityScore,
+		&timestamp, &lastUpdated, &metadataJSON,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	evidence.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+	evidence.LastUpdated = time.Unix(lastUpdated, 0)
+
+	if err := json.Unmarshal([]byte(refsJSON), &evidence.References); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal references: %v", err)
+	}
+	if err := json.Unmarshal([]byte(metadataJSON), &evidence.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+	}
+
+	return &evidence, nil
+}
+
+// GetContentEvidence retrieves all evidence for a piece of content
+func (m *Manager) GetContentEvidence(contentID string) ([]*models.Evidence, error) {
+	rows, err := m.db.Query(`
+		SELECT id, content_id, submitter_id, content_author_id,
+		       evidence_text, references, quality_score,
+		       timestamp, last_updated, metadata
+		FROM evidence WHERE content_id = $1
+		ORDER BY quality_score DESC`, contentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evidence []*models.Evidence
+	for rows.Next() {
+		var e models.Evidence
+		var refsJSON, metadataJSON string
+		var timestamp, lastUpdated int64
+
+		err := rows.Scan(
+			&e.ID, &e.ContentID, &e.SubmitterID,
+			&e.ContentAuthorID, &e.EvidenceText,
+			&refsJSON, &e.QualityScore,
+			&timestamp, &lastUpdated, &metadataJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		e.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		e.LastUpdated = time.Unix(lastUpdated, 0)
+
+		if err := json.Unmarshal([]byte(refsJSON), &e.References); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal references: %v", err)
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &e.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+		}
+
+		evidence = append(evidence, &e)
+	}
+
+	return evidence, nil
+}
+
+// UpdateEvidence updates evidence in the database
+func (m *Manager) UpdateEvidence(evidence *models.Evidence) error {
+	metadata, err := json.Marshal(evidence.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %v", err)
+	}
+
+	references, err := json.Marshal(evidence.References)
+	if err != nil {
+		return fmt.Errorf("failed to marshal references: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			UPDATE evidence SET
+				evidence_text = $1,
+				references = $2,
+				quality_score = $3,
+				last_updated = $4,
+				metadata = $5
+			WHERE id = $6`,
+			evidence.EvidenceText,
+			string(references),
+			evidence.QualityScore,
+			evidence.LastUpdated.Unix(),
+			string(metadata),
+			evidence.ID,
+		)
+		return err
+	})
+}
+
+func (m *Manager) GetUserEvidence(userID string, since time.Time) ([]*models.Evidence, error) {
+	rows, err := m.db.Query(`
+		SELECT id, content_id, submitter_id, evidence_type, url,
+		       text, media_hash, description, timestamp, verification_state,
+		       quality_score, context_data, references
+		FROM evidence
+		WHERE submitter_id = $1 AND timestamp >= $2`,
+		userID, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evidences []*models.Evidence
+	for rows.Next() {
+		var evidence models.Evidence
+		var timestamp int64
+		var contextDataJSON, referencesJSON string
+
+		err := rows.Scan(
+			&evidence.ID, &evidence.ContentID, &evidence.SubmitterID,
+			&evidence.EvidenceType, &evidence.URL, &evidence.Text,
+			&evidence.MediaHash, &evidence.Description, &timestamp,
+			&evidence.VerificationState, &evidence.QualityScore,
+			&contextDataJSON, &referencesJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		evidence.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		if err := json.Unmarshal([]byte(contextDataJSON), &evidence.ContextData); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(referencesJSON), &evidence.References); err != nil {
+			return nil, err
+		}
+
+		evidences = append(evidences, &evidence)
+	}
+
+	return evidences, rows.Err()
+}
+
+// Content Bundle Operations
+
+// GetContentBundle loads a single feed-ready view of content - the content
+// itself, its author, an aggregated vote tally, its top evidence by
+// quality score, and viewerID's own vote on it, if any - in one query.
+// viewerID may be empty if there is no authenticated viewer.
+func (m *Manager) GetContentBundle(contentID, viewerID string) (*db.ContentBundle, error) {
+	bundles, err := m.GetContentBundles([]string{contentID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, nil
+	}
+	return bundles[0], nil
+}
+
+// GetContentBundles is the batched form of GetContentBundle: it LEFT JOINs
+// content against users, an aggregated vote tally, the viewer's own vote,
+// and a quality-ranked evidence window in a single query so a feed of any
+// page size costs one round trip instead of four per item.
+func (m *Manager) GetContentBundles(ids []string, viewerID string) ([]*db.ContentBundle, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, viewerID)
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+2)
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		WITH tally AS (
+			SELECT content_id,
+				SUM(CASE WHEN vote_type = 'upvote' THEN 1 ELSE 0 END) AS upvotes,
+				SUM(CASE WHEN vote_type = 'downvote' THEN 1 ELSE 0 END) AS downvotes,
+				SUM(CASE WHEN vote_type = 'affirm' THEN 1 ELSE 0 END) AS affirms,
+				SUM(CASE WHEN vote_type = 'deny' THEN 1 ELSE 0 END) AS denies,
+				SUM(CASE WHEN vote_type = 'engage' THEN 1 ELSE 0 END) AS engages,
+				SUM(CASE WHEN vote_type = 'unengage' THEN 1 ELSE 0 END) AS unengages,
+				COUNT(*) AS total
+			FROM truth_consensus
+			GROUP BY content_id
+		),
+		ranked_evidence AS (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY content_id ORDER BY quality_score DESC) AS rn
+			FROM evidence
+		)
+		SELECT
+			c.id, c.author_id, c.content_type, c.content_text, c.media_urls, c.parent_id,
+			c.timestamp, c.signature, c.hash, c.processing_status, c.last_updated,
+			u.id, u.username, u.authenticity_score, u.reputation_score,
+			COALESCE(t.upvotes, 0), COALESCE(t.downvotes, 0), COALESCE(t.affirms, 0),
+			COALESCE(t.denies, 0), COALESCE(t.engages, 0), COALESCE(t.unengages, 0), COALESCE(t.total, 0),
+			vv.voter_id, vv.vote_type, vv.vote_weight, vv.certainty_level, vv.evidence_ids, vv.timestamp, vv.last_updated,
+			e.id, e.submitter_id, e.content_author_id, e.evidence_text, e.references, e.quality_score, e.timestamp, e.last_updated
+		FROM content c
+		LEFT JOIN users u ON u.id = c.author_id
+		LEFT JOIN tally t ON t.content_id = c.id
+		LEFT JOIN truth_consensus vv ON vv.content_id = c.id AND vv.voter_id = $1
+		LEFT JOIN ranked_evidence e ON e.content_id = c.id AND e.rn <= %d
+		WHERE c.id IN (%s)
+	`, db.BundleEvidenceLimit, strings.Join(placeholders, ", "))
+
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content bundles: %v", err)
+	}
+	defer rows.Close()
+
+	order := make([]string, 0, len(ids))
+	byID := make(map[string]*db.ContentBundle, len(ids))
+
+	for rows.Next() {
+		var (
+			id, authorID, contentType, contentText, mediaURLsJSON string
+			parentID                                              sql.NullString
+			timestamp, lastUpdated                                int64
+			signature, hash, processingStatus                     string
+
+			authorRowID              sql.NullString
+			username                 sql.NullString
+			authenticity, reputation sql.NullFloat64
+
+			upvotes, downvotes, affirms, denies, engages, unengages, total int
+
+			viewerVoterID                      sql.NullString
+			viewerVoteType                     sql.NullString
+			viewerVoteWeight                   sql.NullFloat64
+			viewerCertainty                    sql.NullInt64
+			viewerEvidenceIDsJSON              sql.NullString
+			viewerTimestamp, viewerLastUpdated sql.NullInt64
+
+			evID, evSubmitterID, evContentAuthorID, evText, evRefsJSON sql.NullString
+			evQuality                                                  sql.NullFloat64
+			evTimestamp, evLastUpdated                                 sql.NullInt64
+		)
+
+		err := rows.Scan(
+			&id, &authorID, &contentType, &contentText, &mediaURLsJSON, &parentID,
+			&timestamp, &signature, &hash, &processingStatus, &lastUpdated,
+			&authorRowID, &username, &authenticity, &reputation,
+			&upvotes, &downvotes, &affirms, &denies, &engages, &unengages, &total,
+			&viewerVoterID, &viewerVoteType, &viewerVoteWeight, &viewerCertainty, &viewerEvidenceIDsJSON, &viewerTimestamp, &viewerLastUpdated,
+			&evID, &evSubmitterID, &evContentAuthorID, &evText, &evRefsJSON, &evQuality, &evTimestamp, &evLastUpdated,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content bundle row: %v", err)
+		}
+
+		bundle, ok := byID[id]
+		if !ok {
+			content := &models.Content{
+				ID:               id,
+				AuthorID:         authorID,
+				ContentType:      contentType,
+				ContentText:      contentText,
+				Timestamp:        models.NewBabelTime(time.Unix(timestamp, 0)),
+				Signature:        signature,
+				Hash:             hash,
+				ProcessingStatus: processingStatus,
+				LastUpdated:      time.Unix(lastUpdated, 0),
+			}
+			if parentID.Valid {
+				content.ParentID = &parentID.String
+			}
+			if err := json.Unmarshal([]byte(mediaURLsJSON), &content.MediaURLs); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal media URLs: %v", err)
+			}
+
+			bundle = &db.ContentBundle{
+				Content: content,
+				VoteTally: db.VoteTally{
+					Upvotes:   upvotes,
+					Downvotes: downvotes,
+					Affirms:   affirms,
+					Denies:    denies,
+					Engages:   engages,
+					Unengages: unengages,
+					Total:     total,
+				},
+			}
+			if authorRowID.Valid {
+				bundle.Author = &db.AuthorSummary{
+					ID:                authorRowID.String,
+					Username:          username.String,
+					AuthenticityScore: authenticity.Float64,
+					ReputationScore:   reputation.Float64,
+				}
+			}
+			if viewerVoterID.Valid {
+				vote := &db.VoteInfo{
+					ContentID:      id,
+					VoterID:        viewerVoterID.String,
+					VoteType:       viewerVoteType.String,
+					VoteWeight:     viewerVoteWeight.Float64,
+					CertaintyLevel: int(viewerCertainty.Int64),
+					Timestamp:      time.Unix(viewerTimestamp.Int64, 0),
+					LastUpdated:    time.Unix(viewerLastUpdated.Int64, 0),
+				}
+				if viewerEvidenceIDsJSON.Valid {
+					if err := json.Unmarshal([]byte(viewerEvidenceIDsJSON.String), &vote.EvidenceIDs); err != nil {
+						return nil, fmt.Errorf("failed to unmarshal viewer vote evidence IDs: %v", err)
+					}
+				}
+				bundle.ViewerVote = vote
+			}
+
+			byID[id] = bundle
+			order = append(order, id)
+		}
+
+		if evID.Valid {
+			ev := &models.Evidence{
+				ID:              evID.String,
+				ContentID:       id,
+				SubmitterID:     evSubmitterID.String,
+				ContentAuthorID: evContentAuthorID.String,
+				EvidenceText:    evText.String,
+				QualityScore:    evQuality.Float64,
+				Timestamp:       models.NewBabelTime(time.Unix(evTimestamp.Int64, 0)),
+				LastUpdated:     time.Unix(evLastUpdated.Int64, 0),
+			}
+			if evRefsJSON.Valid {
+				if err := json.Unmarshal([]byte(evRefsJSON.String), &ev.References); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal evidence references: %v", err)
+				}
+			}
+			bundle.TopEvidence = append(bundle.TopEvidence, ev)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	bundles := make([]*db.ContentBundle, 0, len(order))
+	for _, id := range order {
+		bundles = append(bundles, byID[id])
+	}
+	return bundles, nil
+}
+
+// placeholders returns n "$1, $2, ..." placeholders joined for an IN (...) clause.
+func placeholders(n int) string {
+	ph := make([]string, n)
+	for i := range ph {
+		ph[i] = fmt.Sprintf("$%d", i+1)
+	}
+	return strings.Join(ph, ", ")
+}
+
+// Bulk-by-key Operations
+//
+// These back the internal/loaders batching layer: each collects a request's
+// worth of keys into a single WHERE id IN (...) query instead of one round
+// trip per key.
+
+func (m *Manager) GetUsersByIDs(ids []string) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, public_key, username, created_at,
+		       authenticity_score, reputation_score, last_active, session_data
+		FROM users WHERE id IN (%s)`, placeholders(len(ids))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		var createdAt, lastActive int64
+		var sessionData string
+
+		if err := rows.Scan(
+			&user.ID, &user.PublicKey, &user.Username, &createdAt,
+			&user.AuthenticityScore, &user.ReputationScore, &lastActive, &sessionData,
+		); err != nil {
+			return nil, err
+		}
+
+		user.CreatedAt = time.Unix(createdAt, 0)
+		user.LastActive = time.Unix(lastActive, 0)
+		if err := user.UnmarshalSessionData(sessionData); err != nil {
+			return nil, err
+		}
+
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+func (m *Manager) GetContentByIDs(ids []string) ([]*models.Content, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, author_id, content_type, content_text, media_urls,
+		       parent_id, timestamp, signature, hash, processing_status, last_updated
+		FROM content WHERE id IN (%s)`, placeholders(len(ids))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		var timestamp, lastUpdated int64
+		var mediaURLs string
+
+		if err := rows.Scan(
+			&content.ID, &content.AuthorID, &content.ContentType, &content.ContentText, &mediaURLs,
+			&content.ParentID, &timestamp, &content.Signature, &content.Hash,
+			&content.ProcessingStatus, &lastUpdated,
+		); err != nil {
+			return nil, err
+		}
+
+		content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		content.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := content.UnmarshalMediaURLs(mediaURLs); err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+func (m *Manager) GetContentVotesByContentIDs(contentIDs []string) (map[string][]*db.VoteInfo, error) {
+	if len(contentIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(contentIDs))
+	for i, id := range contentIDs {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT content_id, voter_id, vote_type, vote_weight,
+		       certainty_level, evidence_ids, timestamp, last_updated
+		FROM truth_consensus WHERE content_id IN (%s)`, placeholders(len(contentIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := make(map[string][]*db.VoteInfo, len(contentIDs))
+	for rows.Next() {
+		var vote db.VoteInfo
+		var timestamp, lastUpdated int64
+		var evidenceIDsJSON string
+
+		if err := rows.Scan(
+			&vote.ContentID, &vote.VoterID, &vote.VoteType, &vote.VoteWeight,
+			&vote.CertaintyLevel, &evidenceIDsJSON, &timestamp, &lastUpdated,
+		); err != nil {
+			return nil, err
+		}
+
+		vote.Timestamp = time.Unix(timestamp, 0)
+		vote.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := json.Unmarshal([]byte(evidenceIDsJSON), &vote.EvidenceIDs); err != nil {
+			return nil, err
+		}
+
+		votes[vote.ContentID] = append(votes[vote.ContentID], &vote)
+	}
+
+	return votes, rows.Err()
+}
+
+func (m *Manager) GetContentEvidenceByContentIDs(contentIDs []string) (map[string][]*models.Evidence, error) {
+	if len(contentIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(contentIDs))
+	for i, id := range contentIDs {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, content_id, submitter_id, content_author_id,
+		       evidence_text, references, quality_score,
+		       timestamp, last_updated, metadata
+		FROM evidence WHERE content_id IN (%s)
+		ORDER BY quality_score DESC`, placeholders(len(contentIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	evidence := make(map[string][]*models.Evidence, len(contentIDs))
+	for rows.Next() {
+		var e models.Evidence
+		var refsJSON, metadataJSON string
+		var timestamp, lastUpdated int64
+
+		if err := rows.Scan(
+			&e.ID, &e.ContentID, &e.SubmitterID,
+			&e.ContentAuthorID, &e.EvidenceText,
+			&refsJSON, &e.QualityScore,
+			&timestamp, &lastUpdated, &metadataJSON,
+		); err != nil {
+			return nil, err
+		}
+
+		e.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		e.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := json.Unmarshal([]byte(refsJSON), &e.References); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal references: %v", err)
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &e.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+		}
+
+		evidence[e.ContentID] = append(evidence[e.ContentID], &e)
+	}
+
+	return evidence, rows.Err()
+}
+
+func (m *Manager) GetUserVerificationsByUserIDs(userIDs []string) (map[string][]*models.UserVerification, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, user_id, verification_type, status, verified_at,
+		       verifier_id, proof_data, metadata
+		FROM user_verification WHERE user_id IN (%s)`, placeholders(len(userIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	verifications := make(map[string][]*models.UserVerification, len(userIDs))
+	for rows.Next() {
+		var verification models.UserVerification
+		var verifiedAt sql.NullInt64
+		var proofDataJSON, metadataJSON string
+
+		if err := rows.Scan(
+			&verification.ID, &verification.UserID,
+			&verification.VerificationType, &verification.Status,
+			&verifiedAt, &verification.VerifierID,
+			&proofDataJSON, &metadataJSON,
+		); err != nil {
+			return nil, err
+		}
+
+		if verifiedAt.Valid {
+			t := time.Unix(verifiedAt.Int64, 0)
+			verification.VerifiedAt = &t
+		}
+		if err := json.Unmarshal([]byte(proofDataJSON), &verification.ProofData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal proof data: %v", err)
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &verification.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+		}
+
+		verifications[verification.UserID] = append(verifications[verification.UserID], &verification)
+	}
+
+	return verifications, rows.Err()
+}
+
+// Direct Message Operations
+
+func (m *Manager) CreateDirectMessage(message *models.DirectMessage) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO direct_messages (
+				id, sender_id, receiver_id, text, timestamp, read_at
+			) VALUES ($1, $2, $3, $4, $5, NULL)`,
+			message.ID, message.SenderID, message.ReceiverID,
+			message.Text, message.Timestamp.Unix(),
+		)
+		return err
+	})
+}
+
+// Event Subscriber Operations
+
+func (m *Manager) CreateEventSubscriber(sub *models.EventSubscriber) error {
+	topics, err := db.JSONArrayToString(sub.Topics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal topics: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO event_subscribers (
+				id, owner_id, secret, topics, delivery_url, transport,
+				active, created_at, last_updated
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			sub.ID, sub.OwnerID, sub.Secret, topics, sub.DeliveryURL, sub.Transport,
+			sub.Active, sub.CreatedAt.Unix(), sub.LastUpdated.Unix(),
+		)
+		return err
+	})
+}
+
+func (m *Manager) GetEventSubscriber(id string) (*models.EventSubscriber, error) {
+	var sub models.EventSubscriber
+	var topics string
+	var createdAt, lastUpdated int64
+
+	err := m.db.QueryRow(`
+		SELECT id, owner_id, secret, topics, delivery_url, transport,
+		       active, created_at, last_updated
+		FROM event_subscribers WHERE id = $1`, id).Scan(
+		&sub.ID, &sub.OwnerID, &sub.Secret, &topics, &sub.DeliveryURL, &sub.Transport,
+		&sub.Active, &createdAt, &lastUpdated,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sub.CreatedAt = time.Unix(createdAt, 0)
+	sub.LastUpdated = time.Unix(lastUpdated, 0)
+	if sub.Topics, err = db.StringToJSONArray(topics); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (m *Manager) ListEventSubscribers() ([]*models.EventSubscriber, error) {
+	rows, err := m.db.Query(`
+		SELECT id, owner_id, secret, topics, delivery_url, transport,
+		       active, created_at, last_updated
+		FROM event_subscribers WHERE active = true`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event subscribers: %v", err)
+	}
+	defer rows.Close()
+
+	var subs []*models.EventSubscriber
+	for rows.Next() {
+		var sub models.EventSubscriber
+		var topics string
+		var createdAt, lastUpdated int64
+
+		if err := rows.Scan(
+			&sub.ID, &sub.OwnerID, &sub.Secret, &topics, &sub.DeliveryURL, &sub.Transport,
+			&sub.Active, &createdAt, &lastUpdated,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event subscriber: %v", err)
+		}
+
+		sub.CreatedAt = time.Unix(createdAt, 0)
+		sub.LastUpdated = time.Unix(lastUpdated, 0)
+		if sub.Topics, err = db.StringToJSONArray(topics); err != nil {
+			return nil, err
+		}
+
+		subs = append(subs, &sub)
+	}
+
+	return subs, nil
+}
+
+func (m *Manager) UpdateEventSubscriber(sub *models.EventSubscriber) error {
+	topics, err := db.JSONArrayToString(sub.Topics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal topics: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			UPDATE event_subscribers SET
+				secret = $1, topics = $2, delivery_url = $3, transport = $4,
+				active = $5, last_updated = $6
+			WHERE id = $7`,
+			sub.Secret, topics, sub.DeliveryURL, sub.Transport,
+			sub.Active, time.Now().Unix(), sub.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("event subscriber not found")
+		}
+
+		return nil
+	})
+}
+
+func (m *Manager) DeleteEventSubscriber(id string) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM event_subscribers WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("event subscriber not found")
+		}
+
+		return nil
+	})
+}
+
+// LogDeadLetterEvent persists a failed delivery for operator inspection and
+// replay once its retries are exhausted.
+func (m *Manager) LogDeadLetterEvent(event *models.DeadLetterEvent) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO event_dead_letters (
+				id, subscriber_id, event_type, payload, attempts,
+				last_error, first_attempt, last_attempt
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			event.ID, event.SubscriberID, event.EventType, event.Payload, event.Attempts,
+			event.LastError, event.FirstAttempt.Unix(), event.LastAttempt.Unix(),
+		)
+		return err
+	})
+}
@@ -1,110 +0,0 @@
-package api
-
-import (
-	"github.com/gin-gonic/gin"
-)
-
-// CreateContentHandler handles content creation
-func CreateContentHandler(hc *HederaClient) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		var req PostRequest
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(400, gin.H{"error": err.Error()})
-			return
-		}
-
-		// TODO: Implement content creation with Hedera
-		c.JSON(200, gin.H{"message": "Content created"})
-	}
-}
-
-// GetContentHandler handles content retrieval
-func GetContentHandler(hc *HederaClient) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		id := c.Param("id")
-		// TODO: Implement content retrieval from Hedera
-		c.JSON(200, gin.H{"content_id": id})
-	}
-}
-
-// VoteContentHandler handles content voting
-func VoteContentHandler(hc *HederaClient, hub *WebSocketHub) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		id := c.Param("id")
-		var req struct {
-			UserID string `json:"user_id"`
-			Vote   string `json:"vote"` // "true", "false", "uncertain"
-		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(400, gin.H{"error": err.Error()})
-			return
-		}
-
-		// TODO: Implement vote submission to Hedera
-		// Broadcast update to connected clients
-		hub.BroadcastUpdate("vote", gin.H{
-			"content_id": id,
-			"vote_type":  req.Vote,
-		})
-
-		c.JSON(200, gin.H{"message": "Vote recorded"})
-	}
-}
-
-// AddEvidenceHandler handles evidence submission
-func AddEvidenceHandler(hc *HederaClient, hub *WebSocketHub) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		id := c.Param("id")
-		var req struct {
-			UserID       string `json:"user_id"`
-			Evidence     string `json:"evidence"`
-			EvidenceType string `json:"evidence_type"`
-		}
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(400, gin.H{"error": err.Error()})
-			return
-		}
-
-		// TODO: Implement evidence submission to Hedera
-		// Broadcast update to connected clients
-		hub.BroadcastUpdate("evidence", gin.H{
-			"content_id": id,
-			"evidence":   req.Evidence,
-		})
-
-		c.JSON(200, gin.H{"message": "Evidence added"})
-	}
-}
-
-// GetUserProfileHandler handles user profile retrieval
-func GetUserProfileHandler() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// TODO: Implement user profile retrieval
-		c.JSON(200, gin.H{"message": "User profile"})
-	}
-}
-
-// GetUserReputationHandler handles user reputation retrieval
-func GetUserReputationHandler() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// TODO: Implement reputation retrieval
-		c.JSON(200, gin.H{"message": "User reputation"})
-	}
-}
-
-// GetContentAnalyticsHandler handles content analytics retrieval
-func GetContentAnalyticsHandler() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		id := c.Param("id")
-		// TODO: Implement analytics retrieval
-		c.JSON(200, gin.H{"content_id": id, "analytics": "placeholder"})
-	}
-}
-
-// GetTrendingContentHandler handles trending content retrieval
-func GetTrendingContentHandler() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// TODO: Implement trending content retrieval
-		c.JSON(200, gin.H{"trending": []string{}})
-	}
-}
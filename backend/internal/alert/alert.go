@@ -0,0 +1,138 @@
+// Package alert raises operator-facing alerts (coordinated vote flips,
+// evidence-validation error spikes, auth-failure storms, etc.) to
+// configurable sinks, with per-key rate limiting so a storm of identical
+// alerts doesn't page the same person hundreds of times.
+package alert
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Severity classifies how urgently an alert needs human attention.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// defaultMinInterval is used when an Alerter is constructed with a
+// non-positive minInterval.
+const defaultMinInterval = 5 * time.Minute
+
+// Alert is the payload handed to every AlertSink.
+type Alert struct {
+	Severity  Severity
+	Key       string
+	Message   string
+	Fields    map[string]interface{}
+	Timestamp time.Time
+
+	// Suppressed is how many raises with this Key were suppressed since the
+	// last delivered alert. Zero unless this alert is the one that ends a
+	// suppression window.
+	Suppressed int
+}
+
+// AlertSink delivers an Alert somewhere (Slack, email, a generic webhook).
+// Implementations should not block for long; Raise calls them synchronously.
+type AlertSink interface {
+	Send(a Alert) error
+}
+
+type keyState struct {
+	lastRaised time.Time
+	suppressed int
+}
+
+// Alerter rate-limits and fans alerts out to a set of sinks.
+type Alerter struct {
+	sinks       []AlertSink
+	minInterval time.Duration
+
+	mu    sync.Mutex
+	state map[string]*keyState
+}
+
+// NewAlerter creates an Alerter that delivers to sinks, suppressing repeat
+// alerts for the same key more often than once per minInterval. A
+// non-positive minInterval falls back to a 5-minute default.
+func NewAlerter(sinks []AlertSink, minInterval time.Duration) *Alerter {
+	if minInterval <= 0 {
+		minInterval = defaultMinInterval
+	}
+	return &Alerter{
+		sinks:       sinks,
+		minInterval: minInterval,
+		state:       make(map[string]*keyState),
+	}
+}
+
+// Raise delivers an alert for key, unless one was already delivered for the
+// same key within minInterval, in which case it's suppressed and counted;
+// the next delivered alert for key carries that count in Suppressed.
+func (a *Alerter) Raise(severity Severity, key, message string, fields map[string]interface{}) {
+	a.mu.Lock()
+	st, exists := a.state[key]
+	if !exists {
+		st = &keyState{}
+		a.state[key] = st
+	}
+
+	now := time.Now()
+	if exists && now.Sub(st.lastRaised) < a.minInterval {
+		st.suppressed++
+		a.mu.Unlock()
+		return
+	}
+
+	suppressed := st.suppressed
+	st.lastRaised = now
+	st.suppressed = 0
+	a.mu.Unlock()
+
+	msg := message
+	if suppressed > 0 {
+		msg = fmt.Sprintf("%s (+%d more since)", message, suppressed)
+	}
+
+	alert := Alert{
+		Severity:   severity,
+		Key:        key,
+		Message:    msg,
+		Fields:     fields,
+		Timestamp:  now,
+		Suppressed: suppressed,
+	}
+
+	for _, sink := range a.sinks {
+		if err := sink.Send(alert); err != nil {
+			log.Printf("alert: sink failed to deliver alert %q: %v", key, err)
+		}
+	}
+}
+
+// defaultAlerter is used by the package-level Raise so call sites across
+// unrelated packages (the algorithm client, the WebSocket hub, auth
+// middleware) don't need an Alerter threaded through their constructors.
+// SetDefault installs it once during startup.
+var defaultAlerter *Alerter
+
+// SetDefault installs a as the Alerter used by the package-level Raise.
+func SetDefault(a *Alerter) {
+	defaultAlerter = a
+}
+
+// Raise delivers an alert through the default Alerter installed via
+// SetDefault. It is a no-op if no default has been configured, so call
+// sites can raise alerts unconditionally without a nil check.
+func Raise(severity Severity, key, message string, fields map[string]interface{}) {
+	if defaultAlerter == nil {
+		return
+	}
+	defaultAlerter.Raise(severity, key, message, fields)
+}
@@ -0,0 +1,137 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+// SlackSink delivers alerts to a Slack incoming webhook.
+type SlackSink struct {
+	WebhookURL string
+	httpClient *http.Client
+}
+
+// NewSlackSink creates a SlackSink posting to webhookURL.
+func NewSlackSink(webhookURL string) *SlackSink {
+	return &SlackSink{
+		WebhookURL: webhookURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *SlackSink) Send(a Alert) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("[%s] %s: %s", a.Severity, a.Key, a.Message),
+	})
+	if err != nil {
+		return fmt.Errorf("alert: failed to marshal slack payload: %v", err)
+	}
+
+	resp, err := s.httpClient.Post(s.WebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("alert: failed to post to slack: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("alert: slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailSink delivers alerts via SMTP.
+type EmailSink struct {
+	SMTPAddr string // host:port
+	Auth     smtp.Auth
+	From     string
+	To       []string
+}
+
+// NewEmailSink creates an EmailSink that authenticates with smtp.PlainAuth.
+func NewEmailSink(smtpAddr, host, username, password, from string, to []string) *EmailSink {
+	return &EmailSink{
+		SMTPAddr: smtpAddr,
+		Auth:     smtp.PlainAuth("", username, password, host),
+		From:     from,
+		To:       to,
+	}
+}
+
+func (s *EmailSink) Send(a Alert) error {
+	subject := fmt.Sprintf("[%s] %s", a.Severity, a.Key)
+	msg := fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", joinAddrs(s.To), subject, a.Message)
+
+	if err := smtp.SendMail(s.SMTPAddr, s.Auth, s.From, s.To, []byte(msg)); err != nil {
+		return fmt.Errorf("alert: failed to send email: %v", err)
+	}
+	return nil
+}
+
+func joinAddrs(addrs []string) string {
+	joined := ""
+	for i, addr := range addrs {
+		if i > 0 {
+			joined += ", "
+		}
+		joined += addr
+	}
+	return joined
+}
+
+// HTTPSink delivers alerts as a generic JSON POST, for arbitrary
+// webhook-based integrations (PagerDuty, OpsGenie, an internal dashboard).
+type HTTPSink struct {
+	URL        string
+	httpClient *http.Client
+}
+
+// NewHTTPSink creates an HTTPSink posting to url.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{
+		URL:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *HTTPSink) Send(a Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("alert: failed to marshal payload: %v", err)
+	}
+
+	resp, err := s.httpClient.Post(s.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("alert: failed to post alert: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("alert: sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// NewAlerterFromConfig builds an Alerter wired up to whichever sinks cfg
+// has non-empty settings for. It's valid to call with a zero-value
+// AlertConfig; the resulting Alerter simply has no sinks.
+func NewAlerterFromConfig(cfg config.AlertConfig) *Alerter {
+	var sinks []AlertSink
+
+	if cfg.SlackWebhookURL != "" {
+		sinks = append(sinks, NewSlackSink(cfg.SlackWebhookURL))
+	}
+	if cfg.HTTPSinkURL != "" {
+		sinks = append(sinks, NewHTTPSink(cfg.HTTPSinkURL))
+	}
+	if cfg.SMTPAddr != "" && len(cfg.EmailTo) > 0 {
+		sinks = append(sinks, NewEmailSink(cfg.SMTPAddr, cfg.SMTPHost, cfg.SMTPUsername, cfg.SMTPPassword, cfg.EmailFrom, cfg.EmailTo))
+	}
+
+	return NewAlerter(sinks, cfg.MinInterval)
+}
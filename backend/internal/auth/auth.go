@@ -0,0 +1,73 @@
+// Package auth implements pluggable end-user authentication: a local
+// argon2id password provider, a JWT bearer-token validator, and an
+// OIDC/OAuth2 provider, selected at startup by config.AuthConfig.Provider
+// and consulted through the common Authenticator interface so
+// api/middleware.RequireAuth and the login/logout/profile handlers don't
+// need to know which one is live.
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+// Role values an Identity can carry. RoleUser is the default for
+// self-registered/OIDC accounts; RoleModerator is required by
+// middleware.RequireRole on the admin user-scores API.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+)
+
+// Identity is the authenticated caller api/middleware.RequireAuth injects
+// into the request context, replacing the client-supplied user IDs
+// handlers used to trust directly.
+type Identity struct {
+	UserID            string
+	Username          string
+	Role              string
+	VerificationLevel int
+}
+
+// Credentials carries whatever a provider needs to authenticate a caller:
+// Username/Password for the local provider, Code for OIDC's authorization
+// code exchange. A provider ignores the fields it doesn't use.
+type Credentials struct {
+	Username string
+	Password string
+	Code     string
+}
+
+// Authenticator is implemented by every provider this package ships.
+// Authenticate turns raw Credentials into an Identity (login); IssueToken
+// mints a bearer token for an already-authenticated Identity (the session
+// returned to the client); Validate turns a bearer token back into the
+// Identity it was issued for (checked on every subsequent request).
+type Authenticator interface {
+	Authenticate(ctx context.Context, creds Credentials) (Identity, error)
+	IssueToken(identity Identity) (string, error)
+	Validate(token string) (Identity, error)
+}
+
+// ErrUnsupported is returned by a provider method it deliberately doesn't
+// implement - e.g. JWTProvider.Authenticate, since it validates tokens
+// issued elsewhere rather than accepting credentials itself.
+var ErrUnsupported = fmt.Errorf("unsupported by this auth provider")
+
+// New builds the Authenticator selected by cfg.Provider. store is only
+// consulted for AuthProviderLocal; pass nil for the other providers.
+func New(cfg config.AuthConfig, jwtCfg config.JWTConfig, store Store) (Authenticator, error) {
+	switch cfg.Provider {
+	case config.AuthProviderJWT:
+		return NewJWTProvider(jwtCfg), nil
+	case config.AuthProviderOIDC:
+		return NewOIDCProvider(cfg.OIDC, jwtCfg)
+	default:
+		if store == nil {
+			return nil, fmt.Errorf("the local auth provider requires a database backend that supports auth.Store")
+		}
+		return NewLocalProvider(store, jwtCfg), nil
+	}
+}
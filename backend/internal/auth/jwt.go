@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+// tokenClaims is the JWT payload IssueToken signs and Validate parses
+// back into an Identity.
+type tokenClaims struct {
+	Username          string `json:"username"`
+	Role              string `json:"role"`
+	VerificationLevel int    `json:"verification_level"`
+	jwt.RegisteredClaims
+}
+
+// JWTProvider issues and validates HMAC-signed bearer tokens. It's used
+// standalone when config.AuthConfig.Provider is AuthProviderJWT (tokens
+// are issued by some external system; this instance only validates
+// them), and embedded by LocalProvider/OIDCProvider to mint the session
+// token returned after a successful Authenticate.
+type JWTProvider struct {
+	secret []byte
+	expiry time.Duration
+}
+
+// NewJWTProvider builds a JWTProvider from cfg. expiry falls back to an
+// hour when cfg.ExpiryMinutes is unset.
+func NewJWTProvider(cfg config.JWTConfig) *JWTProvider {
+	expiry := time.Duration(cfg.ExpiryMinutes) * time.Minute
+	if expiry <= 0 {
+		expiry = time.Hour
+	}
+	return &JWTProvider{secret: []byte(cfg.Secret), expiry: expiry}
+}
+
+// Authenticate is unsupported: a standalone JWTProvider only validates
+// tokens issued elsewhere, it doesn't accept credentials.
+func (p *JWTProvider) Authenticate(ctx context.Context, creds Credentials) (Identity, error) {
+	return Identity{}, ErrUnsupported
+}
+
+// IssueToken signs a bearer token for identity, valid for p.expiry.
+func (p *JWTProvider) IssueToken(identity Identity) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims{
+		Username:          identity.Username,
+		Role:              identity.Role,
+		VerificationLevel: identity.VerificationLevel,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   identity.UserID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(p.expiry)),
+		},
+	})
+
+	signed, err := token.SignedString(p.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %v", err)
+	}
+	return signed, nil
+}
+
+// Validate parses and verifies token, returning the Identity it was
+// issued for.
+func (p *JWTProvider) Validate(token string) (Identity, error) {
+	parsed, err := jwt.ParseWithClaims(token, &tokenClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return p.secret, nil
+	})
+	if err != nil {
+		return Identity{}, fmt.Errorf("invalid token: %v", err)
+	}
+
+	claims, ok := parsed.Claims.(*tokenClaims)
+	if !ok || !parsed.Valid {
+		return Identity{}, fmt.Errorf("invalid token claims")
+	}
+
+	return Identity{
+		UserID:            claims.Subject,
+		Username:          claims.Username,
+		Role:              claims.Role,
+		VerificationLevel: claims.VerificationLevel,
+	}, nil
+}
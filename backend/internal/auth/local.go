@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+// argon2id parameters, tuned for an interactive login (not a batch job) -
+// matches the OWASP-recommended minimums for the algorithm.
+const (
+	argon2Time    = 1
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	saltLen       = 16
+)
+
+// LocalProvider authenticates username/password pairs against Store and
+// issues sessions via an embedded JWTProvider - a locally-issued token is
+// just a JWT like the standalone JWTProvider validates, so both share
+// IssueToken/Validate.
+type LocalProvider struct {
+	store Store
+	*JWTProvider
+}
+
+// NewLocalProvider builds a LocalProvider over store, using jwtCfg for
+// the sessions it issues on successful login.
+func NewLocalProvider(store Store, jwtCfg config.JWTConfig) *LocalProvider {
+	return &LocalProvider{store: store, JWTProvider: NewJWTProvider(jwtCfg)}
+}
+
+// Authenticate verifies creds.Username/Password against the stored
+// argon2id hash.
+func (p *LocalProvider) Authenticate(ctx context.Context, creds Credentials) (Identity, error) {
+	cred, err := p.store.GetCredentialByUsername(creds.Username)
+	if err != nil {
+		return Identity{}, fmt.Errorf("failed to look up credential: %v", err)
+	}
+	if cred == nil || !verifyPassword(creds.Password, cred.PasswordHash) {
+		return Identity{}, fmt.Errorf("invalid username or password")
+	}
+	return Identity{UserID: cred.UserID, Username: cred.Username, Role: cred.Role}, nil
+}
+
+// Register hashes password and persists a new credential for userID with
+// RoleUser. The login handler calls this from the registration endpoint -
+// there's no separate signup provider, since creating a local credential
+// is the one thing only LocalProvider can do.
+func (p *LocalProvider) Register(userID, username, password string) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %v", err)
+	}
+	return p.store.CreateCredential(userID, username, hash, RoleUser)
+}
+
+// hashPassword returns "<salt>$<hash>", both base64-encoded, so a single
+// string column round-trips through Store.
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	hash := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("%s$%s", base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash)), nil
+}
+
+func verifyPassword(password, encoded string) bool {
+	saltB64, hashB64, ok := strings.Cut(encoded, "$")
+	if !ok {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(saltB64)
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(hashB64)
+	if err != nil {
+		return false
+	}
+
+	got := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
@@ -0,0 +1,21 @@
+package auth
+
+// Store is the persistence the local password provider needs beyond
+// db.Repository. *sqlite.DBManager satisfies it; this package never
+// imports internal/db/sqlite, so there is no cycle - the same split
+// decisions.Store and moderation.Store use.
+type Store interface {
+	CreateCredential(userID, username, passwordHash, role string) error
+	GetCredentialByUsername(username string) (*Credential, error)
+}
+
+// Credential is one local username/password registration. Role defaults
+// to RoleUser for self-service registration - moderator accounts are
+// provisioned out-of-band (directly against the store) rather than
+// through the public register endpoint.
+type Credential struct {
+	UserID       string
+	Username     string
+	PasswordHash string
+	Role         string
+}
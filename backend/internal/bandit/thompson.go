@@ -0,0 +1,90 @@
+// Package bandit implements Thompson sampling over Beta-distributed arm
+// posteriors, shared by every db.Repository backend's experiment
+// subsystem so the sampling math lives in exactly one place.
+package bandit
+
+import (
+	"math"
+	"math/rand"
+)
+
+// ArmPosterior is an arm's current Beta(Alpha, Beta) reward posterior.
+type ArmPosterior struct {
+	ArmID string
+	Alpha float64
+	Beta  float64
+}
+
+// SelectArm samples theta ~ Beta(Alpha, Beta) for every arm and returns
+// the ID of the arm with the highest sample. Panics if arms is empty -
+// callers are expected to have already validated the experiment has arms.
+func SelectArm(arms []ArmPosterior) string {
+	best := arms[0]
+	bestSample := sampleBeta(best.Alpha, best.Beta)
+
+	for _, arm := range arms[1:] {
+		sample := sampleBeta(arm.Alpha, arm.Beta)
+		if sample > bestSample {
+			best, bestSample = arm, sample
+		}
+	}
+
+	return best.ArmID
+}
+
+// UpdatePosterior folds a reward observation into (alpha, beta). reward
+// is clamped to [0, 1] and treated as a Bernoulli-ish success
+// probability, so a continuous engagement signal (e.g. normalized
+// dwell time) works as well as a binary one.
+func UpdatePosterior(alpha, beta, reward float64) (newAlpha, newBeta float64) {
+	if reward < 0 {
+		reward = 0
+	}
+	if reward > 1 {
+		reward = 1
+	}
+	return alpha + reward, beta + (1 - reward)
+}
+
+// sampleBeta draws from Beta(alpha, beta) via the standard Gamma ratio:
+// X ~ Gamma(alpha), Y ~ Gamma(beta), X/(X+Y) ~ Beta(alpha, beta).
+func sampleBeta(alpha, beta float64) float64 {
+	x := sampleGamma(alpha)
+	y := sampleGamma(beta)
+	return x / (x + y)
+}
+
+// sampleGamma draws from Gamma(shape, 1) using the Marsaglia-Tsang
+// method. shape must be > 0; shapes below 1 are boosted via the standard
+// Gamma(shape+1) transform so the method's d = shape-1/3 term stays
+// well-behaved.
+func sampleGamma(shape float64) float64 {
+	if shape < 1 {
+		u := rand.Float64()
+		return sampleGamma(shape+1) * math.Pow(u, 1/shape)
+	}
+
+	d := shape - 1.0/3.0
+	c := 1.0 / math.Sqrt(9*d)
+
+	for {
+		var x, v float64
+		for {
+			x = rand.NormFloat64()
+			v = 1 + c*x
+			if v > 0 {
+				break
+			}
+		}
+		v = v * v * v
+
+		u := rand.Float64()
+		x2 := x * x
+		if u < 1-0.0331*x2*x2 {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
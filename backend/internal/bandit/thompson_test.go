@@ -0,0 +1,61 @@
+package bandit
+
+import "testing"
+
+func TestUpdatePosteriorClampsRewardAndAccumulates(t *testing.T) {
+	tests := []struct {
+		name        string
+		alpha, beta float64
+		reward      float64
+		wantAlpha   float64
+		wantBeta    float64
+	}{
+		{"full reward", 1, 1, 1, 2, 1},
+		{"zero reward", 1, 1, 0, 1, 2},
+		{"fractional reward", 1, 1, 0.25, 1.25, 1.75},
+		{"reward above 1 clamps to 1", 1, 1, 1.5, 2, 1},
+		{"negative reward clamps to 0", 1, 1, -0.5, 1, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAlpha, gotBeta := UpdatePosterior(tt.alpha, tt.beta, tt.reward)
+			if gotAlpha != tt.wantAlpha || gotBeta != tt.wantBeta {
+				t.Errorf("UpdatePosterior(%v, %v, %v) = (%v, %v), want (%v, %v)",
+					tt.alpha, tt.beta, tt.reward, gotAlpha, gotBeta, tt.wantAlpha, tt.wantBeta)
+			}
+		})
+	}
+}
+
+// TestSelectArmFavorsStrongerPosterior doesn't assert a single sample's
+// outcome (Thompson sampling is inherently randomized), but an arm with an
+// overwhelming Beta(1000, 1) posterior against a Beta(1, 1000) one should
+// win nearly every draw - if SelectArm ever picked the weak arm more than
+// a handful of times out of many, something deeper than sampling noise
+// would be wrong (e.g. the arms swapped, or sampleBeta is miscomputing).
+func TestSelectArmFavorsStrongerPosterior(t *testing.T) {
+	arms := []ArmPosterior{
+		{ArmID: "strong", Alpha: 1000, Beta: 1},
+		{ArmID: "weak", Alpha: 1, Beta: 1000},
+	}
+
+	const trials = 200
+	strongWins := 0
+	for i := 0; i < trials; i++ {
+		if SelectArm(arms) == "strong" {
+			strongWins++
+		}
+	}
+
+	if strongWins < trials-5 {
+		t.Errorf("strong arm won %d/%d draws, want it to dominate a 1000:1 vs 1:1000 posterior", strongWins, trials)
+	}
+}
+
+func TestSelectArmSingleArmAlwaysWins(t *testing.T) {
+	arms := []ArmPosterior{{ArmID: "only", Alpha: 1, Beta: 1}}
+	if got := SelectArm(arms); got != "only" {
+		t.Errorf("SelectArm with one arm: got %q, want %q", got, "only")
+	}
+}
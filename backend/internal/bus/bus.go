@@ -0,0 +1,104 @@
+// Package bus provides a pluggable publish/subscribe transport so that
+// in-process components such as the WebSocket hub can fan out events across
+// multiple API server instances.
+package bus
+
+import (
+	"sync"
+)
+
+// Bus is implemented by any pub/sub transport. Payloads are opaque bytes so
+// that both in-memory and networked implementations (e.g. Redis) can share
+// the same interface without forcing callers into a specific wire format.
+type Bus interface {
+	// Publish delivers payload to every current subscriber of topic.
+	Publish(topic string, payload []byte) error
+
+	// Subscribe returns a channel that receives payloads published to topic.
+	// The returned channel is closed when Unsubscribe is called with it.
+	Subscribe(topic string) (<-chan []byte, error)
+
+	// Unsubscribe stops delivery to a channel previously returned by Subscribe.
+	Unsubscribe(topic string, ch <-chan []byte) error
+
+	// Close releases any resources held by the bus.
+	Close() error
+}
+
+// InMemoryBus implements Bus using local Go channels. It is the default
+// transport and preserves the hub's original single-process behavior.
+type InMemoryBus struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan []byte]struct{}
+}
+
+// NewInMemoryBus creates a new in-process bus.
+func NewInMemoryBus() *InMemoryBus {
+	return &InMemoryBus{
+		subs: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+// Publish implements Bus.
+func (b *InMemoryBus) Publish(topic string, payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subs[topic] {
+		select {
+		case ch <- payload:
+		default:
+			// Slow subscriber; drop rather than block the publisher.
+		}
+	}
+	return nil
+}
+
+// Subscribe implements Bus.
+func (b *InMemoryBus) Subscribe(topic string) (<-chan []byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan []byte, 256)
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[chan []byte]struct{})
+	}
+	b.subs[topic][ch] = struct{}{}
+	return ch, nil
+}
+
+// Unsubscribe implements Bus.
+func (b *InMemoryBus) Unsubscribe(topic string, ch <-chan []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subs, ok := b.subs[topic]
+	if !ok {
+		return nil
+	}
+	for c := range subs {
+		if c == ch {
+			delete(subs, c)
+			close(c)
+			break
+		}
+	}
+	if len(subs) == 0 {
+		delete(b.subs, topic)
+	}
+	return nil
+}
+
+// Close implements Bus.
+func (b *InMemoryBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for topic, subs := range b.subs {
+		for c := range subs {
+			close(c)
+		}
+		delete(b.subs, topic)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package bus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+// RedisBus implements Bus on top of Redis Pub/Sub so that events published
+// by one API server instance are delivered to clients connected to any
+// other instance.
+type RedisBus struct {
+	client *redis.Client
+	ctx    context.Context
+
+	mu   sync.Mutex
+	subs map[string]*redisSubscription
+}
+
+type redisSubscription struct {
+	pubsub *redis.PubSub
+	chans  map[chan []byte]struct{}
+}
+
+// NewRedisBus creates a Bus backed by the given Redis configuration.
+func NewRedisBus(cfg config.RedisConfig) *RedisBus {
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Address,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+
+	return &RedisBus{
+		client: client,
+		ctx:    context.Background(),
+		subs:   make(map[string]*redisSubscription),
+	}
+}
+
+// Publish implements Bus.
+func (b *RedisBus) Publish(topic string, payload []byte) error {
+	return b.client.Publish(b.ctx, topic, payload).Err()
+}
+
+// Subscribe implements Bus.
+func (b *RedisBus) Subscribe(topic string) (<-chan []byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan []byte, 256)
+
+	sub, exists := b.subs[topic]
+	if !exists {
+		pubsub := b.client.Subscribe(b.ctx, topic)
+		sub = &redisSubscription{
+			pubsub: pubsub,
+			chans:  make(map[chan []byte]struct{}),
+		}
+		b.subs[topic] = sub
+		go b.forward(topic, sub)
+	}
+	sub.chans[ch] = struct{}{}
+
+	return ch, nil
+}
+
+// forward reads messages from the Redis subscription for topic and fans
+// them out to every locally registered channel.
+func (b *RedisBus) forward(topic string, sub *redisSubscription) {
+	for msg := range sub.pubsub.Channel() {
+		payload := []byte(msg.Payload)
+
+		b.mu.Lock()
+		for ch := range sub.chans {
+			select {
+			case ch <- payload:
+			default:
+			}
+		}
+		b.mu.Unlock()
+	}
+}
+
+// Unsubscribe implements Bus.
+func (b *RedisBus) Unsubscribe(topic string, ch <-chan []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sub, ok := b.subs[topic]
+	if !ok {
+		return nil
+	}
+
+	for c := range sub.chans {
+		if c == ch {
+			delete(sub.chans, c)
+			close(c)
+			break
+		}
+	}
+
+	if len(sub.chans) == 0 {
+		sub.pubsub.Close()
+		delete(b.subs, topic)
+	}
+
+	return nil
+}
+
+// Close implements Bus.
+func (b *RedisBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for topic, sub := range b.subs {
+		sub.pubsub.Close()
+		for c := range sub.chans {
+			close(c)
+		}
+		delete(b.subs, topic)
+	}
+
+	return b.client.Close()
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrStale is returned by Backend.CompareAndSwap when the version the
+// caller observed at read time no longer matches what's stored - someone
+// else won the race to write this key first. Callers should re-derive
+// the value (re-read, recompute) and retry rather than overwrite it.
+var ErrStale = errors.New("cache: stale write rejected")
+
+// Entry is a versioned cache value. Version starts at 1 on the first
+// successful write and increments on every subsequent CompareAndSwap, so
+// 0 always means "never written" and can be used as the expected version
+// for a blind first write.
+type Entry struct {
+	Value   []byte
+	Version int64
+}
+
+// Backend is the storage underneath Cache. Values are opaque bytes (the
+// Cache layer owns JSON-encoding UserScoreCache/ContentScoreCache) so
+// InMemory and Redis implementations don't need to know the shape of
+// what they're storing.
+type Backend interface {
+	// Get returns key's current entry. found is false if key doesn't
+	// exist or has expired.
+	Get(ctx context.Context, key string) (entry Entry, found bool, err error)
+
+	// CompareAndSwap stores value under key if the stored version equals
+	// expectedVersion (0 meaning "didn't exist"), returning the new
+	// version. It returns ErrStale, without writing, if the stored
+	// version has moved on.
+	CompareAndSwap(ctx context.Context, key string, expectedVersion int64, value []byte, ttl time.Duration) (newVersion int64, err error)
+
+	// Delete removes key, if present. It is not an error for key to be
+	// absent already.
+	Delete(ctx context.Context, key string) error
+
+	// Close releases any resources (connections, background goroutines)
+	// the backend holds.
+	Close() error
+}
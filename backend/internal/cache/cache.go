@@ -1,24 +1,46 @@
+// Package cache caches user and content scores behind a versioned,
+// optimistic-concurrency Backend (InMemoryBackend by default, RedisBackend
+// when multiple API server instances need to share one cache), and keeps
+// those instances coherent by publishing an InvalidationEvent to
+// internal/bus whenever a write lands.
 package cache
 
 import (
-	"sync"
+	"context"
+	"encoding/json"
 	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/saint/babel-protocol/backend/internal/bus"
 )
 
-// Cache implements a thread-safe in-memory cache
-type Cache struct {
-	mu            sync.RWMutex
-	userScores    map[string]*UserScoreCache
-	contentScores map[string]*ContentScoreCache
-	ttl           time.Duration
+// InvalidationTopic is the bus topic Cache publishes InvalidationEvents to
+// and subscribes on, so every Cache sharing a bus evicts its local copy of
+// a key as soon as any one of them writes it.
+const InvalidationTopic = "cache:invalidation"
+
+// InvalidationEvent announces that Kind/Key was written at Version by the
+// Cache instance Origin. Cache ignores events it published itself -
+// Origin lets it tell the difference without a round trip back to the
+// backend.
+type InvalidationEvent struct {
+	Kind    string `json:"kind"` // "user" or "content"
+	Key     string `json:"key"`
+	Version int64  `json:"version"`
+	Origin  string `json:"origin"`
 }
 
+// UserScoreCache is one user's cached score snapshot.
 type UserScoreCache struct {
 	Scores      map[string]float64
 	LastUpdated time.Time
 	Version     int64
 }
 
+// ContentScoreCache is one content item's cached score snapshot.
 type ContentScoreCache struct {
 	TruthScore      float64
 	VisibilityScore float64
@@ -26,95 +48,186 @@ type ContentScoreCache struct {
 	Version         int64
 }
 
-// NewCache creates a new cache instance
-func NewCache(ttl time.Duration) *Cache {
-	cache := &Cache{
-		userScores:    make(map[string]*UserScoreCache),
-		contentScores: make(map[string]*ContentScoreCache),
-		ttl:           ttl,
+// Cache caches user and content scores on top of a Backend. Reads return
+// the Version the caller observed; writes take that version back as
+// expectedVersion so a caller that computed a score from stale data loses
+// the race to ErrStale instead of clobbering a newer value.
+type Cache struct {
+	backend    Backend
+	ttl        time.Duration
+	bus        bus.Bus
+	instanceID string
+}
+
+// NewCache builds a Cache over backend, optionally wiring it to b for
+// cross-instance invalidation (pass nil to run standalone, e.g. in tests
+// or a single-instance deployment).
+func NewCache(ttl time.Duration, backend Backend, b bus.Bus) *Cache {
+	c := &Cache{
+		backend:    backend,
+		ttl:        ttl,
+		bus:        b,
+		instanceID: uuid.NewString(),
 	}
-	go cache.cleanup()
-	return cache
+	if b != nil {
+		go c.subscribeInvalidations()
+	}
+	return c
 }
 
-// GetUserScores retrieves cached user scores
-func (c *Cache) GetUserScores(userID string) (map[string]float64, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// NewInMemoryCache is the common case: a Cache over a fresh
+// InMemoryBackend with no cross-instance invalidation. Most callers that
+// don't run multiple API server instances against a shared Redis want
+// this.
+func NewInMemoryCache(ttl time.Duration) *Cache {
+	return NewCache(ttl, NewInMemoryBackend(), nil)
+}
 
-	if cache, exists := c.userScores[userID]; exists {
-		if time.Since(cache.LastUpdated) < c.ttl {
-			return cache.Scores, true
-		}
+// recordCacheEvent adds a "cache hit"/"cache miss" event to ctx's span, if
+// any, so a trace through a manager's Get*Scores call shows whether it hit
+// the cache or fell through to the database/algorithm service.
+func recordCacheEvent(ctx context.Context, operation, key string, hit bool) {
+	name := "cache miss"
+	if hit {
+		name = "cache hit"
 	}
-	return nil, false
+	trace.SpanFromContext(ctx).AddEvent(name, trace.WithAttributes(
+		attribute.String("cache.operation", operation),
+		attribute.String("cache.key", key),
+	))
 }
 
-// SetUserScores caches user scores
-func (c *Cache) SetUserScores(userID string, scores map[string]float64) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func userKey(userID string) string       { return "user:" + userID }
+func contentKey(contentID string) string { return "content:" + contentID }
+
+// GetUserScores retrieves userID's cached scores. version is the entry's
+// current version (0 on a miss) - pass it back to SetUserScores to avoid
+// overwriting a write that happened in between.
+func (c *Cache) GetUserScores(ctx context.Context, userID string) (scores map[string]float64, version int64, hit bool) {
+	entry, found, err := c.backend.Get(ctx, userKey(userID))
+	if err != nil || !found {
+		recordCacheEvent(ctx, "GetUserScores", userID, false)
+		return nil, 0, false
+	}
 
-	c.userScores[userID] = &UserScoreCache{
-		Scores:      scores,
-		LastUpdated: time.Now(),
-		Version:     time.Now().UnixNano(),
+	var cached UserScoreCache
+	if err := json.Unmarshal(entry.Value, &cached); err != nil {
+		recordCacheEvent(ctx, "GetUserScores", userID, false)
+		return nil, 0, false
 	}
+
+	recordCacheEvent(ctx, "GetUserScores", userID, true)
+	return cached.Scores, entry.Version, true
 }
 
-// GetContentScores retrieves cached content scores
-func (c *Cache) GetContentScores(contentID string) (*ContentScoreCache, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+// SetUserScores stores userID's scores if expectedVersion still matches
+// what's in the backend (see Cache's doc comment), publishing an
+// InvalidationEvent on success so other Cache instances sharing a bus
+// drop their local copy.
+func (c *Cache) SetUserScores(ctx context.Context, userID string, scores map[string]float64, expectedVersion int64) (newVersion int64, err error) {
+	value, err := json.Marshal(UserScoreCache{Scores: scores, LastUpdated: time.Now()})
+	if err != nil {
+		return 0, err
+	}
 
-	if cache, exists := c.contentScores[contentID]; exists {
-		if time.Since(cache.LastUpdated) < c.ttl {
-			return cache, true
-		}
+	newVersion, err = c.backend.CompareAndSwap(ctx, userKey(userID), expectedVersion, value, c.ttl)
+	if err != nil {
+		return 0, err
 	}
-	return nil, false
+
+	c.publishInvalidation("user", userID, newVersion)
+	return newVersion, nil
 }
 
-// SetContentScores caches content scores
-func (c *Cache) SetContentScores(contentID string, truth, visibility float64) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// GetContentScores retrieves contentID's cached scores.
+func (c *Cache) GetContentScores(ctx context.Context, contentID string) (*ContentScoreCache, bool) {
+	entry, found, err := c.backend.Get(ctx, contentKey(contentID))
+	if err != nil || !found {
+		recordCacheEvent(ctx, "GetContentScores", contentID, false)
+		return nil, false
+	}
 
-	c.contentScores[contentID] = &ContentScoreCache{
-		TruthScore:      truth,
-		VisibilityScore: visibility,
-		LastUpdated:     time.Now(),
-		Version:         time.Now().UnixNano(),
+	var cached ContentScoreCache
+	if err := json.Unmarshal(entry.Value, &cached); err != nil {
+		recordCacheEvent(ctx, "GetContentScores", contentID, false)
+		return nil, false
 	}
+	cached.Version = entry.Version
+
+	recordCacheEvent(ctx, "GetContentScores", contentID, true)
+	return &cached, true
 }
 
-// Invalidate removes entries from cache
-func (c *Cache) Invalidate(keys ...string) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// SetContentScores stores contentID's truth/visibility scores if
+// expectedVersion still matches what's in the backend - pass the Version
+// from a prior GetContentScores, or 0 after a cache miss.
+func (c *Cache) SetContentScores(ctx context.Context, contentID string, truth, visibility float64, expectedVersion int64) (newVersion int64, err error) {
+	value, err := json.Marshal(ContentScoreCache{TruthScore: truth, VisibilityScore: visibility, LastUpdated: time.Now()})
+	if err != nil {
+		return 0, err
+	}
+
+	newVersion, err = c.backend.CompareAndSwap(ctx, contentKey(contentID), expectedVersion, value, c.ttl)
+	if err != nil {
+		return 0, err
+	}
 
+	c.publishInvalidation("content", contentID, newVersion)
+	return newVersion, nil
+}
+
+// Invalidate evicts userID's and contentID's cached entries, wherever
+// they appear - callers pass whichever IDs they know changed, not caring
+// which ones are actually user vs. content keys.
+func (c *Cache) Invalidate(ctx context.Context, keys ...string) {
 	for _, key := range keys {
-		delete(c.userScores, key)
-		delete(c.contentScores, key)
+		_ = c.backend.Delete(ctx, userKey(key))
+		_ = c.backend.Delete(ctx, contentKey(key))
+	}
+}
+
+// publishInvalidation announces a write to InvalidationTopic, if this
+// Cache has a bus. Publish errors are swallowed - the write to backend
+// already succeeded, and a missed invalidation only costs other instances
+// a stale read until their own TTL expires, not correctness.
+func (c *Cache) publishInvalidation(kind, key string, version int64) {
+	if c.bus == nil {
+		return
 	}
+	payload, err := json.Marshal(InvalidationEvent{Kind: kind, Key: key, Version: version, Origin: c.instanceID})
+	if err != nil {
+		return
+	}
+	_ = c.bus.Publish(InvalidationTopic, payload)
 }
 
-// cleanup periodically removes expired entries
-func (c *Cache) cleanup() {
-	ticker := time.NewTicker(c.ttl)
-	for range ticker.C {
-		c.mu.Lock()
-		now := time.Now()
-		for id, cache := range c.userScores {
-			if now.Sub(cache.LastUpdated) > c.ttl {
-				delete(c.userScores, id)
-			}
+// subscribeInvalidations evicts this Cache's local copy of any key
+// another instance just wrote, so a stale read never outlives the TTL it
+// would otherwise take to expire naturally. It runs for the Cache's
+// lifetime; there's currently no Close() to tear it down, matching the
+// rest of this package's backends (InMemoryBackend.Close stops its own
+// evictor, but nothing currently calls it either).
+func (c *Cache) subscribeInvalidations() {
+	ch, err := c.bus.Subscribe(InvalidationTopic)
+	if err != nil {
+		return
+	}
+
+	ctx := context.Background()
+	for payload := range ch {
+		var evt InvalidationEvent
+		if err := json.Unmarshal(payload, &evt); err != nil {
+			continue
+		}
+		if evt.Origin == c.instanceID {
+			continue
 		}
-		for id, cache := range c.contentScores {
-			if now.Sub(cache.LastUpdated) > c.ttl {
-				delete(c.contentScores, id)
-			}
+
+		switch evt.Kind {
+		case "user":
+			_ = c.backend.Delete(ctx, userKey(evt.Key))
+		case "content":
+			_ = c.backend.Delete(ctx, contentKey(evt.Key))
 		}
-		c.mu.Unlock()
 	}
 }
@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"container/heap"
+	"context"
+	"crypto/sha1"
+	"sync"
+	"time"
+)
+
+// shardCount is the number of independently-locked shards InMemoryBackend
+// splits its keyspace across, so a hot key in one shard doesn't block a
+// read/write to an unrelated key in another.
+const shardCount = 16
+
+// InMemoryBackend is a process-local Backend. Keys are sharded by hash to
+// spread lock contention, and each shard expires entries off a min-heap
+// keyed by deadline rather than scanning every entry on a ticker, so
+// eviction cost stays proportional to how much has actually expired
+// rather than to the shard's total size.
+type InMemoryBackend struct {
+	shards [shardCount]*shard
+	stop   chan struct{}
+}
+
+type shard struct {
+	mu      sync.Mutex
+	entries map[string]memEntry
+	expiry  expiryHeap
+}
+
+type memEntry struct {
+	value     []byte
+	version   int64
+	expiresAt time.Time
+}
+
+// NewInMemoryBackend starts an InMemoryBackend and its background
+// evictor, which wakes on whichever shard's next entry is soonest to
+// expire rather than polling on a fixed tick.
+func NewInMemoryBackend() *InMemoryBackend {
+	b := &InMemoryBackend{stop: make(chan struct{})}
+	for i := range b.shards {
+		b.shards[i] = &shard{entries: make(map[string]memEntry)}
+		go b.evictLoop(b.shards[i])
+	}
+	return b
+}
+
+func (b *InMemoryBackend) shardFor(key string) *shard {
+	sum := sha1.Sum([]byte(key))
+	return b.shards[int(sum[0])%shardCount]
+}
+
+func (b *InMemoryBackend) Get(ctx context.Context, key string) (Entry, bool, error) {
+	s := b.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return Entry{}, false, nil
+	}
+	return Entry{Value: e.value, Version: e.version}, true, nil
+}
+
+func (b *InMemoryBackend) CompareAndSwap(ctx context.Context, key string, expectedVersion int64, value []byte, ttl time.Duration) (int64, error) {
+	s := b.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, exists := s.entries[key]
+	liveVersion := int64(0)
+	if exists && time.Now().Before(current.expiresAt) {
+		liveVersion = current.version
+	}
+	if liveVersion != expectedVersion {
+		return 0, ErrStale
+	}
+
+	newVersion := liveVersion + 1
+	expiresAt := time.Now().Add(ttl)
+	s.entries[key] = memEntry{value: value, version: newVersion, expiresAt: expiresAt}
+	heap.Push(&s.expiry, &expiryItem{key: key, expiresAt: expiresAt})
+	return newVersion, nil
+}
+
+func (b *InMemoryBackend) Delete(ctx context.Context, key string) error {
+	s := b.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+func (b *InMemoryBackend) Close() error {
+	close(b.stop)
+	return nil
+}
+
+// evictLoop sleeps until the shard's soonest-expiring entry is due, wakes
+// to evict it (and anything else that's expired by then), then
+// recomputes how long to sleep next. It re-checks every 5 seconds even
+// with an empty heap so entries pushed after the loop went to sleep are
+// picked up promptly.
+func (b *InMemoryBackend) evictLoop(s *shard) {
+	const idleRecheck = 5 * time.Second
+	timer := time.NewTimer(idleRecheck)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-timer.C:
+			timer.Reset(s.evictDue(idleRecheck))
+		}
+	}
+}
+
+// evictDue pops and discards every heap entry at or past expiry whose
+// map entry is still the one the heap item was pushed for (a key may
+// have been overwritten or deleted since), then returns how long until
+// the next one is due.
+func (s *shard) evictDue(idleRecheck time.Duration) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for s.expiry.Len() > 0 {
+		next := s.expiry[0]
+		if next.expiresAt.After(now) {
+			return next.expiresAt.Sub(now)
+		}
+		heap.Pop(&s.expiry)
+
+		if e, ok := s.entries[next.key]; ok && !e.expiresAt.After(now) {
+			delete(s.entries, next.key)
+		}
+	}
+	return idleRecheck
+}
+
+// expiryItem is one shard.expiry heap element.
+type expiryItem struct {
+	key       string
+	expiresAt time.Time
+}
+
+// expiryHeap is a container/heap.Interface min-heap ordered by expiresAt.
+// Stale entries (superseded by a later CompareAndSwap, or deleted) are
+// left in place and skipped by evictDue rather than removed eagerly -
+// removing an arbitrary heap element requires its index, which would mean
+// tracking one more map.
+type expiryHeap []*expiryItem
+
+func (h expiryHeap) Len() int            { return len(h) }
+func (h expiryHeap) Less(i, j int) bool  { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h expiryHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x interface{}) { *h = append(*h, x.(*expiryItem)) }
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
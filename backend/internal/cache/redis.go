@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// casScript atomically compares the stored version (the "v" field of a
+// hash at KEYS[1]) against ARGV[1] and, if it matches, writes ARGV[2] as
+// the new value with an incremented version and refreshes the TTL
+// (ARGV[3], in milliseconds). It returns the new version, or -1 if the
+// comparison failed - the whole check-and-write has to happen in one
+// round trip or two concurrent writers could both pass a GET-then-SET.
+var casScript = redis.NewScript(`
+local current = redis.call("HGET", KEYS[1], "v")
+local expected = tonumber(ARGV[1])
+if current == false then
+	current = 0
+else
+	current = tonumber(current)
+end
+if current ~= expected then
+	return -1
+end
+local newVersion = current + 1
+redis.call("HSET", KEYS[1], "v", newVersion, "d", ARGV[2])
+redis.call("PEXPIRE", KEYS[1], ARGV[3])
+return newVersion
+`)
+
+// RedisBackend is a Backend over Redis, giving every process sharing a
+// Redis instance a consistent view of cached scores and a single source
+// of truth for CompareAndSwap - unlike InMemoryBackend, a RedisBackend's
+// CAS guarantee holds across API server instances, not just goroutines.
+type RedisBackend struct {
+	client *redis.Client
+}
+
+// NewRedisBackend connects to the Redis instance at redisURL (a
+// "redis://" URL, as accepted by redis.ParseURL). It takes a plain
+// string rather than internal/config.RedisConfig or api.Config so this
+// package doesn't have to import either - internal/recommend already
+// imports internal/cache, and api imports internal/recommend, so an
+// import back to api here would cycle.
+func NewRedisBackend(redisURL string) (*RedisBackend, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisBackend{client: redis.NewClient(opts)}, nil
+}
+
+func (b *RedisBackend) Get(ctx context.Context, key string) (Entry, bool, error) {
+	result, err := b.client.HMGet(ctx, key, "v", "d").Result()
+	if err != nil {
+		return Entry{}, false, err
+	}
+	if result[0] == nil || result[1] == nil {
+		return Entry{}, false, nil
+	}
+
+	versionStr, ok := result[0].(string)
+	if !ok {
+		return Entry{}, false, nil
+	}
+	data, ok := result[1].(string)
+	if !ok {
+		return Entry{}, false, nil
+	}
+	version, err := strconv.ParseInt(versionStr, 10, 64)
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	return Entry{Value: []byte(data), Version: version}, true, nil
+}
+
+func (b *RedisBackend) CompareAndSwap(ctx context.Context, key string, expectedVersion int64, value []byte, ttl time.Duration) (int64, error) {
+	result, err := casScript.Run(ctx, b.client, []string{key}, expectedVersion, value, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if result == -1 {
+		return 0, ErrStale
+	}
+	return result, nil
+}
+
+func (b *RedisBackend) Delete(ctx context.Context, key string) error {
+	return b.client.Del(ctx, key).Err()
+}
+
+func (b *RedisBackend) Close() error {
+	return b.client.Close()
+}
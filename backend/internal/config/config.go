@@ -10,36 +10,224 @@ import (
 	"github.com/joho/godotenv"
 )
 
-// Config holds all configuration for the application
+// Config holds all configuration for the application. JSON tags give
+// ConfigHandler a stable path vocabulary (e.g. "/rate_limit/requests") for
+// MarshalJSONPath/UnmarshalJSONPath.
 type Config struct {
-	Environment         string
-	ServerAddress       string
-	DatabasePath        string
-	AlgorithmServiceURL string
-	RateLimit           RateLimit
-	Redis               RedisConfig
-	JWT                 JWTConfig
+	Environment          string              `json:"environment"`
+	ServerAddress        string              `json:"server_address"`
+	DatabasePath         string              `json:"database_path"`
+	DatabaseBackend      string              `json:"database_backend"`
+	PostgresDSN          string              `json:"postgres_dsn"`
+	MongoURI             string              `json:"mongo_uri"`
+	MongoDatabase        string              `json:"mongo_database"`
+	AlgorithmServiceURL  string              `json:"algorithm_service_url"`
+	AlgorithmEnrollToken string              `json:"algorithm_enroll_token"`
+	RateLimit            RateLimit           `json:"rate_limit"`
+	Redis                RedisConfig         `json:"redis"`
+	JWT                  JWTConfig           `json:"jwt"`
+	Auth                 AuthConfig          `json:"auth"`
+	TLS                  TLSConfig           `json:"tls"`
+	Alert                AlertConfig         `json:"alert"`
+	MetricsToken         string              `json:"metrics_token"`
+	HCS                  HCSConfig           `json:"hcs"`
+	Federation           FederationConfig    `json:"federation"`
+	Observability        ObservabilityConfig `json:"observability"`
+	Scoring              ScoringConfig       `json:"scoring"`
+	Batch                BatchConfig         `json:"batch"`
 }
 
-// RateLimit holds rate limiting configuration
+// Rate limit backend selection. Values match the RATE_LIMIT_BACKEND config
+// setting.
+const (
+	RateLimitBackendMemory = "memory"
+	RateLimitBackendRedis  = "redis"
+)
+
+// RouteLimit overrides the default RequestsPerSecond/Burst for one route
+// key - the string internal/ratelimit.Limiter.Middleware is called with,
+// e.g. "send_direct_message" for a stricter cap than read-only routes.
+// ConcurrencyLimit, if non-zero, additionally caps how many requests under
+// that route key this instance will serve at once, independent of the QPS
+// bucket - zero means unlimited.
+type RouteLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+	ConcurrencyLimit  uint64  `json:"concurrency_limit"`
+}
+
+// ReputationTier scales a caller's effective rate limit by their
+// User.ReputationScore: of every tier whose MinReputation the caller meets
+// or exceeds, the one with the highest MinReputation applies, so a
+// brand-new account (reputation 0) falls through to RateLimit's base
+// RequestsPerSecond/Burst.
+type ReputationTier struct {
+	MinReputation     float64 `json:"min_reputation"`
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"`
+}
+
+// RateLimit holds rate limiting configuration. The base RequestsPerSecond/
+// Burst apply to a caller with no matching ReputationTier; RouteOverrides
+// replaces them (not scales them) for a specific route key before tiers
+// are applied.
 type RateLimit struct {
-	Enabled   bool
-	Requests  int
-	Window    time.Duration
-	WhiteList []string
+	Enabled           bool                  `json:"enabled"`
+	Backend           string                `json:"backend"`
+	RequestsPerSecond float64               `json:"requests_per_second"`
+	Burst             int                   `json:"burst"`
+	WhiteList         []string              `json:"whitelist"`
+	RouteOverrides    map[string]RouteLimit `json:"route_overrides"`
+	ReputationTiers   []ReputationTier      `json:"reputation_tiers"`
 }
 
 // RedisConfig holds Redis configuration
 type RedisConfig struct {
-	Address  string
-	Password string
-	DB       int
+	Address  string `json:"address"`
+	Password string `json:"password"`
+	DB       int    `json:"db"`
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret        string
-	ExpiryMinutes int
+	Secret        string `json:"secret"`
+	ExpiryMinutes int    `json:"expiry_minutes"`
+}
+
+// Auth provider selection. Values match the AUTH_PROVIDER config setting;
+// internal/auth.New picks the matching Authenticator implementation.
+const (
+	AuthProviderLocal = "local"
+	AuthProviderJWT   = "jwt"
+	AuthProviderOIDC  = "oidc"
+)
+
+// AuthConfig selects which internal/auth.Authenticator backs login and
+// session validation. OIDC is only consulted when Provider is
+// AuthProviderOIDC.
+type AuthConfig struct {
+	Provider string     `json:"provider"`
+	OIDC     OIDCConfig `json:"oidc"`
+}
+
+// OIDCConfig holds an external OIDC/OAuth2 identity provider's client
+// registration.
+type OIDCConfig struct {
+	IssuerURL    string   `json:"issuer_url"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	RedirectURL  string   `json:"redirect_url"`
+	Scopes       []string `json:"scopes"`
+	AuthURL      string   `json:"auth_url"`
+	TokenURL     string   `json:"token_url"`
+}
+
+// TLS auth types for TLSConfig.AuthType
+const (
+	AuthTypeNone = "none"
+	AuthTypeTLS  = "tls"
+	AuthTypeMTLS = "mtls"
+)
+
+// TLSConfig holds the TLS material used to talk to the algorithm service.
+// AuthType selects how strict the connection is: "none" for plaintext,
+// "tls" to verify the server cert only, "mtls" to also present a client
+// cert obtained via the bootstrap-enrollment flow.
+type TLSConfig struct {
+	CAFile   string `json:"ca_file"`
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	AuthType string `json:"auth_type"`
+}
+
+// AlertConfig holds the critical-alert subsystem's rate limiting and sink
+// configuration. Any sink field left blank is simply not constructed.
+type AlertConfig struct {
+	MinInterval     time.Duration `json:"min_interval"`
+	SlackWebhookURL string        `json:"slack_webhook_url"`
+	HTTPSinkURL     string        `json:"http_sink_url"`
+	SMTPAddr        string        `json:"smtp_addr"`
+	SMTPHost        string        `json:"smtp_host"`
+	SMTPUsername    string        `json:"smtp_username"`
+	SMTPPassword    string        `json:"smtp_password"`
+	EmailFrom       string        `json:"email_from"`
+	EmailTo         []string      `json:"email_to"`
+}
+
+// HCSConfig holds the Hedera Consensus Service mirroring subsystem's
+// configuration. NodeKey signs outbox messages and must stay stable for
+// Verify to accept previously-signed messages on replay.
+type HCSConfig struct {
+	Enabled       bool          `json:"enabled"`
+	AccountID     string        `json:"account_id"`
+	PrivateKey    string        `json:"private_key"`
+	NodeKey       string        `json:"node_key"`
+	TopicContent  string        `json:"topic_content"`
+	TopicVote     string        `json:"topic_vote"`
+	TopicEvidence string        `json:"topic_evidence"`
+	PollInterval  time.Duration `json:"poll_interval"`
+	BatchSize     int           `json:"batch_size"`
+}
+
+// FederationConfig holds the ActivityPub-style federation subsystem's
+// configuration. Per-remote trust weighting lives on the
+// federation_following row instead of here, since it's relationship
+// data established at follow time, not a global setting.
+type FederationConfig struct {
+	Enabled      bool          `json:"enabled"`
+	InboxBuffer  int           `json:"inbox_buffer"`
+	PollInterval time.Duration `json:"poll_interval"`
+	BatchSize    int           `json:"batch_size"`
+}
+
+// ObservabilityConfig holds the OpenTelemetry tracing subsystem's
+// configuration. TraceSampleRatio is the fraction (0.0-1.0) of root spans
+// kept; every span that is a child of a sampled parent is kept regardless,
+// per the ParentBased sampler internal/observability configures.
+type ObservabilityConfig struct {
+	Enabled          bool    `json:"enabled"`
+	OTLPEndpoint     string  `json:"otlp_endpoint"`
+	ServiceName      string  `json:"service_name"`
+	TraceSampleRatio float64 `json:"trace_sample_ratio"`
+}
+
+// ScoringConfig selects which content.ScoringEngine (registered via
+// content.RegisterScoringEngine) handles each content type.
+// EngineByContentType overrides DefaultEngine for the content types it
+// names (e.g. routing "context" posts to a different engine than
+// top-level content, or sending a fraction of a deployment's traffic to a
+// new engine for A/B comparison); any content type not listed falls back
+// to DefaultEngine.
+type ScoringConfig struct {
+	DefaultEngine       string            `json:"default_engine"`
+	EngineByContentType map[string]string `json:"engine_by_content_type"`
+}
+
+// BatchConfig tunes content.Manager's adaptive batch scheduler: when a
+// batch flushes, how much backlog it tolerates, and at what point it
+// decides the algorithm service is unhealthy and starts rejecting new
+// content instead of buffering it indefinitely.
+type BatchConfig struct {
+	// MaxSize flushes the batch as soon as it holds this many items,
+	// regardless of how long the oldest item has been queued.
+	MaxSize int `json:"max_size"`
+	// MaxLatency flushes the batch once its oldest normal-priority item
+	// has been queued this long, even if MaxSize hasn't been reached.
+	MaxLatency time.Duration `json:"max_latency"`
+	// PriorityMaxLatency is the shorter MaxLatency applied to items with
+	// models.Content.Priority above zero (breaking news, high-visibility
+	// posts), so they flush sooner than the rest of the queue.
+	PriorityMaxLatency time.Duration `json:"priority_max_latency"`
+	// MaxQueueDepth bounds batchBuffer. CreateContent rejects new content
+	// with ErrQueueSaturated once the queue is at this depth and the
+	// algorithm service looks unhealthy, rather than buffering without
+	// limit.
+	MaxQueueDepth int `json:"max_queue_depth"`
+	// UnhealthyLatency is the algorithm-service p99 latency (tracked via
+	// EWMA) above which the service is considered unhealthy: the
+	// scheduler flushes smaller batches sooner, and CreateContent starts
+	// applying backpressure once MaxQueueDepth is also reached.
+	UnhealthyLatency time.Duration `json:"unhealthy_latency"`
 }
 
 // Load loads configuration from environment variables
@@ -48,15 +236,28 @@ func Load() (*Config, error) {
 	godotenv.Load()
 
 	cfg := &Config{
-		Environment:         getEnv("ENVIRONMENT", "development"),
-		ServerAddress:       getEnv("SERVER_ADDRESS", ":8080"),
-		DatabasePath:        getEnv("DATABASE_PATH", "babel.db"),
-		AlgorithmServiceURL: getEnv("ALGORITHM_SERVICE_URL", "http://localhost:8081"),
+		Environment:          getEnv("ENVIRONMENT", "development"),
+		ServerAddress:        getEnv("SERVER_ADDRESS", ":8080"),
+		DatabasePath:         getEnv("DATABASE_PATH", "babel.db"),
+		DatabaseBackend:      getEnv("DATABASE_BACKEND", "sqlite"),
+		PostgresDSN:          getEnv("POSTGRES_DSN", ""),
+		MongoURI:             getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		MongoDatabase:        getEnv("MONGO_DATABASE", "babel"),
+		AlgorithmServiceURL:  getEnv("ALGORITHM_SERVICE_URL", "http://localhost:8081"),
+		AlgorithmEnrollToken: getEnv("ALGORITHM_ENROLL_TOKEN", ""),
 		RateLimit: RateLimit{
-			Enabled:   getBoolEnv("RATE_LIMIT_ENABLED", true),
-			Requests:  getIntEnv("RATE_LIMIT_REQUESTS", 100),
-			Window:    getDurationEnv("RATE_LIMIT_WINDOW", 1*time.Minute),
-			WhiteList: getStringSliceEnv("RATE_LIMIT_WHITELIST", []string{}),
+			Enabled:           getBoolEnv("RATE_LIMIT_ENABLED", true),
+			Backend:           getEnv("RATE_LIMIT_BACKEND", RateLimitBackendMemory),
+			RequestsPerSecond: getFloatEnv("RATE_LIMIT_REQUESTS_PER_SECOND", 5.0),
+			Burst:             getIntEnv("RATE_LIMIT_BURST", 20),
+			WhiteList:         getStringSliceEnv("RATE_LIMIT_WHITELIST", []string{}),
+			RouteOverrides: map[string]RouteLimit{
+				"send_direct_message": {RequestsPerSecond: 1, Burst: 5},
+			},
+			ReputationTiers: []ReputationTier{
+				{MinReputation: 100, RequestsPerSecond: 10, Burst: 40},
+				{MinReputation: 500, RequestsPerSecond: 25, Burst: 100},
+			},
 		},
 		Redis: RedisConfig{
 			Address:  getEnv("REDIS_ADDRESS", "localhost:6379"),
@@ -67,6 +268,70 @@ func Load() (*Config, error) {
 			Secret:        getEnv("JWT_SECRET", ""),
 			ExpiryMinutes: getIntEnv("JWT_EXPIRY_MINUTES", 60),
 		},
+		Auth: AuthConfig{
+			Provider: getEnv("AUTH_PROVIDER", AuthProviderLocal),
+			OIDC: OIDCConfig{
+				IssuerURL:    getEnv("OIDC_ISSUER_URL", ""),
+				ClientID:     getEnv("OIDC_CLIENT_ID", ""),
+				ClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+				RedirectURL:  getEnv("OIDC_REDIRECT_URL", ""),
+				Scopes:       getStringSliceEnv("OIDC_SCOPES", []string{"openid", "email"}),
+				AuthURL:      getEnv("OIDC_AUTH_URL", ""),
+				TokenURL:     getEnv("OIDC_TOKEN_URL", ""),
+			},
+		},
+		TLS: TLSConfig{
+			CAFile:   getEnv("ALGORITHM_TLS_CA_FILE", ""),
+			CertFile: getEnv("ALGORITHM_TLS_CERT_FILE", "algorithm-client.crt"),
+			KeyFile:  getEnv("ALGORITHM_TLS_KEY_FILE", "algorithm-client.key"),
+			AuthType: getEnv("ALGORITHM_TLS_AUTH_TYPE", AuthTypeNone),
+		},
+		Alert: AlertConfig{
+			MinInterval:     getDurationEnv("ALERT_MIN_INTERVAL", 5*time.Minute),
+			SlackWebhookURL: getEnv("ALERT_SLACK_WEBHOOK_URL", ""),
+			HTTPSinkURL:     getEnv("ALERT_HTTP_SINK_URL", ""),
+			SMTPAddr:        getEnv("ALERT_SMTP_ADDR", ""),
+			SMTPHost:        getEnv("ALERT_SMTP_HOST", ""),
+			SMTPUsername:    getEnv("ALERT_SMTP_USERNAME", ""),
+			SMTPPassword:    getEnv("ALERT_SMTP_PASSWORD", ""),
+			EmailFrom:       getEnv("ALERT_EMAIL_FROM", ""),
+			EmailTo:         getStringSliceEnv("ALERT_EMAIL_TO", []string{}),
+		},
+		MetricsToken: getEnv("METRICS_TOKEN", ""),
+		Observability: ObservabilityConfig{
+			Enabled:          getBoolEnv("OTEL_ENABLED", false),
+			OTLPEndpoint:     getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			ServiceName:      getEnv("OTEL_SERVICE_NAME", "babel-protocol"),
+			TraceSampleRatio: getFloatEnv("OTEL_TRACE_SAMPLE_RATIO", 1.0),
+		},
+		HCS: HCSConfig{
+			Enabled:       getBoolEnv("HCS_ENABLED", false),
+			AccountID:     getEnv("HCS_ACCOUNT_ID", ""),
+			PrivateKey:    getEnv("HCS_PRIVATE_KEY", ""),
+			NodeKey:       getEnv("HCS_NODE_KEY", ""),
+			TopicContent:  getEnv("HCS_TOPIC_CONTENT", ""),
+			TopicVote:     getEnv("HCS_TOPIC_VOTE", ""),
+			TopicEvidence: getEnv("HCS_TOPIC_EVIDENCE", ""),
+			PollInterval:  getDurationEnv("HCS_POLL_INTERVAL", 2*time.Second),
+			BatchSize:     getIntEnv("HCS_BATCH_SIZE", 25),
+		},
+		Federation: FederationConfig{
+			Enabled:      getBoolEnv("FEDERATION_ENABLED", false),
+			InboxBuffer:  getIntEnv("FEDERATION_INBOX_BUFFER", 256),
+			PollInterval: getDurationEnv("FEDERATION_POLL_INTERVAL", 5*time.Second),
+			BatchSize:    getIntEnv("FEDERATION_BATCH_SIZE", 25),
+		},
+		Scoring: ScoringConfig{
+			DefaultEngine:       getEnv("SCORING_DEFAULT_ENGINE", "ema"),
+			EngineByContentType: getStringMapEnv("SCORING_ENGINE_BY_CONTENT_TYPE", map[string]string{}),
+		},
+		Batch: BatchConfig{
+			MaxSize:            getIntEnv("BATCH_MAX_SIZE", 100),
+			MaxLatency:         getDurationEnv("BATCH_MAX_LATENCY", 5*time.Second),
+			PriorityMaxLatency: getDurationEnv("BATCH_PRIORITY_MAX_LATENCY", 500*time.Millisecond),
+			MaxQueueDepth:      getIntEnv("BATCH_MAX_QUEUE_DEPTH", 1000),
+			UnhealthyLatency:   getDurationEnv("BATCH_UNHEALTHY_LATENCY", 2*time.Second),
+		},
 	}
 
 	// Validate required configuration
@@ -74,6 +339,18 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("JWT_SECRET is required")
 	}
 
+	switch cfg.DatabaseBackend {
+	case "sqlite", "postgres", "mongo":
+	default:
+		return nil, fmt.Errorf("DATABASE_BACKEND must be one of sqlite, postgres, mongo (got %q)", cfg.DatabaseBackend)
+	}
+
+	switch cfg.Auth.Provider {
+	case AuthProviderLocal, AuthProviderJWT, AuthProviderOIDC:
+	default:
+		return nil, fmt.Errorf("AUTH_PROVIDER must be one of local, jwt, oidc (got %q)", cfg.Auth.Provider)
+	}
+
 	return cfg, nil
 }
 
@@ -105,6 +382,15 @@ func getIntEnv(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value, exists := os.LookupEnv(key); exists {
 		if d, err := time.ParseDuration(value); err == nil {
@@ -121,6 +407,27 @@ func getStringSliceEnv(key string, defaultValue []string) []string {
 	return defaultValue
 }
 
+// getStringMapEnv parses a "key1=value1,key2=value2" env var into a map,
+// e.g. SCORING_ENGINE_BY_CONTENT_TYPE=context=bayesian,article=ema.
+// Malformed entries (missing "=") are skipped rather than failing config
+// load outright.
+func getStringMapEnv(key string, defaultValue map[string]string) map[string]string {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+
+	result := make(map[string]string)
+	for _, pair := range split(value, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
 func split(s string, sep string) []string {
 	if s == "" {
 		return []string{}
@@ -0,0 +1,339 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ErrFingerprintMismatch is returned by DoLockedAction when the config has
+// changed since the caller last read its fingerprint.
+var ErrFingerprintMismatch = fmt.Errorf("config: fingerprint mismatch, reload and retry")
+
+// ChangeFunc is invoked whenever the config is reloaded or patched. old is
+// nil on the very first callback registration replay.
+type ChangeFunc func(old, new *Config)
+
+// ConfigHandler serves a live, hot-reloadable Config. Reads (Current) take
+// an RLock; reloads and patches take the write lock and notify subscribers
+// after the swap so they can rebuild dependent state atomically.
+type ConfigHandler struct {
+	mu      sync.RWMutex
+	current *Config
+	envPath string
+
+	subsMu      sync.Mutex
+	subscribers []ChangeFunc
+}
+
+// NewHandler loads the initial config from envPath (defaulting to ".env" in
+// the working directory when empty) and returns a handler ready to serve it.
+func NewHandler(envPath string) (*ConfigHandler, error) {
+	if envPath == "" {
+		envPath = ".env"
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ConfigHandler{
+		current: cfg,
+		envPath: envPath,
+	}, nil
+}
+
+// Current returns the currently active config. Callers must not mutate the
+// returned value; treat it as read-only.
+func (h *ConfigHandler) Current() *Config {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.current
+}
+
+// Fingerprint returns a short hash of the current config, used for
+// compare-and-swap style updates via DoLockedAction.
+func (h *ConfigHandler) Fingerprint() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return fingerprintOf(h.current)
+}
+
+func fingerprintOf(cfg *Config) string {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// OnChange registers a callback invoked after every successful reload or
+// patch. Subscribers (rate-limit middleware, JWT verifier, algorithm
+// client, ...) use this to rebuild their own state atomically.
+func (h *ConfigHandler) OnChange(fn ChangeFunc) {
+	h.subsMu.Lock()
+	defer h.subsMu.Unlock()
+	h.subscribers = append(h.subscribers, fn)
+}
+
+func (h *ConfigHandler) notify(old, new *Config) {
+	h.subsMu.Lock()
+	subs := make([]ChangeFunc, len(h.subscribers))
+	copy(subs, h.subscribers)
+	h.subsMu.Unlock()
+
+	for _, fn := range subs {
+		fn(old, new)
+	}
+}
+
+// DoLockedAction runs cb with the current config only if fingerprint still
+// matches what's active, giving callers compare-and-swap semantics for
+// concurrent admin updates. On success the config returned by cb becomes
+// the new active config and subscribers are notified.
+func (h *ConfigHandler) DoLockedAction(fingerprint string, cb func(current *Config) (*Config, error)) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if fingerprintOf(h.current) != fingerprint {
+		return ErrFingerprintMismatch
+	}
+
+	updated, err := cb(h.current)
+	if err != nil {
+		return err
+	}
+	if err := validate(updated); err != nil {
+		return err
+	}
+
+	old := h.current
+	h.current = updated
+	go h.notify(old, updated)
+	return nil
+}
+
+// MarshalJSONPath returns the JSON encoding of the subtree at path, e.g.
+// "/rate_limit/requests" or "" for the whole config.
+func (h *ConfigHandler) MarshalJSONPath(path string) ([]byte, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	tree, err := toTree(h.current)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := navigate(tree, splitPath(path))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(node)
+}
+
+// UnmarshalJSONPath patches the subtree at path with data and, once the
+// result validates, swaps it in as the active config and notifies
+// subscribers. Use DoLockedAction instead when the caller needs
+// compare-and-swap semantics.
+func (h *ConfigHandler) UnmarshalJSONPath(path string, data []byte) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tree, err := toTree(h.current)
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("config: invalid JSON patch: %v", err)
+	}
+
+	if err := setPath(tree, splitPath(path), value); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+
+	var updated Config
+	if err := json.Unmarshal(merged, &updated); err != nil {
+		return fmt.Errorf("config: patched value does not fit Config schema: %v", err)
+	}
+	if err := validate(&updated); err != nil {
+		return err
+	}
+
+	old := h.current
+	h.current = &updated
+	go h.notify(old, &updated)
+	return nil
+}
+
+// validate rejects configs that would leave the service unable to run,
+// most importantly a reload that blanks out JWT_SECRET.
+func validate(cfg *Config) error {
+	if cfg.JWT.Secret == "" {
+		return fmt.Errorf("config: JWT secret cannot be blanked by a reload")
+	}
+	return nil
+}
+
+// reload re-reads environment variables (and the .env file) and, if the
+// result validates, swaps it in as the active config.
+func (h *ConfigHandler) reload() error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	old := h.current
+	h.current = cfg
+	h.mu.Unlock()
+
+	h.notify(old, cfg)
+	return nil
+}
+
+// Watch blocks, reloading the config on SIGHUP and whenever envPath
+// changes on disk, until ctx is canceled.
+func (h *ConfigHandler) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("config: failed to create file watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	watchDir := filepath.Dir(h.envPath)
+	if watchDir == "" {
+		watchDir = "."
+	}
+	if err := watcher.Add(watchDir); err != nil {
+		return fmt.Errorf("config: failed to watch %s: %v", watchDir, err)
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-sighup:
+			if err := h.reload(); err != nil {
+				fmt.Printf("config: reload on SIGHUP failed: %v\n", err)
+			}
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(h.envPath) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := h.reload(); err != nil {
+				fmt.Printf("config: reload on file change failed: %v\n", err)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Printf("config: file watcher error: %v\n", err)
+		}
+	}
+}
+
+// toTree marshals cfg into a generic JSON tree for path-based navigation.
+func toTree(cfg *Config) (map[string]interface{}, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// navigate walks a decoded JSON tree following segments and returns the
+// node found there.
+func navigate(tree map[string]interface{}, segments []string) (interface{}, error) {
+	var node interface{} = tree
+	for _, segment := range segments {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("config: path segment %q is not an object", segment)
+		}
+		child, exists := m[segment]
+		if !exists {
+			return nil, fmt.Errorf("config: unknown config path segment %q", segment)
+		}
+		node = child
+	}
+	return node, nil
+}
+
+// setPath walks a decoded JSON tree to the parent of the final segment and
+// replaces that key with value.
+func setPath(tree map[string]interface{}, segments []string, value interface{}) error {
+	if len(segments) == 0 {
+		merged, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("config: root patch must be an object")
+		}
+		for k, v := range merged {
+			tree[k] = v
+		}
+		return nil
+	}
+
+	node := map[string]interface{}(tree)
+	for _, segment := range segments[:len(segments)-1] {
+		child, exists := node[segment]
+		if !exists {
+			return fmt.Errorf("config: unknown config path segment %q", segment)
+		}
+		childMap, ok := child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("config: path segment %q is not an object", segment)
+		}
+		node = childMap
+	}
+
+	last := segments[len(segments)-1]
+	if _, exists := node[last]; !exists {
+		return fmt.Errorf("config: unknown config path segment %q", last)
+	}
+	node[last] = value
+	return nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// GetTLSConfig builds a *tls.Config for talking to the algorithm service,
+// honoring TLS.AuthType:
+//   - "none": returns nil, so callers fall back to a plaintext transport.
+//   - "tls": verifies the server certificate, optionally against a custom
+//     CA bundle if TLS.CAFile is set.
+//   - "mtls": does the above and also presents the client cert/key pair at
+//     TLS.CertFile/TLS.KeyFile, which must already exist (see
+//     content.AlgorithmClient's bootstrap-enrollment flow).
+func (c *Config) GetTLSConfig() (*tls.Config, error) {
+	switch c.TLS.AuthType {
+	case "", AuthTypeNone:
+		return nil, nil
+
+	case AuthTypeTLS:
+		tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if c.TLS.CAFile != "" {
+			pool, err := loadCAPool(c.TLS.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsCfg.RootCAs = pool
+		}
+		return tlsCfg, nil
+
+	case AuthTypeMTLS:
+		cert, err := tls.LoadX509KeyPair(c.TLS.CertFile, c.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("config: failed to load client cert/key: %v", err)
+		}
+		tlsCfg := &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+		}
+		if c.TLS.CAFile != "" {
+			pool, err := loadCAPool(c.TLS.CAFile)
+			if err != nil {
+				return nil, err
+			}
+			tlsCfg.RootCAs = pool
+		}
+		return tlsCfg, nil
+
+	default:
+		return nil, fmt.Errorf("config: unknown TLS auth type %q", c.TLS.AuthType)
+	}
+}
+
+func loadCAPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read CA file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("config: failed to parse CA file %s", path)
+	}
+	return pool, nil
+}
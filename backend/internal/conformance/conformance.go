@@ -0,0 +1,175 @@
+// Package conformance pins the exact numerical behavior of the content
+// package's scoring functions (CalculateTruthScore, CalculateVisibilityScore,
+// CalculateContextScore, UpdateTruthScoreWithContext, GetConsensusState)
+// against a versioned corpus of JSON vectors, so a refactor that quietly
+// shifts scores (e.g. swapping the exponential moving average for a
+// Bayesian estimator) fails the conformance run instead of slipping out
+// unnoticed. The same vectors double as a reference oracle the Python
+// algorithm service can replay against its own implementation.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/content"
+)
+
+// VoteInput describes one vote in a Vector's vote stream. AgeHours is used
+// instead of an absolute timestamp because the scoring functions decay
+// votes relative to time.Now() - encoding age rather than a wall-clock
+// time keeps vectors reproducible regardless of when the suite runs.
+type VoteInput struct {
+	Type           string  `json:"type"`
+	Weight         float64 `json:"weight"`
+	CertaintyLevel int     `json:"certainty_level"`
+	AgeHours       float64 `json:"age_hours"`
+}
+
+// ContextInput describes the context post passed to CalculateContextScore.
+type ContextInput struct {
+	ContentText string   `json:"content_text"`
+	MediaURLs   []string `json:"media_urls"`
+}
+
+// Expected holds the outputs a Vector asserts. Only the fields relevant to
+// the function(s) a vector targets need to be set; Evaluate skips any
+// output whose expectation is nil.
+type Expected struct {
+	TruthScore        *float64 `json:"truth_score,omitempty"`
+	VisibilityScore   *float64 `json:"visibility_score,omitempty"`
+	ContextScore      *float64 `json:"context_score,omitempty"`
+	UpdatedTruthScore *float64 `json:"updated_truth_score,omitempty"`
+	ConsensusState    *string  `json:"consensus_state,omitempty"`
+}
+
+// Vector is one conformance case. Its inputs cover whichever functions its
+// Expected fields target: Votes feeds CalculateTruthScore/
+// CalculateVisibilityScore, Context feeds CalculateContextScore,
+// PriorTruthScore+ContextScore feeds UpdateTruthScoreWithContext, and
+// ConsensusScore feeds GetConsensusState.
+type Vector struct {
+	Name            string        `json:"name"`
+	Votes           []VoteInput   `json:"votes,omitempty"`
+	Context         *ContextInput `json:"context,omitempty"`
+	PriorTruthScore *float64      `json:"prior_truth_score,omitempty"`
+	ContextScore    *float64      `json:"context_score,omitempty"`
+	ConsensusScore  *float64      `json:"consensus_score,omitempty"`
+	Expected        Expected      `json:"expected"`
+}
+
+// LoadVectors reads every *.json file directly under dir (e.g.
+// "vectors/v1"), decodes each as an array of Vectors, and returns them
+// concatenated in filename order so a run is deterministic.
+func LoadVectors(dir string) ([]Vector, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("conformance: reading vector dir %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var vectors []Vector
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("conformance: reading %s: %w", path, err)
+		}
+		var batch []Vector
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return nil, fmt.Errorf("conformance: parsing %s: %w", path, err)
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+// Drift describes one output where a vector's actual value fell outside
+// epsilon of its expected value.
+type Drift struct {
+	Vector   string
+	Output   string
+	Expected string
+	Actual   string
+}
+
+// Evaluate runs v against the content package's scoring functions and
+// returns a Drift for every expected output that falls outside epsilon
+// (or, for ConsensusState, doesn't match exactly).
+func Evaluate(v Vector, epsilon float64) []Drift {
+	var drifts []Drift
+	check := func(output string, expected, actual float64) {
+		if math.Abs(expected-actual) > epsilon {
+			drifts = append(drifts, Drift{
+				Vector:   v.Name,
+				Output:   output,
+				Expected: fmt.Sprintf("%v", expected),
+				Actual:   fmt.Sprintf("%v", actual),
+			})
+		}
+	}
+
+	if v.Expected.TruthScore != nil {
+		// nil topics/ReputationProvider disable reputation weighting, so
+		// these vectors keep pinning the pure vote-weight formula.
+		check("truth_score", *v.Expected.TruthScore, content.CalculateTruthScore(toVotes(v.Votes), nil, nil))
+	}
+	if v.Expected.VisibilityScore != nil {
+		check("visibility_score", *v.Expected.VisibilityScore, content.CalculateVisibilityScore(toVotes(v.Votes), nil, nil))
+	}
+	if v.Expected.ContextScore != nil && v.Context != nil {
+		actual := content.CalculateContextScore(&models.Content{
+			ContentText: v.Context.ContentText,
+			MediaURLs:   v.Context.MediaURLs,
+		})
+		check("context_score", *v.Expected.ContextScore, actual)
+	}
+	if v.Expected.UpdatedTruthScore != nil && v.PriorTruthScore != nil && v.ContextScore != nil {
+		actual := content.UpdateTruthScoreWithContext(*v.PriorTruthScore, *v.ContextScore)
+		check("updated_truth_score", *v.Expected.UpdatedTruthScore, actual)
+	}
+	if v.Expected.ConsensusState != nil && v.ConsensusScore != nil {
+		actual := content.GetConsensusState(*v.ConsensusScore)
+		if actual != *v.Expected.ConsensusState {
+			drifts = append(drifts, Drift{
+				Vector:   v.Name,
+				Output:   "consensus_state",
+				Expected: *v.Expected.ConsensusState,
+				Actual:   actual,
+			})
+		}
+	}
+
+	return drifts
+}
+
+// toVotes builds *models.Vote values from a vector's vote inputs, turning
+// each AgeHours into a Timestamp relative to time.Now() so the decay math
+// in CalculateTruthScore/CalculateVisibilityScore sees the age the vector
+// intends regardless of when the suite runs.
+func toVotes(inputs []VoteInput) []*models.Vote {
+	now := time.Now()
+	votes := make([]*models.Vote, len(inputs))
+	for i, in := range inputs {
+		votes[i] = &models.Vote{
+			Type:           in.Type,
+			Weight:         in.Weight,
+			CertaintyLevel: in.CertaintyLevel,
+			Timestamp:      models.NewBabelTime(now.Add(-time.Duration(in.AgeHours * float64(time.Hour)))),
+		}
+	}
+	return votes
+}
@@ -0,0 +1,77 @@
+package hcs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+)
+
+// TopicSet maps each RecordType this package mirrors to the Hedera topic
+// its messages are submitted to.
+type TopicSet map[RecordType]hedera.TopicID
+
+// Publisher submits outbox payloads to HCS and replays a topic's history.
+// It is the seam the Worker and Reconciler are tested against; production
+// code gets a HederaPublisher.
+type Publisher interface {
+	Submit(recordType RecordType, payload []byte) (hederaSeq uint64, consensusTimestamp time.Time, err error)
+	Replay(recordType RecordType, onMessage func(payload []byte, hederaSeq uint64, consensusTimestamp time.Time)) error
+}
+
+// HederaPublisher is the production Publisher, submitting to and
+// replaying from real Hedera Consensus Service topics.
+type HederaPublisher struct {
+	client *hedera.Client
+	topics TopicSet
+}
+
+// NewHederaPublisher builds a HederaPublisher bound to client, submitting
+// to and replaying from the topics in topics.
+func NewHederaPublisher(client *hedera.Client, topics TopicSet) *HederaPublisher {
+	return &HederaPublisher{client: client, topics: topics}
+}
+
+// Submit publishes payload to recordType's topic and returns the
+// consensus sequence number and timestamp from the transaction receipt.
+func (p *HederaPublisher) Submit(recordType RecordType, payload []byte) (uint64, time.Time, error) {
+	topicID, ok := p.topics[recordType]
+	if !ok {
+		return 0, time.Time{}, fmt.Errorf("hcs: no topic configured for record type %q", recordType)
+	}
+
+	txResponse, err := hedera.NewTopicMessageSubmitTransaction().
+		SetTopicID(topicID).
+		SetMessage(payload).
+		Execute(p.client)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("hcs: submitting message: %w", err)
+	}
+
+	receipt, err := txResponse.GetReceipt(p.client)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("hcs: getting receipt: %w", err)
+	}
+
+	return receipt.TopicSequenceNumber, time.Now(), nil
+}
+
+// Replay subscribes to recordType's topic from the beginning and invokes
+// onMessage for every message observed, in consensus order.
+func (p *HederaPublisher) Replay(recordType RecordType, onMessage func(payload []byte, hederaSeq uint64, consensusTimestamp time.Time)) error {
+	topicID, ok := p.topics[recordType]
+	if !ok {
+		return fmt.Errorf("hcs: no topic configured for record type %q", recordType)
+	}
+
+	_, err := hedera.NewTopicMessageQuery().
+		SetTopicID(topicID).
+		SetStartTime(time.Unix(0, 0)).
+		Subscribe(p.client, func(message hedera.TopicMessage) {
+			onMessage(message.Contents, message.SequenceNumber, message.ConsensusTimestamp)
+		})
+	if err != nil {
+		return fmt.Errorf("hcs: replaying topic: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package hcs
+
+import "time"
+
+// VoteRecordID builds the record ID a vote's outbox entry is keyed by -
+// content and voter together, since a vote is only unique per pair.
+func VoteRecordID(contentID, voterID string) string {
+	return contentID + ":" + voterID
+}
+
+// VerifyVoteFinality reports whether the vote by voterID on contentID has
+// reached HCS consensus, and if so the consensus timestamp and sequence
+// number assigned to it. A zero time with a nil error means the vote
+// exists locally but has not (yet) been submitted.
+func VerifyVoteFinality(store Store, contentID, voterID string) (hederaTimestamp time.Time, consensusSeq uint64, err error) {
+	entry, err := store.OutboxEntryForRecord(RecordTypeVote, VoteRecordID(contentID, voterID))
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	if entry == nil || entry.Status != StatusSubmitted || entry.HederaTimestamp == nil || entry.HederaSeq == nil {
+		return time.Time{}, 0, nil
+	}
+	return *entry.HederaTimestamp, *entry.HederaSeq, nil
+}
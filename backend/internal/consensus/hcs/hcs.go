@@ -0,0 +1,19 @@
+// Package hcs mirrors DBManager's RecordVote/CreateEvidence/CreateContent
+// writes onto Hedera Consensus Service. Every write enqueues a
+// canonicalized, signed Message into a small outbox table in the same
+// transaction as the write itself; a background Worker drains the outbox
+// and submits each entry to a per-record-type HCS topic, so DB commits
+// never wait on the network but every write is still guaranteed to reach
+// HCS eventually. A Reconciler replays each topic at startup and flags any
+// local state that no longer matches what HCS actually recorded.
+package hcs
+
+// RecordType identifies which DBManager write produced a Message.
+type RecordType string
+
+// Record types, one per DBManager write path this package mirrors.
+const (
+	RecordTypeContent  RecordType = "content"
+	RecordTypeVote     RecordType = "vote"
+	RecordTypeEvidence RecordType = "evidence"
+)
@@ -0,0 +1,77 @@
+package hcs
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Message is the canonicalized, signed envelope submitted to a
+// per-RecordType Hedera topic for every outbox entry.
+type Message struct {
+	RecordType  RecordType `json:"record_type"`
+	RecordID    string     `json:"record_id"`
+	ContentHash string     `json:"content_hash"`
+	Sequence    int64      `json:"sequence"`
+	Timestamp   int64      `json:"timestamp"`
+	Signature   string     `json:"signature"`
+}
+
+// BuildMessage canonicalizes record (its JSON encoding is the canonical
+// form, same approach models.Activity uses for federation), hashes it,
+// signs the resulting envelope with nodeKey, and marshals it ready to
+// submit to HCS. It returns the Message, its content hash, and the
+// marshaled payload.
+func BuildMessage(nodeKey string, recordType RecordType, recordID string, record interface{}, sequence int64) (*Message, string, []byte, error) {
+	contentHash, err := hashRecord(record)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	msg := &Message{
+		RecordType:  recordType,
+		RecordID:    recordID,
+		ContentHash: contentHash,
+		Sequence:    sequence,
+		Timestamp:   time.Now().Unix(),
+	}
+	msg.Signature = sign(nodeKey, msg)
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("hcs: marshaling message envelope: %w", err)
+	}
+	return msg, contentHash, payload, nil
+}
+
+// Verify recomputes msg's signature using nodeKey and reports whether it
+// matches - the check the replay path runs on every HCS message before
+// trusting its content hash.
+func Verify(nodeKey string, msg *Message) bool {
+	expected := sign(nodeKey, msg)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(msg.Signature)) == 1
+}
+
+func hashRecord(record interface{}) (string, error) {
+	canonical, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("hcs: marshaling record: %w", err)
+	}
+	digest := sha256.Sum256(canonical)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// sign computes the hex-encoded HMAC-SHA256, keyed by nodeKey, of msg's
+// fields other than Signature itself.
+func sign(nodeKey string, msg *Message) string {
+	mac := hmac.New(sha256.New, []byte(nodeKey))
+	mac.Write([]byte(msg.RecordType))
+	mac.Write([]byte(msg.RecordID))
+	mac.Write([]byte(msg.ContentHash))
+	fmt.Fprintf(mac, "%d:%d", msg.Sequence, msg.Timestamp)
+	return hex.EncodeToString(mac.Sum(nil))
+}
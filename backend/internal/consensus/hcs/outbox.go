@@ -0,0 +1,29 @@
+package hcs
+
+import "time"
+
+// Outbox entry statuses.
+const (
+	StatusPending   = "pending"
+	StatusSubmitted = "submitted"
+	StatusFailed    = "failed"
+)
+
+// OutboxEntry is a row in the hcs_outbox table: one signed Message,
+// enqueued in the same transaction as the DBManager write it mirrors,
+// waiting for (or recording the result of) submission to HCS.
+type OutboxEntry struct {
+	ID              string
+	RecordType      RecordType
+	RecordID        string
+	ContentHash     string
+	Payload         []byte
+	Sequence        int64
+	Status          string
+	HederaSeq       *uint64
+	HederaTimestamp *time.Time
+	Attempts        int
+	LastError       string
+	CreatedAt       time.Time
+	SubmittedAt     *time.Time
+}
@@ -0,0 +1,140 @@
+package hcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// Reconciler replays each RecordType's topic at startup and flags any
+// local state that no longer matches what HCS actually recorded.
+type Reconciler struct {
+	store     Store
+	publisher Publisher
+	nodeKey   string
+}
+
+// NewReconciler creates a Reconciler that verifies replayed messages
+// against store using nodeKey.
+func NewReconciler(store Store, publisher Publisher, nodeKey string) *Reconciler {
+	return &Reconciler{store: store, publisher: publisher, nodeKey: nodeKey}
+}
+
+// Reconcile replays every RecordType's topic and flags divergences. It
+// keeps going after a single type's replay fails so one bad topic can't
+// block reconciliation of the others; the caller decides whether to
+// treat the combined error as fatal.
+func (r *Reconciler) Reconcile() error {
+	var errs []string
+	for _, recordType := range []RecordType{RecordTypeContent, RecordTypeVote, RecordTypeEvidence} {
+		if err := r.reconcileType(recordType); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", recordType, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("hcs: reconciliation failed for: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcileType(recordType RecordType) error {
+	return r.publisher.Replay(recordType, func(payload []byte, hederaSeq uint64, consensusTimestamp time.Time) {
+		var msg Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			r.flag(recordType, "", "unparseable_message", err.Error())
+			return
+		}
+
+		if !Verify(r.nodeKey, &msg) {
+			r.flag(recordType, msg.RecordID, "signature_mismatch", "HCS message signature does not match the node key")
+			return
+		}
+
+		localHash, found, err := r.localContentHash(recordType, msg.RecordID)
+		if err != nil {
+			r.flag(recordType, msg.RecordID, "local_lookup_failed", err.Error())
+			return
+		}
+		if !found {
+			r.flag(recordType, msg.RecordID, "missing_local_record", "HCS recorded this record but it no longer exists locally")
+			return
+		}
+		if localHash != msg.ContentHash {
+			r.flag(recordType, msg.RecordID, "content_hash_mismatch", "local record no longer matches the content hash recorded on HCS")
+		}
+	})
+}
+
+// localContentHash recomputes the content hash of the local record
+// identified by recordType/recordID, the same way BuildMessage did when
+// it was first enqueued.
+func (r *Reconciler) localContentHash(recordType RecordType, recordID string) (hash string, found bool, err error) {
+	switch recordType {
+	case RecordTypeContent:
+		content, err := r.store.GetContent(recordID)
+		if err != nil {
+			return "", false, err
+		}
+		if content == nil {
+			return "", false, nil
+		}
+		hash, err := hashRecord(content)
+		return hash, true, err
+
+	case RecordTypeEvidence:
+		evidence, err := r.store.GetEvidence(recordID)
+		if err != nil {
+			return "", false, err
+		}
+		if evidence == nil {
+			return "", false, nil
+		}
+		hash, err := hashRecord(evidence)
+		return hash, true, err
+
+	case RecordTypeVote:
+		contentID, voterID, ok := splitVoteRecordID(recordID)
+		if !ok {
+			return "", false, fmt.Errorf("malformed vote record id %q", recordID)
+		}
+		votes, err := r.store.GetContentVotes(contentID)
+		if err != nil {
+			return "", false, err
+		}
+		for _, vote := range votes {
+			if vote.VoterID == voterID {
+				hash, err := hashRecord(vote)
+				return hash, true, err
+			}
+		}
+		return "", false, nil
+
+	default:
+		return "", false, fmt.Errorf("unknown record type %q", recordType)
+	}
+}
+
+func (r *Reconciler) flag(recordType RecordType, recordID, errorType, message string) {
+	_ = r.store.LogError(&models.AlgorithmError{
+		AlgorithmName: "hcs_reconciler",
+		ErrorType:     errorType,
+		ErrorMessage:  message,
+		Context: map[string]interface{}{
+			"record_type": recordType,
+			"record_id":   recordID,
+		},
+		Timestamp: time.Now(),
+	})
+}
+
+// splitVoteRecordID reverses VoteRecordID.
+func splitVoteRecordID(recordID string) (contentID, voterID string, ok bool) {
+	idx := strings.LastIndex(recordID, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+	return recordID[:idx], recordID[idx+1:], true
+}
@@ -0,0 +1,28 @@
+package hcs
+
+import (
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// Store is the narrow slice of DBManager the Worker and Reconciler need.
+// It is satisfied structurally by *sqlite.DBManager - this package never
+// imports internal/db/sqlite, so there is no cycle.
+type Store interface {
+	// Outbox drives the Worker's drain loop.
+	ClaimPendingOutboxEntries(limit int) ([]*OutboxEntry, error)
+	MarkOutboxSubmitted(id string, hederaSeq uint64, consensusTimestamp time.Time) error
+	MarkOutboxFailed(id string, submitErr error) error
+	OutboxEntryForRecord(recordType RecordType, recordID string) (*OutboxEntry, error)
+
+	// Local record lookups let the Reconciler recompute a record's
+	// content hash to compare against what HCS actually recorded.
+	GetContent(id string) (*models.Content, error)
+	GetContentVotes(contentID string) ([]*db.VoteInfo, error)
+	GetEvidence(id string) (*models.Evidence, error)
+
+	// LogError records a divergence the Reconciler flags.
+	LogError(algErr *models.AlgorithmError) error
+}
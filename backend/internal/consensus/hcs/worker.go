@@ -0,0 +1,72 @@
+package hcs
+
+import (
+	"log"
+	"time"
+)
+
+// Worker periodically drains pending hcs_outbox entries and submits them
+// to HCS, so DBManager writes never block on the network.
+type Worker struct {
+	store     Store
+	publisher Publisher
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+}
+
+// NewWorker creates a Worker that claims up to batchSize pending entries
+// from store every interval and submits them via publisher.
+func NewWorker(store Store, publisher Publisher, interval time.Duration, batchSize int) *Worker {
+	return &Worker{
+		store:     store,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+		stop:      make(chan struct{}),
+	}
+}
+
+// Run drains the outbox every w.interval until Stop is called. Call this
+// in its own goroutine.
+func (w *Worker) Run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.drain()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Stop halts the drain loop.
+func (w *Worker) Stop() {
+	close(w.stop)
+}
+
+func (w *Worker) drain() {
+	entries, err := w.store.ClaimPendingOutboxEntries(w.batchSize)
+	if err != nil {
+		log.Printf("hcs: claiming pending outbox entries: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		hederaSeq, consensusTimestamp, err := w.publisher.Submit(entry.RecordType, entry.Payload)
+		if err != nil {
+			log.Printf("hcs: submitting outbox entry %s (%s %s): %v", entry.ID, entry.RecordType, entry.RecordID, err)
+			if markErr := w.store.MarkOutboxFailed(entry.ID, err); markErr != nil {
+				log.Printf("hcs: marking outbox entry %s failed: %v", entry.ID, markErr)
+			}
+			continue
+		}
+
+		if err := w.store.MarkOutboxSubmitted(entry.ID, hederaSeq, consensusTimestamp); err != nil {
+			log.Printf("hcs: marking outbox entry %s submitted: %v", entry.ID, err)
+		}
+	}
+}
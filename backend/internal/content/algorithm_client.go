@@ -2,28 +2,52 @@ package content
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/alert"
+	"github.com/saint/babel-protocol/backend/internal/config"
 )
 
 // AlgorithmClient handles communication with the algorithm service
 type AlgorithmClient struct {
 	baseURL    string
+	tlsCfg     config.TLSConfig
 	httpClient *http.Client
 }
 
-// NewAlgorithmClient creates a new algorithm service client
-func NewAlgorithmClient(baseURL string) *AlgorithmClient {
+// NewAlgorithmClient creates a new algorithm service client. If tlsCfg.AuthType
+// is AuthTypeMTLS and no client cert exists yet at tlsCfg.CertFile/KeyFile, it
+// runs the one-time bootstrap-enrollment flow against {baseURL}/enroll using
+// enrollToken before building the client's transport.
+func NewAlgorithmClient(baseURL string, tlsCfg config.TLSConfig, enrollToken string) (*AlgorithmClient, error) {
+	if tlsCfg.AuthType == config.AuthTypeMTLS && !IsEnrolled(tlsCfg) {
+		if err := enroll(baseURL, tlsCfg, enrollToken); err != nil {
+			return nil, fmt.Errorf("failed to enroll with algorithm service: %v", err)
+		}
+	}
+
+	transport := http.DefaultTransport
+	if tlsCfg.AuthType != "" && tlsCfg.AuthType != config.AuthTypeNone {
+		tlsClientCfg, err := (&config.Config{TLS: tlsCfg}).GetTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build TLS config: %v", err)
+		}
+		transport = &http.Transport{TLSClientConfig: tlsClientCfg}
+	}
+
 	return &AlgorithmClient{
 		baseURL: baseURL,
+		tlsCfg:  tlsCfg,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: transport,
 		},
-	}
+	}, nil
 }
 
 // AnalyzeContent sends content to the algorithm service for analysis
@@ -57,11 +81,13 @@ func (c *AlgorithmClient) AnalyzeContent(content *models.Content) (*models.Algor
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		c.raiseNonOK("/analyze", resp.StatusCode)
 		return nil, fmt.Errorf("algorithm service returned status %d", resp.StatusCode)
 	}
 
 	var result models.AlgorithmResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		c.raiseDecodeFailure("/analyze", err)
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
@@ -102,11 +128,13 @@ func (c *AlgorithmClient) ValidateEvidence(evidence *models.Evidence) (*models.A
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		c.raiseNonOK("/validate", resp.StatusCode)
 		return nil, fmt.Errorf("algorithm service returned status %d", resp.StatusCode)
 	}
 
 	var result models.AlgorithmResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		c.raiseDecodeFailure("/validate", err)
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
@@ -140,34 +168,74 @@ func (c *AlgorithmClient) UpdateConsensus(contentID string, votes []*models.Vote
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		c.raiseNonOK("/consensus", resp.StatusCode)
 		return nil, fmt.Errorf("algorithm service returned status %d", resp.StatusCode)
 	}
 
 	var result models.AlgorithmResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		c.raiseDecodeFailure("/consensus", err)
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
 	return &result, nil
 }
 
-// AnalyzeContentBatch sends a batch of content to the algorithm service for analysis
+// AnalyzeContentBatch sends a batch of content to the algorithm service for
+// analysis. It's equivalent to AnalyzeContentBatchCtx(context.Background(), req).
 func (c *AlgorithmClient) AnalyzeContentBatch(req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
+	return c.AnalyzeContentBatchCtx(context.Background(), req)
+}
+
+// AnalyzeContentBatchCtx sends a batch of content to the algorithm service
+// for analysis, carrying ctx on the outbound request so the batch's trace
+// span (and any deadline the caller set) follows the call into the
+// algorithm service instead of stopping at processBatch.
+func (c *AlgorithmClient) AnalyzeContentBatchCtx(ctx context.Context, req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err := c.httpClient.Post(c.baseURL+"/analyze/batch", "application/json", bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/analyze/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send batch request: %v", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		c.raiseNonOK("/analyze/batch", resp.StatusCode)
+	}
+
 	var result models.AlgorithmResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		c.raiseDecodeFailure("/analyze/batch", err)
 		return nil, fmt.Errorf("failed to decode response: %v", err)
 	}
 
 	return &result, nil
 }
+
+// raiseNonOK alerts on a non-200 response from the algorithm service. Kept
+// as a warning since a single failed call isn't necessarily an outage;
+// alert.Raise's own rate limiting collapses repeats into one page.
+func (c *AlgorithmClient) raiseNonOK(endpoint string, status int) {
+	alert.Raise(alert.SeverityWarning, "algorithm_client.non_200",
+		fmt.Sprintf("algorithm service %s returned status %d", endpoint, status),
+		map[string]interface{}{"endpoint": endpoint, "status": status})
+}
+
+// raiseDecodeFailure alerts when the algorithm service's response body
+// can't be decoded, which usually means it's running an incompatible
+// version or is misconfigured.
+func (c *AlgorithmClient) raiseDecodeFailure(endpoint string, err error) {
+	alert.Raise(alert.SeverityWarning, "algorithm_client.decode_failure",
+		fmt.Sprintf("failed to decode algorithm service response from %s: %v", endpoint, err),
+		map[string]interface{}{"endpoint": endpoint, "error": err.Error()})
+}
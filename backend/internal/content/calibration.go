@@ -0,0 +1,152 @@
+package content
+
+import (
+	"math"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// Calibration tuning constants.
+const (
+	// calibrationStableAge is how old a content item must be before its
+	// truth score is considered "settled" enough to grade early voters
+	// against.
+	calibrationStableAge = 7 * 24 * time.Hour
+	// calibrationMinVotes is the minimum vote count a content item must
+	// have accumulated before it's considered settled.
+	calibrationMinVotes = 100
+	// earlyVoteWindow is how soon after content creation a vote must have
+	// landed to count as an "early" prediction worth grading - voting
+	// after the crowd has already settled the score isn't a prediction.
+	earlyVoteWindow = 24 * time.Hour
+	// calibrationTick is how often runCalibrationPass re-scans for newly
+	// stabilized content. Calibration isn't latency-sensitive the way
+	// score flushes are, so this runs far less often than schedulerTick.
+	calibrationTick = 10 * time.Minute
+	// calibrationEWMAAlpha is the weight given to each new Brier-derived
+	// sample when rolling it into a voter's ConfidenceScore. Kept low so
+	// one lucky or unlucky call doesn't swing a voter's standing, the
+	// same rationale as algoLatencyEWMA's downAlpha.
+	calibrationEWMAAlpha = 0.1
+)
+
+// CalibrationStore is the optional db.Repository capability backing the
+// calibration tracker, following the same pattern as decisions.Store and
+// user.Store: implemented only by backends that support it (today, only
+// sqlite), and type-asserted out of db.Repository by main.go. A backend
+// that doesn't implement it simply never runs the tracker.
+type CalibrationStore interface {
+	// StabilizedContent returns content items created before cutoff with
+	// at least minVotes votes that haven't already been calibrated.
+	StabilizedContent(cutoff time.Time, minVotes int) ([]*models.Content, error)
+	// MarkCalibrated records that a content item's early voters have been
+	// graded, so future passes don't re-grade it.
+	MarkCalibrated(contentID string) error
+	// SaveVoterCalibration persists a voter's updated per-topic-cluster
+	// ExpertiseInfo - updateUserCalibration's sibling to
+	// updateUserContextScore, writing to the same users table rather than
+	// going through the reputation_events ledger, since calibration is a
+	// derived signal rather than an authoritative reputation input.
+	SaveVoterCalibration(voterID, topicCluster string, info models.ExpertiseInfo) error
+}
+
+// SetCalibrationStore wires in the backend-specific calibration store.
+// Called from main.go only when dbManager implements CalibrationStore.
+func (m *Manager) SetCalibrationStore(store CalibrationStore) {
+	m.calibration = store
+}
+
+// StartCalibrationTracker starts the background loop that grades early
+// voters' predictions against content that has since stabilized. It's a
+// no-op until SetCalibrationStore has been called.
+func (m *Manager) StartCalibrationTracker() {
+	go func() {
+		ticker := time.NewTicker(calibrationTick)
+		defer ticker.Stop()
+		for range ticker.C {
+			m.runCalibrationPass()
+		}
+	}()
+}
+
+// runCalibrationPass grades every content item that has newly stabilized
+// since the last pass.
+func (m *Manager) runCalibrationPass() {
+	if m.calibration == nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-calibrationStableAge)
+	stabilized, err := m.calibration.StabilizedContent(cutoff, calibrationMinVotes)
+	if err != nil {
+		m.logError("StabilizedContent", err)
+		return
+	}
+
+	for _, c := range stabilized {
+		if err := m.calibrateContent(c); err != nil {
+			m.logError("calibrateContent", err)
+			continue
+		}
+		if err := m.calibration.MarkCalibrated(c.ID); err != nil {
+			m.logError("MarkCalibrated", err)
+		}
+	}
+}
+
+// calibrateContent grades c's early voters (those who voted within
+// earlyVoteWindow of c's creation) against c's settled TruthScore using a
+// Brier score - the squared error between the voter's implied prediction
+// and the outcome - and rolls the result into that voter's per-topic
+// ConfidenceScore.
+func (m *Manager) calibrateContent(c *models.Content) error {
+	voteInfos, err := m.db.GetContentVotes(c.ID)
+	if err != nil {
+		return err
+	}
+
+	cluster := topicClusterKey(c.Topics)
+	deadline := c.Timestamp.Time.Add(earlyVoteWindow)
+
+	for _, vote := range voteInfos {
+		if vote.VoteType != models.VoteTypeAffirm && vote.VoteType != models.VoteTypeDeny {
+			continue
+		}
+		if vote.Timestamp.After(deadline) {
+			continue
+		}
+
+		prediction := getVoteTypeValue(vote.VoteType, vote.CertaintyLevel)
+		brierError := (prediction - c.TruthScore) * (prediction - c.TruthScore)
+		sample := 1 - math.Min(1, brierError)
+
+		if err := m.updateUserCalibration(vote.VoterID, cluster, sample); err != nil {
+			m.logError("updateUserCalibration", err)
+		}
+	}
+
+	return nil
+}
+
+// updateUserCalibration is updateUserContextScore's sibling for the
+// calibration signal: it rolls sample (1 - Brier error, so higher is
+// better calibrated) into voterID's ConfidenceScore for topicCluster via
+// an EWMA, then persists through CalibrationStore rather than the
+// reputation_events ledger.
+func (m *Manager) updateUserCalibration(voterID, topicCluster string, sample float64) error {
+	user, err := m.db.GetUser(voterID)
+	if err != nil {
+		return err
+	}
+
+	info := user.DomainExpertise[topicCluster]
+	if info.LastUpdated.IsZero() {
+		info.ConfidenceScore = sample
+	} else {
+		info.ConfidenceScore = (1-calibrationEWMAAlpha)*info.ConfidenceScore + calibrationEWMAAlpha*sample
+	}
+	info.LastUpdated = time.Now()
+
+	return m.calibration.SaveVoterCalibration(voterID, topicCluster, info)
+}
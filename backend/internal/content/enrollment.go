@@ -0,0 +1,135 @@
+package content
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+// EnrollmentStatus reports the state of this client's mTLS enrollment with
+// the algorithm service, for the admin enrollment endpoint.
+type EnrollmentStatus struct {
+	Enrolled    bool      `json:"enrolled"`
+	Subject     string    `json:"subject,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at,omitempty"`
+}
+
+type enrollRequest struct {
+	Token string `json:"token"`
+	CSR   string `json:"csr"` // PEM-encoded PKCS#10 certificate signing request
+}
+
+type enrollResponse struct {
+	Certificate string `json:"certificate"` // PEM-encoded signed client certificate
+}
+
+// IsEnrolled reports whether a client cert/key pair already exists on disk
+// at the configured TLS.CertFile/TLS.KeyFile paths.
+func IsEnrolled(tlsCfg config.TLSConfig) bool {
+	if tlsCfg.CertFile == "" || tlsCfg.KeyFile == "" {
+		return false
+	}
+	if _, err := os.Stat(tlsCfg.CertFile); err != nil {
+		return false
+	}
+	if _, err := os.Stat(tlsCfg.KeyFile); err != nil {
+		return false
+	}
+	return true
+}
+
+// enroll performs the one-time bootstrap flow: generate a keypair, submit a
+// CSR to {baseURL}/enroll along with the one-time enrollToken, and persist
+// the signed certificate and private key at tlsCfg.CertFile/KeyFile.
+func enroll(baseURL string, tlsCfg config.TLSConfig, enrollToken string) error {
+	if enrollToken == "" {
+		return fmt.Errorf("content: ALGORITHM_ENROLL_TOKEN is required to enroll for mTLS")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("content: failed to generate enrollment key: %v", err)
+	}
+
+	csrTemplate := &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "babel-api"},
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, csrTemplate, key)
+	if err != nil {
+		return fmt.Errorf("content: failed to create CSR: %v", err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	reqBody, err := json.Marshal(enrollRequest{Token: enrollToken, CSR: string(csrPEM)})
+	if err != nil {
+		return fmt.Errorf("content: failed to marshal enroll request: %v", err)
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	resp, err := httpClient.Post(baseURL+"/enroll", "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return fmt.Errorf("content: enrollment request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("content: enrollment rejected with status %d", resp.StatusCode)
+	}
+
+	var enrollResp enrollResponse
+	if err := json.NewDecoder(resp.Body).Decode(&enrollResp); err != nil {
+		return fmt.Errorf("content: failed to decode enroll response: %v", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	if err := os.WriteFile(tlsCfg.KeyFile, keyPEM, 0600); err != nil {
+		return fmt.Errorf("content: failed to persist client key: %v", err)
+	}
+	if err := os.WriteFile(tlsCfg.CertFile, []byte(enrollResp.Certificate), 0644); err != nil {
+		return fmt.Errorf("content: failed to persist client cert: %v", err)
+	}
+
+	return nil
+}
+
+// GetEnrollmentStatus inspects the on-disk client certificate, if any, and
+// reports its subject, SHA-256 fingerprint, and expiry.
+func GetEnrollmentStatus(tlsCfg config.TLSConfig) (*EnrollmentStatus, error) {
+	if !IsEnrolled(tlsCfg) {
+		return &EnrollmentStatus{Enrolled: false}, nil
+	}
+
+	certPEM, err := os.ReadFile(tlsCfg.CertFile)
+	if err != nil {
+		return nil, fmt.Errorf("content: failed to read client cert: %v", err)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, fmt.Errorf("content: client cert file is not valid PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("content: failed to parse client cert: %v", err)
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	return &EnrollmentStatus{
+		Enrolled:    true,
+		Subject:     cert.Subject.String(),
+		Fingerprint: fmt.Sprintf("%x", sum),
+		ExpiresAt:   cert.NotAfter,
+	}, nil
+}
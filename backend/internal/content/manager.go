@@ -1,31 +1,179 @@
 package content
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/saint/babel-protocol/backend/api/models"
 	"github.com/saint/babel-protocol/backend/internal/cache"
-	"github.com/saint/babel-protocol/backend/internal/db/sqlite"
+	"github.com/saint/babel-protocol/backend/internal/config"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/observability"
+)
+
+var (
+	contentTracer = otel.Tracer(observability.TracerName)
+	contentMeter  = otel.Meter(observability.TracerName)
+
+	// algorithmLatency records how long the algorithm service takes to
+	// respond to a processBatch call, in seconds.
+	algorithmLatency metric.Float64Histogram
+	// batchSizeHist records how many content items went into each
+	// processBatch dispatch, to tune BatchConfig.MaxSize.
+	batchSizeHist metric.Int64Histogram
+	// cacheHits counts GetContent score-cache lookups, labeled
+	// result=hit|miss.
+	cacheHits metric.Int64Counter
+	// truthScoreHist records the distribution of truth scores content
+	// settles on, whether computed locally or by the algorithm service.
+	truthScoreHist metric.Float64Histogram
+	// queueDepth tracks how many content items are currently sitting in
+	// Manager's batch buffer, to correlate with algorithmLatency and tune
+	// BatchConfig.
+	queueDepth metric.Int64UpDownCounter
+	// flushReasonCounter counts processBatch flushes labeled by
+	// reason=size|latency|priority|unhealthy, to see which SLA is
+	// actually driving flushes in production.
+	flushReasonCounter metric.Int64Counter
+	// timeInQueueHist records how long each content item waited in the
+	// batch buffer before its batch flushed, in seconds.
+	timeInQueueHist metric.Float64Histogram
 )
 
+// Flush trigger reasons recorded against flushReasonCounter.
+const (
+	flushReasonSize      = "size"
+	flushReasonLatency   = "latency"
+	flushReasonPriority  = "priority"
+	flushReasonUnhealthy = "unhealthy"
+)
+
+// ErrQueueSaturated is returned by CreateContent (and AddContext) when the
+// batch queue is at config.BatchConfig.MaxQueueDepth and the algorithm
+// service's EWMA-tracked latency looks unhealthy. Rather than buffering
+// without limit while a struggling algorithm service falls further behind,
+// new content is rejected so callers can retry or shed load.
+var ErrQueueSaturated = errors.New("content: batch queue is saturated and the algorithm service looks unhealthy")
+
+func init() {
+	var err error
+	algorithmLatency, err = contentMeter.Float64Histogram(
+		"content.algorithm.latency",
+		metric.WithDescription("Latency of algorithm service batch analysis calls"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		log.Printf("failed to create algorithm.latency histogram: %v", err)
+	}
+	batchSizeHist, err = contentMeter.Int64Histogram(
+		"content.batch.size",
+		metric.WithDescription("Number of content items dispatched per processBatch call"),
+	)
+	if err != nil {
+		log.Printf("failed to create batch.size histogram: %v", err)
+	}
+	cacheHits, err = contentMeter.Int64Counter(
+		"content.cache.lookups",
+		metric.WithDescription("GetContent score-cache lookups, labeled by result=hit|miss"),
+	)
+	if err != nil {
+		log.Printf("failed to create cache.lookups counter: %v", err)
+	}
+	truthScoreHist, err = contentMeter.Float64Histogram(
+		"content.truth_score",
+		metric.WithDescription("Distribution of truth scores assigned to content"),
+	)
+	if err != nil {
+		log.Printf("failed to create truth_score histogram: %v", err)
+	}
+	queueDepth, err = contentMeter.Int64UpDownCounter(
+		"content.batch.queue_depth",
+		metric.WithDescription("Number of content items currently buffered awaiting a batch flush"),
+	)
+	if err != nil {
+		log.Printf("failed to create batch.queue_depth counter: %v", err)
+	}
+	flushReasonCounter, err = contentMeter.Int64Counter(
+		"content.batch.flush_reason",
+		metric.WithDescription("processBatch flushes, labeled by reason=size|latency|priority|unhealthy"),
+	)
+	if err != nil {
+		log.Printf("failed to create batch.flush_reason counter: %v", err)
+	}
+	timeInQueueHist, err = contentMeter.Float64Histogram(
+		"content.batch.time_in_queue",
+		metric.WithDescription("Time a content item spent buffered before its batch flushed"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		log.Printf("failed to create batch.time_in_queue histogram: %v", err)
+	}
+}
+
 // Manager handles content-related operations and integrates with the algorithm service
 type Manager struct {
-	db    *sqlite.DBManager
+	db    db.Repository
 	algo  *AlgorithmClient
 	cache *cache.Cache
 
+	// Scoring
+	defaultEngine       ScoringEngine
+	engineByContentType map[string]ScoringEngine
+	reputation          ReputationProvider
+
+	// Calibration (see calibration.go); nil if db.Repository doesn't
+	// implement CalibrationStore, in which case the tracker doesn't run.
+	calibration CalibrationStore
+
 	// Batch processing
+	batchCfg    config.BatchConfig
 	batchMu     sync.Mutex
-	batchBuffer map[string]*models.Content
-	batchTimer  *time.Timer
+	batchBuffer map[string]*queuedContent
+
+	// algoLatencyEWMA is an asymmetric (fast-rise, slow-decay) EWMA of
+	// AnalyzeContentBatchCtx latency, approximating the algorithm
+	// service's p99 without needing a real percentile histogram. It
+	// drives both the adaptive scheduler's effective batch size and
+	// CreateContent's backpressure decision.
+	latencyMu       sync.RWMutex
+	algoLatencyEWMA time.Duration
 
 	// Score caching
 	scoreMu    sync.RWMutex
 	scoreCache map[string]*scoreInfo
+
+	// Live score update fan-out (see pubsub.go)
+	scores *scoreBroker
+}
+
+// engineFor returns the ScoringEngine contentType should be scored with,
+// falling back to the manager's default engine for any content type
+// config.ScoringConfig.EngineByContentType doesn't name.
+func (m *Manager) engineFor(contentType string) ScoringEngine {
+	if engine, ok := m.engineByContentType[contentType]; ok {
+		return engine
+	}
+	return m.defaultEngine
+}
+
+// queuedContent pairs content awaiting batch processing with the context
+// (and trace span) of the CreateContent/AddContext call that queued it, so
+// processBatch can link the batch's span back to every request that fed it
+// even though no single caller owns the batch.
+type queuedContent struct {
+	content  *models.Content
+	ctx      context.Context
+	queuedAt time.Time
 }
 
 type scoreInfo struct {
@@ -35,14 +183,43 @@ type scoreInfo struct {
 	expiresAt       time.Time
 }
 
-// NewManager creates a new content manager instance
-func NewManager(db *sqlite.DBManager, algoURL string) *Manager {
+// NewManager creates a new content manager instance. tlsCfg and enrollToken
+// configure how algo is secured; see AlgorithmClient for the mTLS bootstrap
+// flow. scoringCfg selects the ScoringEngine (see RegisterScoringEngine)
+// each content type is scored with, failing fast if it names an engine
+// that was never registered. batchCfg tunes the adaptive batch scheduler
+// (see shouldFlush and queueForProcessing).
+func NewManager(db db.Repository, algoURL string, tlsCfg config.TLSConfig, enrollToken string, scoringCfg config.ScoringConfig, batchCfg config.BatchConfig) (*Manager, error) {
+	algo, err := NewAlgorithmClient(algoURL, tlsCfg, enrollToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create algorithm client: %v", err)
+	}
+
+	defaultEngine, err := NewScoringEngine(scoringCfg.DefaultEngine)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build default scoring engine: %v", err)
+	}
+
+	engineByContentType := make(map[string]ScoringEngine, len(scoringCfg.EngineByContentType))
+	for contentType, engineName := range scoringCfg.EngineByContentType {
+		engine, err := NewScoringEngine(engineName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build scoring engine for content type %q: %v", contentType, err)
+		}
+		engineByContentType[contentType] = engine
+	}
+
 	m := &Manager{
-		db:          db,
-		algo:        NewAlgorithmClient(algoURL),
-		cache:       cache.NewCache(5 * time.Minute),
-		batchBuffer: make(map[string]*models.Content),
-		scoreCache:  make(map[string]*scoreInfo),
+		db:                  db,
+		algo:                algo,
+		cache:               cache.NewInMemoryCache(5 * time.Minute),
+		defaultEngine:       defaultEngine,
+		engineByContentType: engineByContentType,
+		reputation:          newDBReputationProvider(db),
+		batchCfg:            batchCfg,
+		batchBuffer:         make(map[string]*queuedContent),
+		scoreCache:          make(map[string]*scoreInfo),
+		scores:              newScoreBroker(),
 	}
 
 	// Start batch processor
@@ -50,14 +227,28 @@ func NewManager(db *sqlite.DBManager, algoURL string) *Manager {
 
 	// Start cache cleanup
 	go m.startCacheCleanup()
-	return m
+	return m, nil
 }
 
-// CreateContent initializes new content with default values and stores it
-func (m *Manager) CreateContent(content *models.Content) error {
+// CreateContent initializes new content with default values and stores it.
+// ctx's span is retained on the queued item so processBatch can link the
+// eventual batch span back to this request even though the actual analysis
+// doesn't happen until the adaptive batch scheduler flushes it (see
+// shouldFlush). Returns ErrQueueSaturated, without storing content, if the
+// batch queue is full and the algorithm service looks unhealthy - the
+// content is never created in that case, so callers can retry instead of
+// the content floating in the database unscored indefinitely.
+func (m *Manager) CreateContent(ctx context.Context, content *models.Content) error {
+	ctx, span := observability.StartSpan(ctx, contentTracer, "content.CreateContent", attribute.String("content.id", content.ID))
+	defer span.End()
+
+	if err := m.checkQueueCapacity(); err != nil {
+		return err
+	}
+
 	// Set timestamps
 	now := time.Now()
-	content.Timestamp = now
+	content.Timestamp = models.NewBabelTime(now)
 	content.LastUpdated = now
 	content.ProcessingStatus = "pending"
 
@@ -67,23 +258,29 @@ func (m *Manager) CreateContent(content *models.Content) error {
 	}
 
 	// Add to batch processing queue
-	m.queueForProcessing(content)
+	m.queueForProcessing(ctx, content)
 
 	return nil
 }
 
 // GetContent retrieves content by ID and enriches it with additional data
-func (m *Manager) GetContent(id string) (*models.Content, error) {
+func (m *Manager) GetContent(ctx context.Context, id string) (*models.Content, error) {
+	ctx, span := observability.StartSpan(ctx, contentTracer, "content.GetContent", attribute.String("content.id", id))
+	defer span.End()
+
 	// Check cache first
-	if scores, exists := m.cache.GetContentScores(id); exists {
+	if scores, exists := m.cache.GetContentScores(ctx, id); exists {
+		cacheHits.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "hit")))
 		content, err := m.db.GetContent(id)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get content: %v", err)
 		}
 		content.TruthScore = scores.TruthScore
 		content.VisibilityScore = scores.VisibilityScore
+		truthScoreHist.Record(ctx, content.TruthScore)
 		return content, nil
 	}
+	cacheHits.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "miss")))
 
 	// Cache miss, get full content
 	content, err := m.db.GetContent(id)
@@ -108,46 +305,156 @@ func (m *Manager) GetContent(id string) (*models.Content, error) {
 			Type:        info.VoteType,
 			Weight:      info.VoteWeight,
 			EvidenceIDs: info.EvidenceIDs,
-			Timestamp:   info.Timestamp,
+			Timestamp:   models.NewBabelTime(info.Timestamp),
 			LastUpdated: info.LastUpdated,
 		}
 	}
 
-	// Calculate scores
-	content.TruthScore = calculateTruthScore(votes)
-	content.VisibilityScore = calculateVisibilityScore(votes)
+	// Calculate scores using whichever engine is configured for this
+	// content type
+	engine := m.engineFor(content.ContentType)
+	content.TruthScore = engine.TruthScore(votes, content.Topics, m.reputation).Score
+	content.VisibilityScore = engine.VisibilityScore(votes, content.Topics, m.reputation)
+	content.Consensus.State = engine.ConsensusState(content.Consensus.Score)
 
-	// Update cache
-	m.cache.SetContentScores(id, content.TruthScore, content.VisibilityScore)
+	// Update cache. expectedVersion is 0 because we only reach here on a
+	// cache miss; if another goroutine won the race and wrote first,
+	// ErrStale just means its (equally fresh) scores are already cached.
+	if _, err := m.cache.SetContentScores(ctx, id, content.TruthScore, content.VisibilityScore, 0); err != nil && err != cache.ErrStale {
+		return nil, fmt.Errorf("failed to cache content scores: %v", err)
+	}
+	truthScoreHist.Record(ctx, content.TruthScore)
 
 	return content, nil
 }
 
-// queueForProcessing adds content to the batch processing queue
-func (m *Manager) queueForProcessing(content *models.Content) {
+// checkQueueCapacity rejects new content with ErrQueueSaturated once the
+// batch buffer is at batchCfg.MaxQueueDepth and the algorithm service looks
+// unhealthy. It's a capacity check, not an atomic reservation - under
+// concurrent CreateContent calls the buffer can briefly overshoot
+// MaxQueueDepth by a handful of items, which is fine for an approximate
+// backpressure bound.
+func (m *Manager) checkQueueCapacity() error {
+	m.batchMu.Lock()
+	depth := len(m.batchBuffer)
+	m.batchMu.Unlock()
+
+	if depth >= m.batchCfg.MaxQueueDepth && m.algorithmUnhealthy() {
+		return ErrQueueSaturated
+	}
+	return nil
+}
+
+// queueForProcessing adds content to the batch processing queue, keeping
+// ctx alongside it so its span can be linked into the eventual batch span,
+// and queuedAt so shouldFlush can judge it against the latency SLA.
+func (m *Manager) queueForProcessing(ctx context.Context, content *models.Content) {
+	m.batchMu.Lock()
+	m.batchBuffer[content.ID] = &queuedContent{content: content, ctx: ctx, queuedAt: time.Now()}
+	m.batchMu.Unlock()
+
+	queueDepth.Add(ctx, 1)
+}
+
+// schedulerTick is how often shouldFlush re-evaluates the buffer. It's
+// independent of BatchConfig's own latency thresholds - fine-grained enough
+// that PriorityMaxLatency is honored promptly without busy-looping.
+const schedulerTick = 100 * time.Millisecond
+
+// recordAlgorithmLatency feeds d into the asymmetric EWMA used to estimate
+// the algorithm service's p99 latency: it rises quickly toward a slow call
+// (so a real slowdown is noticed almost immediately) and decays slowly
+// afterward (so a single slow call isn't forgotten the instant the next
+// batch happens to be fast).
+func (m *Manager) recordAlgorithmLatency(d time.Duration) {
+	const upAlpha, downAlpha = 0.5, 0.1
+
+	m.latencyMu.Lock()
+	defer m.latencyMu.Unlock()
+
+	if d >= m.algoLatencyEWMA {
+		m.algoLatencyEWMA += time.Duration(upAlpha * float64(d-m.algoLatencyEWMA))
+	} else {
+		m.algoLatencyEWMA -= time.Duration(downAlpha * float64(m.algoLatencyEWMA-d))
+	}
+}
+
+// algorithmLatencyEstimate returns the current EWMA latency estimate.
+func (m *Manager) algorithmLatencyEstimate() time.Duration {
+	m.latencyMu.RLock()
+	defer m.latencyMu.RUnlock()
+	return m.algoLatencyEWMA
+}
+
+// algorithmUnhealthy reports whether the algorithm service's estimated
+// latency exceeds batchCfg.UnhealthyLatency.
+func (m *Manager) algorithmUnhealthy() bool {
+	return m.algorithmLatencyEstimate() > m.batchCfg.UnhealthyLatency
+}
+
+// shouldFlush inspects the buffer under batchMu and decides whether
+// processBatch should run now, and why: MaxSize reached (shrunk when the
+// algorithm service looks unhealthy, so a struggling service is handed
+// smaller batches instead of falling further behind), the oldest
+// priority item exceeding PriorityMaxLatency, or the oldest item of any
+// priority exceeding MaxLatency.
+func (m *Manager) shouldFlush() (reason string, ok bool) {
 	m.batchMu.Lock()
 	defer m.batchMu.Unlock()
 
-	m.batchBuffer[content.ID] = content
+	n := len(m.batchBuffer)
+	if n == 0 {
+		return "", false
+	}
+
+	maxSize := m.batchCfg.MaxSize
+	if m.algorithmUnhealthy() {
+		if maxSize /= 4; maxSize < 1 {
+			maxSize = 1
+		}
+	}
+	if n >= maxSize {
+		return flushReasonSize, true
+	}
 
-	// Reset batch timer
-	if m.batchTimer != nil {
-		m.batchTimer.Reset(time.Second * 5)
+	var oldestNormal, oldestPriority time.Time
+	for _, qc := range m.batchBuffer {
+		if qc.content.Priority > 0 {
+			if oldestPriority.IsZero() || qc.queuedAt.Before(oldestPriority) {
+				oldestPriority = qc.queuedAt
+			}
+		} else if oldestNormal.IsZero() || qc.queuedAt.Before(oldestNormal) {
+			oldestNormal = qc.queuedAt
+		}
+	}
+	if !oldestPriority.IsZero() && time.Since(oldestPriority) >= m.batchCfg.PriorityMaxLatency {
+		return flushReasonPriority, true
 	}
+	if !oldestNormal.IsZero() && time.Since(oldestNormal) >= m.batchCfg.MaxLatency {
+		return flushReasonLatency, true
+	}
+	return "", false
 }
 
-// startBatchProcessor starts the background batch processor
+// startBatchProcessor starts the background adaptive batch scheduler.
 func (m *Manager) startBatchProcessor() {
-	m.batchTimer = time.NewTimer(time.Second * 5)
 	go func() {
-		for range m.batchTimer.C {
-			m.processBatch()
+		ticker := time.NewTicker(schedulerTick)
+		defer ticker.Stop()
+		for range ticker.C {
+			if reason, ok := m.shouldFlush(); ok {
+				m.processBatch(reason)
+			}
 		}
 	}()
 }
 
-// processBatch processes all queued content
-func (m *Manager) processBatch() {
+// processBatch processes all queued content. Since it coalesces content
+// queued by several independent CreateContent/AddContext calls, none of
+// which "owns" the resulting batch, its span links back to each queued
+// item's originating span instead of being parented under any one of them.
+// reason records which shouldFlush condition triggered this flush.
+func (m *Manager) processBatch(reason string) {
 	m.batchMu.Lock()
 	if len(m.batchBuffer) == 0 {
 		m.batchMu.Unlock()
@@ -156,9 +463,27 @@ func (m *Manager) processBatch() {
 
 	// Get batch and clear buffer
 	batch := m.batchBuffer
-	m.batchBuffer = make(map[string]*models.Content)
+	m.batchBuffer = make(map[string]*queuedContent)
 	m.batchMu.Unlock()
 
+	links := make([]trace.Link, 0, len(batch))
+	for _, qc := range batch {
+		if sc := trace.SpanContextFromContext(qc.ctx); sc.IsValid() {
+			links = append(links, trace.Link{SpanContext: sc})
+		}
+	}
+	ctx, span := contentTracer.Start(context.Background(), "content.processBatch",
+		trace.WithLinks(links...),
+		trace.WithAttributes(attribute.Int("batch.size", len(batch)), attribute.String("batch.flush_reason", reason)))
+	defer span.End()
+
+	batchSizeHist.Record(ctx, int64(len(batch)))
+	flushReasonCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+	queueDepth.Add(ctx, -int64(len(batch)))
+	for _, qc := range batch {
+		timeInQueueHist.Record(ctx, time.Since(qc.queuedAt).Seconds())
+	}
+
 	// Process batch
 	contentList := make([]*models.Content, 0, len(batch))
 	consensusRequests := make([]map[string]interface{}, 0, len(batch))
@@ -170,7 +495,7 @@ func (m *Manager) processBatch() {
 		request map[string]interface{}
 	}, len(batch))
 
-	for _, content := range batch {
+	for _, qc := range batch {
 		wg.Add(1)
 		go func(c *models.Content) {
 			defer wg.Done()
@@ -202,7 +527,7 @@ func (m *Manager) processBatch() {
 					"previous_consensus_score": c.Consensus.Score,
 				},
 			}
-		}(content)
+		}(qc.content)
 	}
 
 	// Close channel when all goroutines are done
@@ -218,13 +543,17 @@ func (m *Manager) processBatch() {
 	}
 
 	// Send batch requests
-	contentResult, err := m.algo.AnalyzeContentBatch(&models.AlgorithmRequest{
+	start := time.Now()
+	contentResult, err := m.algo.AnalyzeContentBatchCtx(ctx, &models.AlgorithmRequest{
 		Type: "content_analysis_batch",
 		Parameters: map[string]interface{}{
 			"content_batch": contentList,
 		},
 		Timestamp: time.Now(),
 	})
+	elapsed := time.Since(start)
+	algorithmLatency.Record(ctx, elapsed.Seconds())
+	m.recordAlgorithmLatency(elapsed)
 
 	if err != nil {
 		m.logError("ProcessBatch", err)
@@ -242,6 +571,7 @@ func (m *Manager) processBatch() {
 				c.Topics = analysis.Topics
 				c.Entities = analysis.Entities
 				c.TruthScore = analysis.TruthScore
+				truthScoreHist.Record(ctx, c.TruthScore)
 
 				// Update score cache
 				m.scoreMu.Lock()
@@ -252,6 +582,8 @@ func (m *Manager) processBatch() {
 					expiresAt:       time.Now().Add(15 * time.Minute),
 				}
 				m.scoreMu.Unlock()
+
+				m.publishScoreUpdate(c)
 			}
 		}(content)
 	}
@@ -271,7 +603,7 @@ func (m *Manager) processBatch() {
 		c.LastUpdated = now
 		if c.ContentType == "context" {
 			// Apply temporal decay to context impact
-			age := time.Since(c.Timestamp)
+			age := time.Since(c.Timestamp.Time)
 			decayFactor := math.Exp(-age.Hours() / (30 * 24)) // 30-day half-life
 			contextImpact := c.TruthScore * decayFactor
 
@@ -306,8 +638,26 @@ func (m *Manager) ValidateContent(content *models.Content) error {
 }
 
 // Helper functions
-
-func calculateTruthScore(votes []*models.Vote) float64 {
+//
+// CalculateTruthScore, CalculateVisibilityScore, CalculateContextScore,
+// UpdateTruthScoreWithContext, and GetConsensusState are exported (rather
+// than the package-private helpers they once were) so internal/conformance
+// can pin their numerical behavior against a versioned vector corpus, and
+// so the algorithm service has a reference Go implementation to validate
+// against.
+
+// CalculateTruthScore computes a content item's truth score from its
+// affirm/deny votes using a time-decayed weighted average - an
+// exponential moving average lets each new vote update the score in O(1)
+// without rescanning history.
+//
+// rep, if non-nil, multiplies each vote's weight by that voter's
+// historical calibration on topics (see ReputationProvider), so a swarm
+// of freshly-created accounts with no track record can't outweigh a
+// handful of voters who have been reliably right about this subject
+// before. rep may be nil (e.g. from internal/conformance's vector
+// corpus), in which case weighting falls back to vote.Weight alone.
+func CalculateTruthScore(votes []*models.Vote, topics []string, rep ReputationProvider) float64 {
 	if len(votes) == 0 {
 		return 0.0
 	}
@@ -317,7 +667,10 @@ func calculateTruthScore(votes []*models.Vote) float64 {
 	// Use exponential moving average for O(1) updates
 	for _, vote := range votes {
 		if vote.Type == models.VoteTypeAffirm || vote.Type == models.VoteTypeDeny {
-			weight := vote.Weight * math.Exp(-time.Since(vote.Timestamp).Hours()/24.0)
+			weight := vote.Weight * math.Exp(-time.Since(vote.Timestamp.Time).Hours()/24.0)
+			if rep != nil {
+				weight *= rep.VoterWeight(vote.UserID, topics)
+			}
 			voteValue := getVoteTypeValue(vote.Type, vote.CertaintyLevel)
 			weightedSum += weight * voteValue
 			totalWeight += weight
@@ -330,7 +683,14 @@ func calculateTruthScore(votes []*models.Vote) float64 {
 	return weightedSum / totalWeight
 }
 
-func calculateVisibilityScore(votes []*models.Vote) float64 {
+// CalculateVisibilityScore computes how prominently content should surface
+// from its votes, weighting engagement types differently and decaying
+// older votes on a 24-hour half-life before normalizing into [0.1, 1.0].
+//
+// rep behaves as in CalculateTruthScore: nil disables reputation
+// weighting entirely, otherwise each vote's weight is scaled by the
+// voter's calibration on topics.
+func CalculateVisibilityScore(votes []*models.Vote, topics []string, rep ReputationProvider) float64 {
 	if len(votes) == 0 {
 		return 1.0 // Default visibility
 	}
@@ -345,8 +705,11 @@ func calculateVisibilityScore(votes []*models.Vote) float64 {
 
 	for _, vote := range votes {
 		// Calculate time decay factor
-		ageFactor := math.Exp(-time.Since(vote.Timestamp).Hours() / decayHalfLife.Hours())
+		ageFactor := math.Exp(-time.Since(vote.Timestamp.Time).Hours() / decayHalfLife.Hours())
 		weight := vote.Weight * ageFactor
+		if rep != nil {
+			weight *= rep.VoterWeight(vote.UserID, topics)
+		}
 
 		// Weight different vote types
 		switch vote.Type {
@@ -404,20 +767,9 @@ func (m *Manager) logError(operation string, err error) {
 	}
 }
 
-// logMetric logs a metric to the database with precise timestamp
-func (m *Manager) logMetric(name string, value float64, metadata map[string]interface{}) {
-	if err := m.db.LogMetric(&models.AlgorithmMetric{
-		AlgorithmName: "content_manager",
-		MetricName:    name,
-		Value:         value,
-		Timestamp:     time.Now(),
-		Metadata:      metadata,
-	}); err != nil {
-		log.Printf("failed to log metric: %v", err)
-	}
-}
-
-func getConsensusState(score float64) string {
+// GetConsensusState buckets a consensus score into the coarse label
+// consumers (UI, notifications) display instead of a raw float.
+func GetConsensusState(score float64) string {
 	switch {
 	case score >= 0.8:
 		return "established"
@@ -446,47 +798,57 @@ func (m *Manager) startCacheCleanup() {
 }
 
 // AddContext adds context to an existing content post
-func (m *Manager) AddContext(parentID string, context *models.Content) error {
+func (m *Manager) AddContext(ctx context.Context, parentID string, contextContent *models.Content) error {
+	ctx, span := observability.StartSpan(ctx, contentTracer, "content.AddContext", attribute.String("content.id", parentID))
+	defer span.End()
+
 	// Verify the user is the author
 	parent, err := m.db.GetContent(parentID)
 	if err != nil {
 		return fmt.Errorf("failed to get parent content: %v", err)
 	}
-	if parent.AuthorID != context.AuthorID {
+	if parent.AuthorID != contextContent.AuthorID {
 		return fmt.Errorf("only the author can add context")
 	}
 
+	if err := m.checkQueueCapacity(); err != nil {
+		return err
+	}
+
 	// Set up context content with timestamps
 	now := time.Now()
-	context.ContentType = "context"
-	context.ParentID = &parentID
-	context.ProcessingStatus = "pending"
-	context.Timestamp = now
-	context.LastUpdated = now
-
-	// Calculate initial scores
-	contextScore := calculateContextScore(context)
-	context.TruthScore = contextScore
-	context.VisibilityScore = 1.0 // Context is always visible
+	contextContent.ContentType = "context"
+	contextContent.ParentID = &parentID
+	contextContent.ProcessingStatus = "pending"
+	contextContent.Timestamp = models.NewBabelTime(now)
+	contextContent.LastUpdated = now
+
+	// Calculate initial scores using the engine configured for "context"
+	// content (falls back to the manager's default engine)
+	contextScore := m.engineFor(contextContent.ContentType).ContextScore(contextContent)
+	contextContent.TruthScore = contextScore
+	contextContent.VisibilityScore = 1.0 // Context is always visible
 
 	// Store the context
-	if err := m.db.CreateContent(context); err != nil {
+	if err := m.db.CreateContent(contextContent); err != nil {
 		return fmt.Errorf("failed to create context: %v", err)
 	}
 
 	// Queue for processing
-	m.queueForProcessing(context)
+	m.queueForProcessing(ctx, contextContent)
+	m.publishScoreUpdate(contextContent)
 
 	// Update parent content's scores with timestamp
-	parent.TruthScore = updateTruthScoreWithContext(parent.TruthScore, contextScore)
+	parent.TruthScore = UpdateTruthScoreWithContext(parent.TruthScore, contextScore)
 	parent.LastUpdated = now
 	if err := m.db.UpdateContent(parent); err != nil {
 		// Log error but don't fail the operation
 		fmt.Printf("failed to update parent content: %v\n", err)
 	}
+	m.publishScoreUpdate(parent)
 
 	// Update user's context contribution score
-	if err := m.updateUserContextScore(context.AuthorID); err != nil {
+	if err := m.updateUserContextScore(contextContent.AuthorID); err != nil {
 		// Log error but don't fail the operation
 		fmt.Printf("failed to update user context score: %v\n", err)
 	}
@@ -494,8 +856,8 @@ func (m *Manager) AddContext(parentID string, context *models.Content) error {
 	return nil
 }
 
-// calculateContextScore determines the quality score of context
-func calculateContextScore(content *models.Content) float64 {
+// CalculateContextScore determines the quality score of context
+func CalculateContextScore(content *models.Content) float64 {
 	baseScore := 0.6 // Base score for context
 
 	// Add bonus for substantial text (up to 0.2)
@@ -513,8 +875,8 @@ func calculateContextScore(content *models.Content) float64 {
 	return baseScore + textBonus + mediaBonus
 }
 
-// updateTruthScoreWithContext updates a content's truth score based on context
-func updateTruthScoreWithContext(currentScore, contextScore float64) float64 {
+// UpdateTruthScoreWithContext updates a content's truth score based on context
+func UpdateTruthScoreWithContext(currentScore, contextScore float64) float64 {
 	// Context can improve score by up to 20%
 	improvement := contextScore * 0.2
 	return math.Min(1.0, currentScore+improvement)
@@ -533,7 +895,7 @@ func (m *Manager) updateUserContextScore(userID string) error {
 	now := time.Now()
 
 	for _, ctx := range contexts {
-		age := now.Sub(ctx.Timestamp)
+		age := now.Sub(ctx.Timestamp.Time)
 		weight := math.Exp(-age.Hours() / (30 * 24)) // 30-day half-life
 		weightedSum += ctx.TruthScore * weight
 		totalWeight += weight
@@ -0,0 +1,117 @@
+package content
+
+import (
+	"sync"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// allTopic is the internal topic key SubscribeAll listens on, distinct from
+// any real content ID.
+const allTopic = "*"
+
+// ScoreUpdate is published whenever processBatch or AddContext settles a
+// content item's scores, so SSE/WebSocket consumers can render live
+// consensus movement instead of polling GetContent.
+type ScoreUpdate struct {
+	ContentID       string    `json:"content_id"`
+	TruthScore      float64   `json:"truth_score"`
+	VisibilityScore float64   `json:"visibility_score"`
+	ConsensusState  string    `json:"consensus_state"`
+	At              time.Time `json:"at"`
+}
+
+// scoreBroker fans a ScoreUpdate out to every subscriber of its content ID
+// plus every SubscribeAll subscriber. It's a self-contained, typed broker
+// rather than an internal/bus.Bus - bus.Bus exists to fan WebSocket events
+// out across API server instances, while score updates are computed locally
+// by whichever instance's batch timer fired and only need in-process
+// delivery to that instance's own SSE/WebSocket consumers.
+type scoreBroker struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan ScoreUpdate]struct{}
+}
+
+func newScoreBroker() *scoreBroker {
+	return &scoreBroker{subs: make(map[string]map[chan ScoreUpdate]struct{})}
+}
+
+// publish delivers update to every subscriber of its content ID and every
+// SubscribeAll subscriber. Slow subscribers are dropped rather than allowed
+// to block processBatch/AddContext.
+func (b *scoreBroker) publish(update ScoreUpdate) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subs[update.ContentID] {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+	for ch := range b.subs[allTopic] {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
+
+// subscribe returns a channel that receives every ScoreUpdate published
+// under topic (a content ID, or allTopic for every content ID).
+func (b *scoreBroker) subscribe(topic string) chan ScoreUpdate {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan ScoreUpdate, 16)
+	if b.subs[topic] == nil {
+		b.subs[topic] = make(map[chan ScoreUpdate]struct{})
+	}
+	b.subs[topic][ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe stops delivery to ch and closes it.
+func (b *scoreBroker) unsubscribe(topic string, ch chan ScoreUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if subs, ok := b.subs[topic]; ok {
+		if _, ok := subs[ch]; ok {
+			delete(subs, ch)
+			close(ch)
+		}
+		if len(subs) == 0 {
+			delete(b.subs, topic)
+		}
+	}
+}
+
+// Subscribe returns a channel of score updates for a single content ID,
+// plus an unsubscribe func that must be called when the caller is done
+// (e.g. when an SSE client disconnects) to release the channel.
+func (m *Manager) Subscribe(contentID string) (<-chan ScoreUpdate, func()) {
+	ch := m.scores.subscribe(contentID)
+	return ch, func() { m.scores.unsubscribe(contentID, ch) }
+}
+
+// SubscribeAll returns a channel of score updates for every content item,
+// plus an unsubscribe func that must be called when the caller is done.
+func (m *Manager) SubscribeAll() (<-chan ScoreUpdate, func()) {
+	ch := m.scores.subscribe(allTopic)
+	return ch, func() { m.scores.unsubscribe(allTopic, ch) }
+}
+
+// publishScoreUpdate builds a ScoreUpdate from c's current scores, using
+// the engine configured for c's content type to derive ConsensusState, and
+// fans it out over m.scores.
+func (m *Manager) publishScoreUpdate(c *models.Content) {
+	m.scores.publish(ScoreUpdate{
+		ContentID:       c.ID,
+		TruthScore:      c.TruthScore,
+		VisibilityScore: c.VisibilityScore,
+		ConsensusState:  m.engineFor(c.ContentType).ConsensusState(c.TruthScore),
+		At:              time.Now(),
+	})
+}
@@ -0,0 +1,85 @@
+package content
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// ReputationProvider weights a voter's influence on a truth/visibility
+// score by how reliable they've historically been, not just by
+// vote.Weight. Without it, a swarm of freshly-created accounts (each with
+// a vote.Weight of 1.0) can outvote a small number of users who have
+// actually been right about this subject before - the classic
+// low-effort-brigading attack against the old weight*exp(-age/24h)
+// formula. It's nil-safe everywhere it's consumed: CalculateTruthScore
+// and CalculateVisibilityScore both accept a nil ReputationProvider (so
+// internal/conformance's vector corpus can keep validating the pure-math
+// formula unmodified) and treat that as "don't reweight."
+type ReputationProvider interface {
+	// VoterWeight returns a multiplier, nominally in [0, 1], for voterID's
+	// influence on content tagged with topics. 1.0 means "weight this vote
+	// at face value."
+	VoterWeight(voterID string, topics []string) float64
+}
+
+// dbReputationProvider is the production ReputationProvider, backed by
+// db.Repository. It has no state of its own; every call reads the
+// voter's current User row.
+type dbReputationProvider struct {
+	db db.Repository
+}
+
+func newDBReputationProvider(repo db.Repository) *dbReputationProvider {
+	return &dbReputationProvider{db: repo}
+}
+
+// VoterWeight combines a voter's general engagement/context track record
+// with any topic-specific calibration built up by the calibration
+// tracker (see calibration.go). A voter with no history at all - a brand
+// new account - still gets a neutral-ish weight rather than zero, since
+// EngagementQuality/ContextQuality/ConfidenceScore all default to 0 and
+// the formula below floors out well above zero; the goal is to dampen
+// low-effort brigading, not to lock new users out of voting entirely.
+func (p *dbReputationProvider) VoterWeight(voterID string, topics []string) float64 {
+	user, err := p.db.GetUser(voterID)
+	if err != nil || user == nil {
+		// Unknown voter: weight at face value rather than penalizing a
+		// lookup failure that isn't the voter's fault.
+		return 1.0
+	}
+
+	base := 0.5 + 0.3*clamp01(user.EngagementQuality) + 0.2*clamp01(user.ContextQuality)
+
+	if info, ok := user.DomainExpertise[topicClusterKey(topics)]; ok {
+		base *= 0.5 + 0.5*clamp01(info.ConfidenceScore)
+	}
+
+	return base
+}
+
+// clamp01 clamps v into [0, 1], guarding against scores that have
+// drifted outside their nominal range (e.g. a stale or corrupt record).
+func clamp01(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// topicClusterKey collapses a content item's topic list into a single
+// map key for DomainExpertise, sorted so topic order doesn't fragment a
+// voter's calibration history, and defaulting to "general" for untagged
+// content rather than using an empty string as a key.
+func topicClusterKey(topics []string) string {
+	if len(topics) == 0 {
+		return "general"
+	}
+	sorted := append([]string(nil), topics...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "+")
+}
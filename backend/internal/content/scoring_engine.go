@@ -0,0 +1,73 @@
+package content
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// ScoringEngine computes content scores from its vote history. It's the
+// seam that lets Manager swap consensus models (the built-in EMA engine,
+// a Bayesian one, or a future third-party one) without the rest of the
+// manager caring which formula produced a score.
+type ScoringEngine interface {
+	// Name identifies the engine as it was registered with
+	// RegisterScoringEngine.
+	Name() string
+	// topics and rep feed reputation-weighted vote quorum; see
+	// ReputationProvider. rep may be nil to disable it.
+	TruthScore(votes []*models.Vote, topics []string, rep ReputationProvider) ScoreResult
+	VisibilityScore(votes []*models.Vote, topics []string, rep ReputationProvider) float64
+	ContextScore(content *models.Content) float64
+	ConsensusState(score float64) string
+}
+
+// ScoreResult is a ScoringEngine's truth-score output. CredibleInterval is
+// nil for point-estimate engines (e.g. the EMA engine) and set for engines
+// that model uncertainty (e.g. the Bayesian engine), so callers that only
+// want a number can ignore it without a type switch.
+type ScoreResult struct {
+	Score float64
+	// CredibleInterval, if non-nil, is the engine's [low, high] 95%
+	// credible interval around Score.
+	CredibleInterval *[2]float64
+}
+
+// EngineFactory builds a fresh ScoringEngine instance. Engines are
+// expected to be stateless (or safe for concurrent use), since Manager
+// shares one instance across every request routed to it.
+type EngineFactory func() ScoringEngine
+
+var (
+	enginesMu sync.RWMutex
+	engines   = map[string]EngineFactory{}
+)
+
+// RegisterScoringEngine adds factory to the engine registry under name,
+// the same way Vault's database plugin catalog registers a backend by
+// name for later lookup by config. It panics if name is already
+// registered, so two engines silently shadowing each other at init time
+// fails loudly instead of picking one at random.
+func RegisterScoringEngine(name string, factory EngineFactory) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	if _, dup := engines[name]; dup {
+		panic(fmt.Sprintf("content: RegisterScoringEngine called twice for engine %q", name))
+	}
+	engines[name] = factory
+}
+
+// NewScoringEngine looks up name in the registry and builds a fresh
+// instance. Callers (Manager's constructor) should fail fast on an
+// unknown name rather than silently falling back, since a typo'd engine
+// name in config is a deploy-time mistake worth surfacing immediately.
+func NewScoringEngine(name string) (ScoringEngine, error) {
+	enginesMu.RLock()
+	factory, ok := engines[name]
+	enginesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("content: unknown scoring engine %q", name)
+	}
+	return factory(), nil
+}
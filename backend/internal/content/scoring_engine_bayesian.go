@@ -0,0 +1,73 @@
+package content
+
+import (
+	"math"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// bayesianScoringEngine tracks affirm/deny votes as the alpha/beta
+// parameters of a Beta distribution instead of collapsing them into a
+// single weighted average. alpha/beta start at the uniform Beta(1,1)
+// prior (no evidence either way -> a flat prior over [0,1]) and each vote
+// adds time-decayed, certainty-scaled mass to alpha (affirm) or beta
+// (deny). The posterior mean is the reported TruthScore, and its variance
+// gives a 95% credible interval - unlike the EMA engine, this makes "we
+// have one confident vote" and "we have a thousand votes that average out
+// to the same score" visibly different.
+type bayesianScoringEngine struct{}
+
+func init() {
+	RegisterScoringEngine("bayesian", func() ScoringEngine { return bayesianScoringEngine{} })
+}
+
+func (bayesianScoringEngine) Name() string { return "bayesian" }
+
+func (bayesianScoringEngine) TruthScore(votes []*models.Vote, topics []string, rep ReputationProvider) ScoreResult {
+	alpha, beta := 1.0, 1.0 // Beta(1,1) prior: uniform over [0,1]
+
+	for _, vote := range votes {
+		if vote.Type != models.VoteTypeAffirm && vote.Type != models.VoteTypeDeny {
+			continue
+		}
+		mass := vote.Weight * certaintyMass(vote.CertaintyLevel) * math.Exp(-time.Since(vote.Timestamp.Time).Hours()/24.0)
+		if rep != nil {
+			mass *= rep.VoterWeight(vote.UserID, topics)
+		}
+		if vote.Type == models.VoteTypeAffirm {
+			alpha += mass
+		} else {
+			beta += mass
+		}
+	}
+
+	total := alpha + beta
+	mean := alpha / total
+	variance := (alpha * beta) / (total * total * (total + 1))
+	stddev := math.Sqrt(variance)
+
+	lo := math.Max(0, mean-1.96*stddev)
+	hi := math.Min(1, mean+1.96*stddev)
+	return ScoreResult{Score: mean, CredibleInterval: &[2]float64{lo, hi}}
+}
+
+// certaintyMass scales a vote's contribution to alpha/beta by its
+// certainty level (1-3), separately from the EMA engine's
+// getVoteTypeValue scaling since a Beta posterior treats "how sure was
+// the voter" as evidence weight rather than as a value between 0 and 1.
+func certaintyMass(certaintyLevel int) float64 {
+	return 0.5 + float64(certaintyLevel)*0.5 // certainty 1,2,3 -> 1.0, 1.5, 2.0
+}
+
+func (bayesianScoringEngine) VisibilityScore(votes []*models.Vote, topics []string, rep ReputationProvider) float64 {
+	return CalculateVisibilityScore(votes, topics, rep)
+}
+
+func (bayesianScoringEngine) ContextScore(content *models.Content) float64 {
+	return CalculateContextScore(content)
+}
+
+func (bayesianScoringEngine) ConsensusState(score float64) string {
+	return GetConsensusState(score)
+}
@@ -0,0 +1,31 @@
+package content
+
+import "github.com/saint/babel-protocol/backend/api/models"
+
+// emaScoringEngine is the original exponential-moving-average engine,
+// registered as "ema" - the built-in default every deployment already
+// runs, now expressed as a ScoringEngine instead of being hard-coded into
+// Manager.
+type emaScoringEngine struct{}
+
+func init() {
+	RegisterScoringEngine("ema", func() ScoringEngine { return emaScoringEngine{} })
+}
+
+func (emaScoringEngine) Name() string { return "ema" }
+
+func (emaScoringEngine) TruthScore(votes []*models.Vote, topics []string, rep ReputationProvider) ScoreResult {
+	return ScoreResult{Score: CalculateTruthScore(votes, topics, rep)}
+}
+
+func (emaScoringEngine) VisibilityScore(votes []*models.Vote, topics []string, rep ReputationProvider) float64 {
+	return CalculateVisibilityScore(votes, topics, rep)
+}
+
+func (emaScoringEngine) ContextScore(content *models.Content) float64 {
+	return CalculateContextScore(content)
+}
+
+func (emaScoringEngine) ConsensusState(score float64) string {
+	return GetConsensusState(score)
+}
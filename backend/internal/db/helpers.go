@@ -0,0 +1,28 @@
+package db
+
+import "encoding/json"
+
+// JSONArrayToString marshals a string slice for storage in a TEXT/JSONB
+// column, normalizing nil to an empty JSON array so scans never see "".
+func JSONArrayToString(arr []string) (string, error) {
+	if arr == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(arr)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// StringToJSONArray is the inverse of JSONArrayToString.
+func StringToJSONArray(s string) ([]string, error) {
+	if s == "" {
+		return []string{}, nil
+	}
+	var arr []string
+	if err := json.Unmarshal([]byte(s), &arr); err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
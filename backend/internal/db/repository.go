@@ -0,0 +1,184 @@
+// Package db defines the storage-agnostic Repository interface implemented
+// by each of the concrete backends (sqlite, postgres, mongo) so the rest of
+// the application can depend on behavior instead of a specific SQL dialect.
+package db
+
+import (
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// Backend identifies which Repository implementation to construct at
+// startup. Values match the DATABASE_BACKEND config setting.
+const (
+	BackendSQLite   = "sqlite"
+	BackendPostgres = "postgres"
+	BackendMongo    = "mongo"
+)
+
+// VoteInfo is the row shape returned by GetContentVotes.
+type VoteInfo struct {
+	ContentID      string    `json:"content_id"`
+	VoterID        string    `json:"voter_id"`
+	VoteType       string    `json:"vote_type"`
+	VoteWeight     float64   `json:"vote_weight"`
+	CertaintyLevel int       `json:"certainty_level"`
+	EvidenceIDs    []string  `json:"evidence_ids"`
+	Timestamp      time.Time `json:"timestamp"`
+	LastUpdated    time.Time `json:"last_updated"`
+}
+
+// ItemSimilarity is one item's precomputed similarity to a neighboring
+// item, as stored by UpsertItemSimilarities and read back by
+// GetItemSimilarities. The item-based collaborative filtering recommender
+// in internal/recommend recomputes these incrementally and keeps only the
+// top-K neighbors per item.
+type ItemSimilarity struct {
+	NeighborID string    `json:"neighbor_id"`
+	Similarity float64   `json:"similarity"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// BundleEvidenceLimit caps how much evidence GetContentBundle/GetContentBundles
+// attach to each item - enough for a feed card without pulling the full,
+// potentially large, evidence chain.
+const BundleEvidenceLimit = 5
+
+// AuthorSummary is the subset of a User a feed card needs to render an
+// author byline, carried on ContentBundle instead of a full *models.User.
+type AuthorSummary struct {
+	ID                string  `json:"id"`
+	Username          string  `json:"username"`
+	AuthenticityScore float64 `json:"authenticity_score"`
+	ReputationScore   float64 `json:"reputation_score"`
+}
+
+// VoteTally is the per-type vote counts for a piece of content, aggregated
+// server-side so callers don't have to walk every VoteInfo themselves.
+type VoteTally struct {
+	Upvotes   int `json:"upvotes"`
+	Downvotes int `json:"downvotes"`
+	Affirms   int `json:"affirms"`
+	Denies    int `json:"denies"`
+	Engages   int `json:"engages"`
+	Unengages int `json:"unengages"`
+	Total     int `json:"total"`
+}
+
+// ContentBundle is the result of a single batched lookup combining a piece
+// of content with everything a feed item typically needs alongside it:
+// the author's byline info, an aggregated vote tally, the top evidence by
+// quality score (capped at BundleEvidenceLimit), and the viewer's own vote
+// on the content, if any. It replaces the four-round-trip
+// GetContent/GetContentVotes/GetContentEvidence/GetUser pattern.
+type ContentBundle struct {
+	Content     *models.Content    `json:"content"`
+	Author      *AuthorSummary     `json:"author,omitempty"`
+	VoteTally   VoteTally          `json:"vote_tally"`
+	TopEvidence []*models.Evidence `json:"top_evidence,omitempty"`
+	ViewerVote  *VoteInfo          `json:"viewer_vote,omitempty"`
+}
+
+// Repository is the full set of persistence operations the API layer needs.
+// Every backend (sqlite, postgres, mongo) implements it in full; callers
+// should depend on this interface rather than a concrete *sqlite.DBManager
+// so the backend can be swapped via config without touching call sites.
+type Repository interface {
+	// Content
+	CreateContent(content *models.Content) error
+	GetContent(id string) (*models.Content, error)
+	UpdateContent(content *models.Content) error
+	UpdateContentBatch(contents []*models.Content) error
+	GetUserContent(userID string, since time.Time) ([]*models.Content, error)
+	GetUserContexts(userID string, since time.Time) ([]*models.Content, error)
+
+	// GetRecentContent returns every content item (any author, including
+	// context posts) created or updated since the given time, across the
+	// whole corpus rather than one user's - it backs the item-based
+	// collaborative filtering recommender's candidate-item and
+	// context-event discovery.
+	GetRecentContent(since time.Time) ([]*models.Content, error)
+
+	// Users
+	CreateUser(user *models.User) error
+	GetUser(id string) (*models.User, error)
+	UpdateUser(user *models.User) error
+
+	// Votes / consensus. RecordVote and UpdateUser's reputation fields are
+	// projections replayed from an append-only event log rather than
+	// authoritative state; ReplayVoteEvents and RebuildAllProjections
+	// re-derive them, enabling verifiable re-scoring.
+	RecordVote(contentID, voterID, voteType string, voteWeight float64, certaintyLevel int, evidenceIDs []string) error
+	GetContentVotes(contentID string) ([]*VoteInfo, error)
+	GetUserVotes(userID string, since time.Time) ([]*models.Vote, error)
+	ReplayVoteEvents(contentID string) error
+	RebuildAllProjections() error
+
+	// GetContentBundle and GetContentBundles load a feed-ready view of one
+	// or many content items - content, author, vote tally, top evidence,
+	// and the viewer's own vote - in a single query per call regardless of
+	// how many items are requested. viewerID may be empty if there is no
+	// authenticated viewer.
+	GetContentBundle(contentID, viewerID string) (*ContentBundle, error)
+	GetContentBundles(ids []string, viewerID string) ([]*ContentBundle, error)
+
+	// Bulk-by-key lookups. These back the internal/loaders batching layer:
+	// each issues a single WHERE id IN (...) query (or backend equivalent)
+	// instead of one query per key.
+	GetUsersByIDs(ids []string) ([]*models.User, error)
+	GetContentByIDs(ids []string) ([]*models.Content, error)
+	GetContentVotesByContentIDs(contentIDs []string) (map[string][]*VoteInfo, error)
+	GetContentEvidenceByContentIDs(contentIDs []string) (map[string][]*models.Evidence, error)
+	GetUserVerificationsByUserIDs(userIDs []string) (map[string][]*models.UserVerification, error)
+
+	// UpsertItemSimilarities replaces itemID's stored neighbor list with
+	// neighbors in a single call, and GetItemSimilarities reads it back.
+	// Together they back the item-based CF recommender's background
+	// refresh: only items touched since the last run are re-upserted.
+	UpsertItemSimilarities(itemID string, neighbors []ItemSimilarity) error
+	GetItemSimilarities(itemID string) ([]ItemSimilarity, error)
+
+	// Evidence
+	CreateEvidence(evidence *models.Evidence) error
+	GetEvidence(id string) (*models.Evidence, error)
+	GetContentEvidence(contentID string) ([]*models.Evidence, error)
+	UpdateEvidence(evidence *models.Evidence) error
+	GetUserEvidence(userID string, since time.Time) ([]*models.Evidence, error)
+
+	// Algorithm cache
+	SetCache(key string, value string, expiry time.Time) error
+	GetCache(key string) (string, error)
+
+	// Algorithm errors and metrics
+	LogError(err *models.AlgorithmError) error
+	RecordMetric(metric *models.AlgorithmMetric) error
+	LogMetric(metric *models.AlgorithmMetric) error
+
+	// Experiments back the bandit-driven FeedbackLoopOptimization/ABTesting
+	// endpoints. AssignArm is sticky per user+experiment and falls back to
+	// a deterministic hash split during warm-up (before an experiment has
+	// collected any reward data); RecordExperimentEvent folds a reward
+	// observation into the assigned arm's posterior.
+	CreateExperiment(experiment *models.Experiment, arms []*models.ExperimentArm) error
+	AssignArm(experimentName, userID string) (*models.ExperimentArm, error)
+	RecordExperimentEvent(experimentName, userID string, reward float64) error
+	GetArmStats(experimentName string) ([]*models.ArmStats, error)
+
+	// User activity and verification
+	GetUserActivities(userID string, since time.Time) ([]*models.UserActivity, error)
+	GetUserVerifications(userID string) ([]*models.UserVerification, error)
+
+	// Direct messages
+	CreateDirectMessage(message *models.DirectMessage) error
+
+	// Event subscribers (outbound webhook/WebSocket delivery)
+	CreateEventSubscriber(sub *models.EventSubscriber) error
+	GetEventSubscriber(id string) (*models.EventSubscriber, error)
+	ListEventSubscribers() ([]*models.EventSubscriber, error)
+	UpdateEventSubscriber(sub *models.EventSubscriber) error
+	DeleteEventSubscriber(id string) error
+	LogDeadLetterEvent(event *models.DeadLetterEvent) error
+
+	Close() error
+}
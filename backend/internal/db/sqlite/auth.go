@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/auth"
+)
+
+func (m *DBManager) CreateCredential(userID, username, passwordHash, role string) error {
+	_, err := m.writeDB.Exec(`
+		INSERT INTO auth_credentials (user_id, username, password_hash, role, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, userID, username, passwordHash, role, time.Now().Unix())
+	return err
+}
+
+func (m *DBManager) GetCredentialByUsername(username string) (*auth.Credential, error) {
+	var cred auth.Credential
+	err := m.db.QueryRow(`
+		SELECT user_id, username, password_hash, role FROM auth_credentials WHERE username = ?
+	`, username).Scan(&cred.UserID, &cred.Username, &cred.PasswordHash, &cred.Role)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cred, nil
+}
+
+var _ auth.Store = (*DBManager)(nil)
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/content"
+)
+
+const stabilizedContentQuery = `
+	SELECT id, author_id, content_type, content_text, media_urls,
+		   parent_id, timestamp, signature, hash, processing_status, last_updated,
+		   truth_score, visibility_score, topics
+	FROM content
+	WHERE timestamp <= ? AND calibrated_at IS NULL
+	  AND (SELECT COUNT(*) FROM truth_consensus WHERE content_id = content.id) >= ?`
+
+// StabilizedContent implements content.CalibrationStore.
+func (m *DBManager) StabilizedContent(cutoff time.Time, minVotes int) ([]*models.Content, error) {
+	rows, err := m.db.Query(stabilizedContentQuery, cutoff.Unix(), minVotes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*models.Content
+	for rows.Next() {
+		var c models.Content
+		var timestamp, lastUpdated int64
+		var mediaURLs, topicsJSON string
+
+		if err := rows.Scan(
+			&c.ID, &c.AuthorID, &c.ContentType, &c.ContentText, &mediaURLs,
+			&c.ParentID, &timestamp, &c.Signature, &c.Hash,
+			&c.ProcessingStatus, &lastUpdated,
+			&c.TruthScore, &c.VisibilityScore, &topicsJSON,
+		); err != nil {
+			return nil, err
+		}
+
+		c.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		c.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := c.UnmarshalMediaURLs(mediaURLs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(topicsJSON), &c.Topics); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &c)
+	}
+
+	return out, rows.Err()
+}
+
+// MarkCalibrated implements content.CalibrationStore.
+func (m *DBManager) MarkCalibrated(contentID string) error {
+	_, err := m.writeDB.Exec(`UPDATE content SET calibrated_at = ? WHERE id = ?`, time.Now().Unix(), contentID)
+	return err
+}
+
+// SaveVoterCalibration implements content.CalibrationStore. It updates
+// the voter's DomainExpertise map in place rather than going through
+// UpdateUser's reputation_events ledger, since calibration is a derived
+// signal tracked per topic cluster, not an authoritative reputation
+// input that needs to be replayable.
+func (m *DBManager) SaveVoterCalibration(voterID, topicCluster string, info models.ExpertiseInfo) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		var domainExpertiseJSON string
+		if err := tx.QueryRow(`SELECT domain_expertise FROM users WHERE id = ?`, voterID).Scan(&domainExpertiseJSON); err != nil {
+			return err
+		}
+
+		expertise := map[string]models.ExpertiseInfo{}
+		if err := json.Unmarshal([]byte(domainExpertiseJSON), &expertise); err != nil {
+			return err
+		}
+		expertise[topicCluster] = info
+
+		updated, err := json.Marshal(expertise)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`UPDATE users SET domain_expertise = ? WHERE id = ?`, string(updated), voterID)
+		return err
+	})
+}
+
+var _ content.CalibrationStore = (*DBManager)(nil)
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/decisions"
+)
+
+func (m *DBManager) CreateDecision(d decisions.Decision) error {
+	_, err := m.writeDB.Exec(`
+		INSERT INTO decisions (id, scope, value, action, reason, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, d.ID, d.Scope, d.Value, d.Action, d.Reason, d.CreatedAt.Unix(), d.ExpiresAt.Unix())
+	return err
+}
+
+func (m *DBManager) DeleteDecision(id string) error {
+	_, err := m.writeDB.Exec(`DELETE FROM decisions WHERE id = ?`, id)
+	return err
+}
+
+func (m *DBManager) ListDecisions() ([]decisions.Decision, error) {
+	rows, err := m.db.Query(`
+		SELECT id, scope, value, action, reason, created_at, expires_at FROM decisions
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []decisions.Decision
+	for rows.Next() {
+		var d decisions.Decision
+		var createdAt, expiresAt int64
+		if err := rows.Scan(&d.ID, &d.Scope, &d.Value, &d.Action, &d.Reason, &createdAt, &expiresAt); err != nil {
+			return nil, err
+		}
+		d.CreatedAt = time.Unix(createdAt, 0)
+		d.ExpiresAt = time.Unix(expiresAt, 0)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (m *DBManager) DeleteExpiredDecisions(now int64) (int, error) {
+	result, err := m.writeDB.Exec(`DELETE FROM decisions WHERE expires_at <= ?`, now)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(affected), nil
+}
+
+var _ decisions.Store = (*DBManager)(nil)
@@ -0,0 +1,254 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/bandit"
+)
+
+// CreateExperiment inserts experiment and its arms, seeding each arm with
+// a uniform Beta(1, 1) prior if Alpha/Beta aren't already set.
+func (m *DBManager) CreateExperiment(experiment *models.Experiment, arms []*models.ExperimentArm) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO experiments (id, name, description, status, created_at)
+			VALUES (?, ?, ?, ?, ?)`,
+			experiment.ID, experiment.Name, experiment.Description, experiment.Status, experiment.CreatedAt.Unix(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create experiment: %v", err)
+		}
+
+		for _, arm := range arms {
+			if arm.Alpha == 0 {
+				arm.Alpha = 1
+			}
+			if arm.Beta == 0 {
+				arm.Beta = 1
+			}
+			arm.ExperimentID = experiment.ID
+
+			_, err := tx.Exec(`
+				INSERT INTO experiment_arms (id, experiment_id, name, alpha, beta)
+				VALUES (?, ?, ?, ?, ?)`,
+				arm.ID, arm.ExperimentID, arm.Name, arm.Alpha, arm.Beta,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create experiment arm %s: %v", arm.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// AssignArm returns the arm experimentName has assigned userID, assigning
+// one first if this is their first time in the experiment. Assignment is
+// sticky: once made, repeat calls always return the same arm.
+//
+// A brand-new experiment (no arm has collected any reward data yet) picks
+// via a deterministic hash of userID+experimentID rather than Thompson
+// sampling, so concurrent cold-start assignments split evenly instead of
+// every uninformative Beta(1,1) draw coinciding on the same arm.
+func (m *DBManager) AssignArm(experimentName, userID string) (*models.ExperimentArm, error) {
+	experimentID, err := m.experimentIDByName(experimentName)
+	if err != nil {
+		return nil, err
+	}
+
+	if arm, err := m.assignedArm(experimentID, userID); err != nil {
+		return nil, err
+	} else if arm != nil {
+		return arm, nil
+	}
+
+	arms, err := m.experimentArms(experimentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(arms) == 0 {
+		return nil, fmt.Errorf("experiment %q has no arms", experimentName)
+	}
+
+	var armID string
+	if experimentIsWarm(arms) {
+		armID = hashAssign(experimentID, userID, arms)
+	} else {
+		posteriors := make([]bandit.ArmPosterior, len(arms))
+		for i, arm := range arms {
+			posteriors[i] = bandit.ArmPosterior{ArmID: arm.ID, Alpha: arm.Alpha, Beta: arm.Beta}
+		}
+		armID = bandit.SelectArm(posteriors)
+	}
+
+	var chosen *models.ExperimentArm
+	for _, arm := range arms {
+		if arm.ID == armID {
+			chosen = arm
+			break
+		}
+	}
+
+	err = m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO experiment_assignments (id, experiment_id, user_id, arm_id, assigned_at)
+			VALUES (?, ?, ?, ?, ?)`,
+			uuid.New().String(), experimentID, userID, chosen.ID, time.Now().Unix(),
+		)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record experiment assignment: %v", err)
+	}
+
+	return chosen, nil
+}
+
+// RecordExperimentEvent folds a reward observation (expected in [0, 1])
+// into the posterior of the arm userID was assigned in experimentName.
+func (m *DBManager) RecordExperimentEvent(experimentName, userID string, reward float64) error {
+	experimentID, err := m.experimentIDByName(experimentName)
+	if err != nil {
+		return err
+	}
+
+	arm, err := m.assignedArm(experimentID, userID)
+	if err != nil {
+		return err
+	}
+	if arm == nil {
+		return fmt.Errorf("user %s has no assignment in experiment %q", userID, experimentName)
+	}
+
+	newAlpha, newBeta := bandit.UpdatePosterior(arm.Alpha, arm.Beta, reward)
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO experiment_events (id, experiment_id, arm_id, user_id, reward, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			uuid.New().String(), experimentID, arm.ID, userID, reward, time.Now().Unix(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record experiment event: %v", err)
+		}
+
+		_, err = tx.Exec(`
+			UPDATE experiment_arms SET alpha = ?, beta = ? WHERE id = ?
+		`, newAlpha, newBeta, arm.ID)
+		if err != nil {
+			return fmt.Errorf("failed to update arm posterior: %v", err)
+		}
+
+		return nil
+	})
+}
+
+// GetArmStats returns every arm of experimentName with its current
+// posterior, assignment count, and event count, feeding the dashboards
+// that watch algorithm_metrics.
+func (m *DBManager) GetArmStats(experimentName string) ([]*models.ArmStats, error) {
+	experimentID, err := m.experimentIDByName(experimentName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query(`
+		SELECT a.id, a.name, a.alpha, a.beta,
+			   (SELECT COUNT(*) FROM experiment_assignments WHERE arm_id = a.id) AS assignment_count,
+			   (SELECT COUNT(*) FROM experiment_events WHERE arm_id = a.id) AS event_count
+		FROM experiment_arms a
+		WHERE a.experiment_id = ?
+	`, experimentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query arm stats: %v", err)
+	}
+	defer rows.Close()
+
+	var stats []*models.ArmStats
+	for rows.Next() {
+		var s models.ArmStats
+		if err := rows.Scan(&s.ArmID, &s.ArmName, &s.Alpha, &s.Beta, &s.AssignmentCount, &s.EventCount); err != nil {
+			return nil, fmt.Errorf("failed to scan arm stats: %v", err)
+		}
+		s.Mean = s.Alpha / (s.Alpha + s.Beta)
+		stats = append(stats, &s)
+	}
+
+	return stats, rows.Err()
+}
+
+func (m *DBManager) experimentIDByName(name string) (string, error) {
+	var id string
+	err := m.db.QueryRow(`SELECT id FROM experiments WHERE name = ?`, name).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", fmt.Errorf("experiment %q not found", name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up experiment %q: %v", name, err)
+	}
+	return id, nil
+}
+
+func (m *DBManager) experimentArms(experimentID string) ([]*models.ExperimentArm, error) {
+	rows, err := m.db.Query(`
+		SELECT id, experiment_id, name, alpha, beta FROM experiment_arms WHERE experiment_id = ?
+	`, experimentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query experiment arms: %v", err)
+	}
+	defer rows.Close()
+
+	var arms []*models.ExperimentArm
+	for rows.Next() {
+		var a models.ExperimentArm
+		if err := rows.Scan(&a.ID, &a.ExperimentID, &a.Name, &a.Alpha, &a.Beta); err != nil {
+			return nil, fmt.Errorf("failed to scan experiment arm: %v", err)
+		}
+		arms = append(arms, &a)
+	}
+	return arms, rows.Err()
+}
+
+// assignedArm returns userID's existing arm assignment in experimentID,
+// or nil if they haven't been assigned one yet.
+func (m *DBManager) assignedArm(experimentID, userID string) (*models.ExperimentArm, error) {
+	var a models.ExperimentArm
+	err := m.db.QueryRow(`
+		SELECT a.id, a.experiment_id, a.name, a.alpha, a.beta
+		FROM experiment_assignments s
+		JOIN experiment_arms a ON a.id = s.arm_id
+		WHERE s.experiment_id = ? AND s.user_id = ?
+	`, experimentID, userID).Scan(&a.ID, &a.ExperimentID, &a.Name, &a.Alpha, &a.Beta)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up experiment assignment: %v", err)
+	}
+	return &a, nil
+}
+
+// experimentIsWarm reports whether every arm is still at its initial
+// uniform prior, i.e. no reward data has been collected yet.
+func experimentIsWarm(arms []*models.ExperimentArm) bool {
+	for _, arm := range arms {
+		if arm.Alpha != 1 || arm.Beta != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// hashAssign deterministically splits userID across arms, keyed by
+// experimentID so the same user lands on different arms in different
+// experiments.
+func hashAssign(experimentID, userID string, arms []*models.ExperimentArm) string {
+	h := fnv.New32a()
+	h.Write([]byte(experimentID + ":" + userID))
+	return arms[int(h.Sum32())%len(arms)].ID
+}
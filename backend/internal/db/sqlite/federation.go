@@ -0,0 +1,202 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saint/babel-protocol/backend/internal/federation"
+)
+
+// AddFollower records a remote actor following localActorID.
+func (m *DBManager) AddFollower(f federation.Follower) error {
+	id := f.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	_, err := m.db.Exec(`
+		INSERT INTO federation_followers (id, local_actor_id, remote_actor_id, remote_inbox, shared_secret, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (local_actor_id, remote_actor_id)
+		DO UPDATE SET remote_inbox = excluded.remote_inbox, shared_secret = excluded.shared_secret
+	`, id, f.LocalActorID, f.RemoteActorID, f.RemoteInbox, f.SharedSecret, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to add federation follower: %v", err)
+	}
+	return nil
+}
+
+// RemoveFollower removes a follow relationship.
+func (m *DBManager) RemoveFollower(localActorID, remoteActorID string) error {
+	_, err := m.db.Exec(`
+		DELETE FROM federation_followers WHERE local_actor_id = ? AND remote_actor_id = ?
+	`, localActorID, remoteActorID)
+	if err != nil {
+		return fmt.Errorf("failed to remove federation follower: %v", err)
+	}
+	return nil
+}
+
+// GetFollowers returns every remote actor following localActorID.
+func (m *DBManager) GetFollowers(localActorID string) ([]federation.Follower, error) {
+	rows, err := m.db.Query(`
+		SELECT id, local_actor_id, remote_actor_id, remote_inbox, shared_secret, created_at
+		FROM federation_followers WHERE local_actor_id = ?
+	`, localActorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query federation followers for %s: %v", localActorID, err)
+	}
+	defer rows.Close()
+
+	var followers []federation.Follower
+	for rows.Next() {
+		var f federation.Follower
+		var createdAt int64
+		if err := rows.Scan(&f.ID, &f.LocalActorID, &f.RemoteActorID, &f.RemoteInbox, &f.SharedSecret, &createdAt); err != nil {
+			return nil, err
+		}
+		f.CreatedAt = time.Unix(createdAt, 0)
+		followers = append(followers, f)
+	}
+	return followers, rows.Err()
+}
+
+// AddFollowing records localActorID following a remote actor, with the
+// trust weight to apply to votes replayed from it.
+func (m *DBManager) AddFollowing(f federation.Following) error {
+	id := f.ID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	trustWeight := f.TrustWeight
+	if trustWeight == 0 {
+		trustWeight = 1.0
+	}
+	_, err := m.db.Exec(`
+		INSERT INTO federation_following (id, local_actor_id, remote_actor_id, remote_inbox, shared_secret, trust_weight, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (local_actor_id, remote_actor_id)
+		DO UPDATE SET remote_inbox = excluded.remote_inbox, shared_secret = excluded.shared_secret, trust_weight = excluded.trust_weight
+	`, id, f.LocalActorID, f.RemoteActorID, f.RemoteInbox, f.SharedSecret, trustWeight, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("failed to add federation following: %v", err)
+	}
+	return nil
+}
+
+// GetFollowing returns the Following row for localActorID's relationship
+// with remoteActorID, or nil if localActorID doesn't follow them.
+func (m *DBManager) GetFollowing(localActorID, remoteActorID string) (*federation.Following, error) {
+	var f federation.Following
+	var createdAt int64
+	err := m.db.QueryRow(`
+		SELECT id, local_actor_id, remote_actor_id, remote_inbox, shared_secret, trust_weight, created_at
+		FROM federation_following WHERE local_actor_id = ? AND remote_actor_id = ?
+	`, localActorID, remoteActorID).Scan(&f.ID, &f.LocalActorID, &f.RemoteActorID, &f.RemoteInbox, &f.SharedSecret, &f.TrustWeight, &createdAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query federation following for %s/%s: %v", localActorID, remoteActorID, err)
+	}
+	f.CreatedAt = time.Unix(createdAt, 0)
+	return &f, nil
+}
+
+// EnqueueDelivery queues one federation_outbox row per entry in
+// deliveries, each carrying that follower's own signed payload.
+func (m *DBManager) EnqueueDelivery(activityID string, deliveries []federation.Delivery) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		now := time.Now()
+		for _, d := range deliveries {
+			_, err := tx.Exec(`
+				INSERT INTO federation_outbox (
+					id, follower_id, remote_inbox, activity_id, payload,
+					status, attempts, created_at, next_attempt_at
+				) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				uuid.New().String(), d.FollowerID, d.RemoteInbox, activityID, d.Payload,
+				federation.StatusPending, 0, now.Unix(), now.Unix(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to enqueue federation delivery to follower %s: %v", d.FollowerID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimPendingDeliveries returns up to limit pending outbox entries whose
+// next retry time has passed, oldest first.
+func (m *DBManager) ClaimPendingDeliveries(limit int) ([]*federation.OutboxEntry, error) {
+	rows, err := m.db.Query(`
+		SELECT id, follower_id, remote_inbox, activity_id, payload, status, attempts, created_at, next_attempt_at
+		FROM federation_outbox
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY created_at ASC
+		LIMIT ?
+	`, federation.StatusPending, time.Now().Unix(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending federation deliveries: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []*federation.OutboxEntry
+	for rows.Next() {
+		var e federation.OutboxEntry
+		var createdAt, nextAttemptAt int64
+		if err := rows.Scan(&e.ID, &e.FollowerID, &e.RemoteInbox, &e.ActivityID, &e.Payload,
+			&e.Status, &e.Attempts, &createdAt, &nextAttemptAt); err != nil {
+			return nil, fmt.Errorf("failed to scan federation outbox entry: %v", err)
+		}
+		e.CreatedAt = time.Unix(createdAt, 0)
+		e.NextAttemptAt = time.Unix(nextAttemptAt, 0)
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// MarkDelivered records that outbox entry id was accepted by its
+// follower's inbox.
+func (m *DBManager) MarkDelivered(id string) error {
+	_, err := m.db.Exec(`
+		UPDATE federation_outbox SET status = ?, delivered_at = ? WHERE id = ?
+	`, federation.StatusDelivered, time.Now().Unix(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark federation outbox entry %s delivered: %v", id, err)
+	}
+	return nil
+}
+
+// MarkDeliveryFailed records deliveryErr against outbox entry id and
+// schedules its next retry with exponential backoff. Entries stay
+// pending until they exceed the Worker's max attempts, at which point
+// they're marked failed for good.
+func (m *DBManager) MarkDeliveryFailed(id string, deliveryErr error) error {
+	var attempts int
+	if err := m.db.QueryRow(`SELECT attempts FROM federation_outbox WHERE id = ?`, id).Scan(&attempts); err != nil {
+		return fmt.Errorf("failed to read attempts for federation outbox entry %s: %v", id, err)
+	}
+	attempts++
+	nextAttemptAt := time.Now().Add(federation.Backoff(attempts))
+
+	status := federation.StatusPending
+	if attempts >= maxFederationAttempts {
+		status = federation.StatusFailed
+	}
+
+	_, err := m.db.Exec(`
+		UPDATE federation_outbox
+		SET attempts = ?, last_error = ?, status = ?, next_attempt_at = ?
+		WHERE id = ?
+	`, attempts, deliveryErr.Error(), status, nextAttemptAt.Unix(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark federation outbox entry %s failed: %v", id, err)
+	}
+	return nil
+}
+
+// maxFederationAttempts mirrors federation.maxDeliveryAttempts (unexported
+// in that package) so this backend gives up retrying at the same point
+// the Worker's own bookkeeping assumes.
+const maxFederationAttempts = 8
@@ -0,0 +1,155 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saint/babel-protocol/backend/internal/consensus/hcs"
+)
+
+// maxHCSAttempts bounds how many times the Worker retries submitting an
+// outbox entry before giving up on it and marking it failed for good.
+const maxHCSAttempts = 5
+
+// enqueueHCS builds a signed hcs.Message for record and inserts it into
+// hcs_outbox within tx, so the outbox entry can never diverge from the
+// write it mirrors. Call this as the last step inside the same
+// Transaction closure as the INSERT it accompanies.
+func (m *DBManager) enqueueHCS(tx *sql.Tx, recordType hcs.RecordType, recordID string, record interface{}) error {
+	var sequence int64
+	err := tx.QueryRow(`
+		SELECT COALESCE(MAX(sequence), 0) + 1 FROM hcs_outbox WHERE record_type = ?
+	`, string(recordType)).Scan(&sequence)
+	if err != nil {
+		return fmt.Errorf("failed to determine hcs sequence: %v", err)
+	}
+
+	_, contentHash, payload, err := hcs.BuildMessage(m.hcsNodeKey, recordType, recordID, record, sequence)
+	if err != nil {
+		return fmt.Errorf("failed to build hcs message: %v", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO hcs_outbox (
+			id, record_type, record_id, content_hash, payload,
+			sequence, status, attempts, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		uuid.New().String(), string(recordType), recordID, contentHash, payload,
+		sequence, hcs.StatusPending, 0, time.Now().Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue hcs outbox entry: %v", err)
+	}
+	return nil
+}
+
+// ClaimPendingOutboxEntries returns up to limit pending outbox entries,
+// oldest first, for the Worker to submit.
+func (m *DBManager) ClaimPendingOutboxEntries(limit int) ([]*hcs.OutboxEntry, error) {
+	rows, err := m.db.Query(`
+		SELECT id, record_type, record_id, content_hash, payload, sequence,
+			   status, attempts, created_at
+		FROM hcs_outbox
+		WHERE status = ?
+		ORDER BY created_at ASC
+		LIMIT ?
+	`, hcs.StatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pending hcs outbox entries: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []*hcs.OutboxEntry
+	for rows.Next() {
+		var e hcs.OutboxEntry
+		var recordType string
+		var createdAt int64
+		if err := rows.Scan(&e.ID, &recordType, &e.RecordID, &e.ContentHash, &e.Payload,
+			&e.Sequence, &e.Status, &e.Attempts, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan hcs outbox entry: %v", err)
+		}
+		e.RecordType = hcs.RecordType(recordType)
+		e.CreatedAt = time.Unix(createdAt, 0)
+		entries = append(entries, &e)
+	}
+
+	return entries, rows.Err()
+}
+
+// MarkOutboxSubmitted records that entry id reached HCS consensus at
+// hederaSeq/consensusTimestamp.
+func (m *DBManager) MarkOutboxSubmitted(id string, hederaSeq uint64, consensusTimestamp time.Time) error {
+	_, err := m.db.Exec(`
+		UPDATE hcs_outbox
+		SET status = ?, hedera_seq = ?, hedera_timestamp = ?, submitted_at = ?
+		WHERE id = ?
+	`, hcs.StatusSubmitted, hederaSeq, consensusTimestamp.Unix(), time.Now().Unix(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark hcs outbox entry %s submitted: %v", id, err)
+	}
+	return nil
+}
+
+// MarkOutboxFailed records submitErr against entry id. Entries stay
+// pending (so the Worker retries them) until they exceed
+// maxHCSAttempts, at which point they're marked failed for good.
+func (m *DBManager) MarkOutboxFailed(id string, submitErr error) error {
+	_, err := m.db.Exec(`
+		UPDATE hcs_outbox
+		SET attempts = attempts + 1,
+			last_error = ?,
+			status = CASE WHEN attempts + 1 >= ? THEN ? ELSE status END
+		WHERE id = ?
+	`, submitErr.Error(), maxHCSAttempts, hcs.StatusFailed, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark hcs outbox entry %s failed: %v", id, err)
+	}
+	return nil
+}
+
+// OutboxEntryForRecord returns the most recent outbox entry for
+// recordType/recordID, or nil if none has been enqueued.
+func (m *DBManager) OutboxEntryForRecord(recordType hcs.RecordType, recordID string) (*hcs.OutboxEntry, error) {
+	var e hcs.OutboxEntry
+	var status string
+	var hederaSeq sql.NullInt64
+	var hederaTimestamp sql.NullInt64
+	var createdAt int64
+	var submittedAt sql.NullInt64
+
+	err := m.db.QueryRow(`
+		SELECT id, record_id, content_hash, sequence, status,
+			   hedera_seq, hedera_timestamp, attempts, created_at, submitted_at
+		FROM hcs_outbox
+		WHERE record_type = ? AND record_id = ?
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, string(recordType), recordID).Scan(&e.ID, &e.RecordID, &e.ContentHash, &e.Sequence, &status,
+		&hederaSeq, &hederaTimestamp, &e.Attempts, &createdAt, &submittedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query hcs outbox entry for %s %s: %v", recordType, recordID, err)
+	}
+
+	e.RecordType = recordType
+	e.Status = status
+	e.CreatedAt = time.Unix(createdAt, 0)
+	if hederaSeq.Valid {
+		seq := uint64(hederaSeq.Int64)
+		e.HederaSeq = &seq
+	}
+	if hederaTimestamp.Valid {
+		ts := time.Unix(hederaTimestamp.Int64, 0)
+		e.HederaTimestamp = &ts
+	}
+	if submittedAt.Valid {
+		ts := time.Unix(submittedAt.Int64, 0)
+		e.SubmittedAt = &ts
+	}
+
+	return &e, nil
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// UpsertItemSimilarities replaces itemID's stored neighbor list with
+// neighbors in one transaction, so a background refresh never leaves a
+// partial row set visible to GetItemSimilarities.
+func (m *DBManager) UpsertItemSimilarities(itemID string, neighbors []db.ItemSimilarity) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM item_similarities WHERE item_id = ?`, itemID); err != nil {
+			return fmt.Errorf("failed to clear item similarities for %s: %v", itemID, err)
+		}
+
+		for _, n := range neighbors {
+			computedAt := n.ComputedAt
+			if computedAt.IsZero() {
+				computedAt = time.Now()
+			}
+			_, err := tx.Exec(`
+				INSERT INTO item_similarities (item_id, neighbor_id, similarity, computed_at)
+				VALUES (?, ?, ?, ?)`,
+				itemID, n.NeighborID, n.Similarity, computedAt.Unix(),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert item similarity %s->%s: %v", itemID, n.NeighborID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetItemSimilarities returns itemID's stored neighbors in similarity
+// descending order, as last written by UpsertItemSimilarities.
+func (m *DBManager) GetItemSimilarities(itemID string) ([]db.ItemSimilarity, error) {
+	rows, err := m.db.Query(`
+		SELECT neighbor_id, similarity, computed_at
+		FROM item_similarities
+		WHERE item_id = ?
+		ORDER BY similarity DESC`, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query item similarities for %s: %v", itemID, err)
+	}
+	defer rows.Close()
+
+	var neighbors []db.ItemSimilarity
+	for rows.Next() {
+		var n db.ItemSimilarity
+		var computedAt int64
+		if err := rows.Scan(&n.NeighborID, &n.Similarity, &computedAt); err != nil {
+			return nil, err
+		}
+		n.ComputedAt = time.Unix(computedAt, 0)
+		neighbors = append(neighbors, n)
+	}
+
+	return neighbors, rows.Err()
+}
@@ -1,66 +1,124 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
-	"sync"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
 )
 
-// DBManager handles database operations
+// pragmas is executed against every connection this manager opens. WAL lets
+// readers proceed concurrently with the single writer; synchronous=NORMAL
+// is safe under WAL (only a power-loss-during-checkpoint window is at risk,
+// not a crash); busy_timeout backstops any writer contention that still
+// makes it past the single-writer pool below.
+const pragmas = `
+	PRAGMA journal_mode=WAL;
+	PRAGMA synchronous=NORMAL;
+	PRAGMA busy_timeout=5000;
+	PRAGMA foreign_keys=ON;
+	PRAGMA temp_store=MEMORY;
+	PRAGMA mmap_size=268435456;
+`
+
+// DBManager handles database operations against a SQLite-backed store. It
+// implements db.Repository; construct one of the other backends under
+// backend/db (postgres, mongo) if you need a different dialect.
 type DBManager struct {
+	// db is the read pool: WAL allows any number of readers to run
+	// alongside the single writer below without blocking each other.
 	db *sql.DB
-	mu sync.Mutex
+
+	// writeDB is capped at a single open connection, so SQLite itself
+	// serializes writes - the same guarantee the old mu sync.Mutex gave,
+	// without forcing every write onto one Go-level lock.
+	writeDB *sql.DB
+
+	readStmts  *stmtCache
+	writeStmts *stmtCache
+
+	// hcsNodeKey signs hcs_outbox messages enqueued by this manager. Empty
+	// disables signing (enqueueHCS still writes rows; Verify will fail
+	// them on replay, which is only a concern once HCS is enabled).
+	hcsNodeKey string
 }
 
-// NewDBManager creates a new database manager
-func NewDBManager(dbPath string) (*DBManager, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+var _ db.Repository = (*DBManager)(nil)
+
+// NewDBManager creates a new database manager. hcsNodeKey signs the
+// Hedera Consensus Service outbox entries enqueued alongside every
+// content/vote/evidence write; pass "" if HCS mirroring is disabled.
+func NewDBManager(dbPath, hcsNodeKey string) (*DBManager, error) {
+	readPool, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, err
 	}
+	readPool.SetMaxOpenConns(25)
+	readPool.SetMaxIdleConns(5)
+	readPool.SetConnMaxLifetime(5 * time.Minute)
 
-	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	writePool, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		readPool.Close()
+		return nil, err
+	}
+	writePool.SetMaxOpenConns(1)
+	writePool.SetMaxIdleConns(1)
+
+	for _, conn := range []*sql.DB{readPool, writePool} {
+		if _, err := conn.Exec(pragmas); err != nil {
+			readPool.Close()
+			writePool.Close()
+			return nil, fmt.Errorf("failed to set pragmas: %v", err)
+		}
+	}
 
 	return &DBManager{
-		db: db,
+		db:         readPool,
+		writeDB:    writePool,
+		readStmts:  newStmtCache(readPool),
+		writeStmts: newStmtCache(writePool),
+		hcsNodeKey: hcsNodeKey,
 	}, nil
 }
 
-// UpdateContentBatch updates multiple content entries in a single transaction
+const updateContentBatchQuery = `
+	UPDATE content SET
+		truth_score = ?,
+		visibility_score = ?,
+		processing_status = ?,
+		last_updated = ?,
+		topics = ?,
+		entities = ?,
+		consensus_state = ?,
+		consensus_score = ?,
+		consensus_validator_count = ?,
+		consensus_temporal_weight = ?,
+		metadata = ?
+	WHERE id = ?
+`
+
+// UpdateContentBatch updates multiple content entries in a single
+// transaction, reusing a cached prepared statement for the per-row UPDATE
+// rather than re-preparing it on every call.
 func (m *DBManager) UpdateContentBatch(contents []*models.Content) error {
-	tx, err := m.db.Begin()
+	tx, err := m.writeDB.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %v", err)
 	}
 	defer tx.Rollback()
 
-	stmt, err := tx.Prepare(`
-		UPDATE content SET 
-			truth_score = ?,
-			visibility_score = ?,
-			processing_status = ?,
-			last_updated = ?,
-			topics = ?,
-			entities = ?,
-			consensus_state = ?,
-			consensus_score = ?,
-			consensus_validator_count = ?,
-			consensus_temporal_weight = ?,
-			metadata = ?
-		WHERE id = ?
-	`)
+	cached, err := m.writeStmts.prepare(updateContentBatchQuery)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %v", err)
 	}
+	stmt := tx.Stmt(cached)
 	defer stmt.Close()
 
 	for _, content := range contents {
@@ -105,19 +163,23 @@ func (m *DBManager) UpdateContentBatch(contents []*models.Content) error {
 	return nil
 }
 
-// Close closes the database connection
+// Close closes both the read and write connection pools.
 func (m *DBManager) Close() error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.readStmts.close()
+	m.writeStmts.close()
+
+	if err := m.writeDB.Close(); err != nil {
+		m.db.Close()
+		return err
+	}
 	return m.db.Close()
 }
 
-// Transaction executes a function within a database transaction
+// Transaction executes fn within a database transaction on the single
+// writer connection, so SQLite itself serializes writes across
+// concurrent callers instead of a Go-level mutex blocking them.
 func (m *DBManager) Transaction(fn func(*sql.Tx) error) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	tx, err := m.db.Begin()
+	tx, err := m.writeDB.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %v", err)
 	}
@@ -160,26 +222,30 @@ func stringToJSONArray(s string) ([]string, error) {
 	return arr, nil
 }
 
-// Maintenance performs routine database maintenance
+// Maintenance performs routine database maintenance: applying any pending
+// schema migrations, reclaiming/defragmenting space, refreshing the query
+// planner's statistics, and clearing expired cache entries. Everything
+// here runs against the single writer connection.
 func (m *DBManager) Maintenance() error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	if err := m.Migrate(context.Background()); err != nil {
+		return fmt.Errorf("failed to apply pending migrations: %v", err)
+	}
 
 	// Run VACUUM to reclaim space and defragment
-	_, err := m.db.Exec("VACUUM;")
+	_, err := m.writeDB.Exec("VACUUM;")
 	if err != nil {
 		return fmt.Errorf("failed to vacuum database: %v", err)
 	}
 
 	// Analyze tables for query optimization
-	_, err = m.db.Exec("ANALYZE;")
+	_, err = m.writeDB.Exec("ANALYZE;")
 	if err != nil {
 		return fmt.Errorf("failed to analyze database: %v", err)
 	}
 
 	// Clean expired cache entries
-	_, err = m.db.Exec(`
-		DELETE FROM algorithm_cache 
+	_, err = m.writeDB.Exec(`
+		DELETE FROM algorithm_cache
 		WHERE expiry < ?;
 	`, time.Now().Unix())
 	if err != nil {
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// newBenchManager opens a fresh on-disk DBManager under b's temp dir,
+// migrated to the current schema. WAL mode needs a real file, not
+// ":memory:", so each benchmark gets its own throwaway database.
+func newBenchManager(b *testing.B) *DBManager {
+	b.Helper()
+
+	mgr, err := NewDBManager(filepath.Join(b.TempDir(), "bench.db"), "")
+	if err != nil {
+		b.Fatalf("NewDBManager: %v", err)
+	}
+	b.Cleanup(func() {
+		mgr.db.Close()
+		mgr.writeDB.Close()
+	})
+
+	if err := mgr.Migrate(context.Background()); err != nil {
+		b.Fatalf("Migrate: %v", err)
+	}
+	return mgr
+}
+
+// BenchmarkMixedWriteThroughput drives the workload this request's WAL +
+// prepared-statement cache were meant to speed up: one comment write
+// followed by one vote write per iteration, the same pair the comment and
+// vote handlers issue back to back. b.N writes are inherently serialized
+// through the single-writer pool, same as production traffic would be;
+// compare ns/op against the pre-WAL, single-global-mutex Transaction this
+// request replaced (`git show <chunk5-6~1>:internal/db/sqlite/manager.go`)
+// to see the throughput gain.
+func BenchmarkMixedWriteThroughput(b *testing.B) {
+	mgr := newBenchManager(b)
+
+	author := &models.User{
+		ID: "bench-author", Username: "bench-author", PublicKey: "bench-key",
+		CreatedAt: time.Now(), LastActive: time.Now(),
+	}
+	if err := mgr.CreateUser(author); err != nil {
+		b.Fatalf("CreateUser: %v", err)
+	}
+
+	parent := &models.Content{
+		ID: "bench-parent", AuthorID: author.ID, ContentType: "post",
+		ContentText: "parent post", Timestamp: models.Now(),
+		ProcessingStatus: "processed", LastUpdated: time.Now(),
+	}
+	if err := mgr.CreateContent(parent); err != nil {
+		b.Fatalf("CreateContent parent: %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		comment := &models.Content{
+			ID: fmt.Sprintf("bench-comment-%d", i), AuthorID: author.ID,
+			ContentType: "comment", ContentText: "nice post", ParentID: &parent.ID,
+			Timestamp: models.Now(), ProcessingStatus: "processed", LastUpdated: time.Now(),
+		}
+		if err := mgr.CreateContent(comment); err != nil {
+			b.Fatalf("CreateContent comment %d: %v", i, err)
+		}
+		if err := mgr.RecordVote(parent.ID, author.ID, "affirm", 1.0, 3, nil); err != nil {
+			b.Fatalf("RecordVote %d: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/migrations"
+)
+
+const createSchemaMigrationsTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at INTEGER NOT NULL
+	)`
+
+// Migrate applies every embedded migration newer than the database's
+// current SchemaVersion, oldest first. Each migration runs in its own
+// BEGIN EXCLUSIVE transaction on a pinned connection - SQLite has no
+// advisory lock, so EXCLUSIVE is this backend's equivalent, serializing
+// concurrent migrators (e.g. two replicas starting up at once).
+func (m *DBManager) Migrate(ctx context.Context) error {
+	if _, err := m.writeDB.ExecContext(ctx, createSchemaMigrationsTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %v", err)
+	}
+
+	all, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+
+	current, err := m.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range all {
+		if mig.Version <= current {
+			continue
+		}
+		if err := m.runMigrationStep(ctx, mig.Up, func(conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, `
+				INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)
+			`, mig.Version, mig.Name, time.Now().Unix())
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to apply migration %d_%s: %v", mig.Version, mig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// MigrateDown reverses the single most recently applied migration.
+func (m *DBManager) MigrateDown(ctx context.Context) error {
+	current, err := m.SchemaVersion()
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		return nil
+	}
+
+	all, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range all {
+		if mig.Version != current {
+			continue
+		}
+		return m.runMigrationStep(ctx, mig.Down, func(conn *sql.Conn) error {
+			_, err := conn.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = ?`, mig.Version)
+			return err
+		})
+	}
+
+	return fmt.Errorf("migration %d not found among embedded migrations", current)
+}
+
+// SchemaVersion returns the highest applied migration version, or 0 if
+// the database has never been migrated.
+func (m *DBManager) SchemaVersion() (int, error) {
+	if _, err := m.writeDB.Exec(createSchemaMigrationsTable); err != nil {
+		return 0, fmt.Errorf("failed to create schema_migrations table: %v", err)
+	}
+
+	var version sql.NullInt64
+	if err := m.db.QueryRow(`SELECT MAX(version) FROM schema_migrations`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %v", err)
+	}
+	if !version.Valid {
+		return 0, nil
+	}
+	return int(version.Int64), nil
+}
+
+// runMigrationStep runs sqlStatements and recordVersion against the same
+// pinned connection inside one BEGIN EXCLUSIVE transaction.
+func (m *DBManager) runMigrationStep(ctx context.Context, sqlStatements string, recordVersion func(*sql.Conn) error) error {
+	conn, err := m.writeDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN EXCLUSIVE"); err != nil {
+		return fmt.Errorf("failed to begin exclusive transaction: %v", err)
+	}
+
+	if sqlStatements != "" {
+		if _, err := conn.ExecContext(ctx, sqlStatements); err != nil {
+			_, _ = conn.ExecContext(ctx, "ROLLBACK")
+			return fmt.Errorf("failed to run migration sql: %v", err)
+		}
+	}
+
+	if err := recordVersion(conn); err != nil {
+		_, _ = conn.ExecContext(ctx, "ROLLBACK")
+		return fmt.Errorf("failed to record migration version: %v", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		return fmt.Errorf("failed to commit migration: %v", err)
+	}
+
+	return nil
+}
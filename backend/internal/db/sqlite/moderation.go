@@ -0,0 +1,261 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saint/babel-protocol/backend/internal/moderation"
+)
+
+// CreateFlag persists a new content flag.
+func (m *DBManager) CreateFlag(flag moderation.ContentFlag) error {
+	evidenceJSON, err := json.Marshal(flag.Evidence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal flag evidence: %v", err)
+	}
+
+	_, err = m.db.Exec(`
+		INSERT INTO content_flags (id, content_id, reporter_id, category, evidence, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		flag.ID, flag.ContentID, flag.ReporterID, flag.Category, string(evidenceJSON), flag.Status, flag.CreatedAt.Unix(),
+	)
+	return err
+}
+
+// GetFlag returns a content flag by ID, or nil if it doesn't exist.
+func (m *DBManager) GetFlag(id string) (*moderation.ContentFlag, error) {
+	var flag moderation.ContentFlag
+	var evidenceJSON string
+	var createdAt int64
+
+	err := m.db.QueryRow(`
+		SELECT id, content_id, reporter_id, category, evidence, status, created_at
+		FROM content_flags WHERE id = ?`, id,
+	).Scan(&flag.ID, &flag.ContentID, &flag.ReporterID, &flag.Category, &evidenceJSON, &flag.Status, &createdAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	flag.CreatedAt = time.Unix(createdAt, 0)
+	if err := json.Unmarshal([]byte(evidenceJSON), &flag.Evidence); err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
+
+// CandidateJurors returns up to limit users, excluding excludeIDs,
+// ordered by ReputationScore descending.
+func (m *DBManager) CandidateJurors(excludeIDs []string, limit int) ([]moderation.JurorCandidate, error) {
+	query := `SELECT id, reputation_score FROM users`
+	args := make([]interface{}, 0, len(excludeIDs)+1)
+	if len(excludeIDs) > 0 {
+		query += fmt.Sprintf(` WHERE id NOT IN (%s)`, placeholders(len(excludeIDs)))
+		for _, id := range excludeIDs {
+			args = append(args, id)
+		}
+	}
+	query += ` ORDER BY reputation_score DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []moderation.JurorCandidate
+	for rows.Next() {
+		var c moderation.JurorCandidate
+		if err := rows.Scan(&c.UserID, &c.ReputationScore); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// CreateJury persists a new jury.
+func (m *DBManager) CreateJury(jury moderation.Jury) error {
+	_, err := m.db.Exec(`
+		INSERT INTO moderation_juries (
+			id, content_id, flag_id, size, quorum, status, is_appeal, parent_jury_id, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		jury.ID, jury.ContentID, jury.FlagID, jury.Size, jury.Quorum, jury.Status,
+		jury.IsAppeal, nullableString(jury.ParentJuryID), jury.CreatedAt.Unix(),
+	)
+	return err
+}
+
+// GetJury returns a jury by ID, or nil if it doesn't exist.
+func (m *DBManager) GetJury(id string) (*moderation.Jury, error) {
+	var jury moderation.Jury
+	var parentJuryID sql.NullString
+	var createdAt int64
+	var resolvedAt sql.NullInt64
+
+	err := m.db.QueryRow(`
+		SELECT id, content_id, flag_id, size, quorum, status, is_appeal, parent_jury_id, created_at, resolved_at
+		FROM moderation_juries WHERE id = ?`, id,
+	).Scan(&jury.ID, &jury.ContentID, &jury.FlagID, &jury.Size, &jury.Quorum, &jury.Status,
+		&jury.IsAppeal, &parentJuryID, &createdAt, &resolvedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	jury.ParentJuryID = parentJuryID.String
+	jury.CreatedAt = time.Unix(createdAt, 0)
+	if resolvedAt.Valid {
+		t := time.Unix(resolvedAt.Int64, 0)
+		jury.ResolvedAt = &t
+	}
+	return &jury, nil
+}
+
+// AddJuryMembers invites each jurorID onto juryID in a single transaction.
+func (m *DBManager) AddJuryMembers(juryID string, jurorIDs []string) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		now := time.Now().Unix()
+		for _, jurorID := range jurorIDs {
+			if _, err := tx.Exec(`
+				INSERT INTO moderation_jury_members (id, jury_id, juror_id, invited_at)
+				VALUES (?, ?, ?, ?)`,
+				uuid.New().String(), juryID, jurorID, now,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// IsJuryMember reports whether jurorID was invited onto juryID.
+func (m *DBManager) IsJuryMember(juryID, jurorID string) (bool, error) {
+	var exists int
+	err := m.db.QueryRow(`
+		SELECT 1 FROM moderation_jury_members WHERE jury_id = ? AND juror_id = ?`,
+		juryID, jurorID,
+	).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// MarkJurorVoted records that jurorID cast their moderation vote on
+// juryID at votedAt.
+func (m *DBManager) MarkJurorVoted(juryID, jurorID string, votedAt time.Time) error {
+	_, err := m.db.Exec(`
+		UPDATE moderation_jury_members SET voted_at = ?
+		WHERE jury_id = ? AND juror_id = ?`,
+		votedAt.Unix(), juryID, jurorID,
+	)
+	return err
+}
+
+// JuryMembers returns every juror invited onto juryID.
+func (m *DBManager) JuryMembers(juryID string) ([]moderation.JuryMember, error) {
+	rows, err := m.db.Query(`
+		SELECT id, jury_id, juror_id, invited_at, voted_at
+		FROM moderation_jury_members WHERE jury_id = ?`, juryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []moderation.JuryMember
+	for rows.Next() {
+		var mem moderation.JuryMember
+		var invitedAt int64
+		var votedAt sql.NullInt64
+		if err := rows.Scan(&mem.ID, &mem.JuryID, &mem.JurorID, &invitedAt, &votedAt); err != nil {
+			return nil, err
+		}
+		mem.InvitedAt = time.Unix(invitedAt, 0)
+		if votedAt.Valid {
+			t := time.Unix(votedAt.Int64, 0)
+			mem.VotedAt = &t
+		}
+		members = append(members, mem)
+	}
+	return members, rows.Err()
+}
+
+// GetJuryVotes returns juryID's jurors' moderation votes on contentID
+// cast at or after since, tallied from vote_events - the append-only
+// ledger - rather than truth_consensus, which only keeps one row per
+// (content_id, voter_id) regardless of vote type. Later events for the
+// same juror overwrite earlier ones, so each juror's latest vote wins.
+func (m *DBManager) GetJuryVotes(juryID, contentID string, since time.Time) ([]moderation.JurorVote, error) {
+	rows, err := m.db.Query(`
+		SELECT voter_id, vote_weight, ts
+		FROM vote_events
+		WHERE content_id = ? AND vote_type = ? AND ts >= ?
+		  AND voter_id IN (SELECT juror_id FROM moderation_jury_members WHERE jury_id = ?)
+		ORDER BY ts ASC`,
+		contentID, "moderation", since.Unix(), juryID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	latest := make(map[string]moderation.JurorVote)
+	for rows.Next() {
+		var jurorID string
+		var weight float64
+		var ts int64
+		if err := rows.Scan(&jurorID, &weight, &ts); err != nil {
+			return nil, err
+		}
+		latest[jurorID] = moderation.JurorVote{JurorID: jurorID, Weight: weight, Timestamp: time.Unix(ts, 0)}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	votes := make([]moderation.JurorVote, 0, len(latest))
+	for _, v := range latest {
+		votes = append(votes, v)
+	}
+	return votes, nil
+}
+
+// ResolveJury marks juryID resolved and persists verdict in a single
+// transaction.
+func (m *DBManager) ResolveJury(juryID string, verdict moderation.Verdict) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`
+			UPDATE moderation_juries SET status = ?, resolved_at = ? WHERE id = ?`,
+			moderation.JuryStatusResolved, verdict.ResolvedAt.Unix(), juryID,
+		); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(`
+			INSERT INTO moderation_verdicts (
+				id, jury_id, content_id, outcome, votes_for, votes_against, visibility_score, resolved_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			verdict.ID, verdict.JuryID, verdict.ContentID, verdict.Outcome,
+			verdict.VotesFor, verdict.VotesAgainst, verdict.VisibilityScore, verdict.ResolvedAt.Unix(),
+		)
+		return err
+	})
+}
+
+// nullableString returns a sql.NullString that's valid only when s is
+// non-empty, for optional TEXT columns like parent_jury_id.
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -4,9 +4,12 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/consensus/hcs"
+	"github.com/saint/babel-protocol/backend/internal/db"
 )
 
 // Content Operations
@@ -27,19 +30,29 @@ func (m *DBManager) CreateContent(content *models.Content) error {
 			content.ParentID, content.Timestamp.Unix(), content.Signature, content.Hash,
 			content.ProcessingStatus, content.LastUpdated.Unix(),
 		)
-		return err
+		if err != nil {
+			return err
+		}
+		return m.enqueueHCS(tx, hcs.RecordTypeContent, content.ID, content)
 	})
 }
 
+const getContentQuery = `
+	SELECT id, author_id, content_type, content_text, media_urls,
+		   parent_id, timestamp, signature, hash, processing_status, last_updated
+	FROM content WHERE id = ?`
+
 func (m *DBManager) GetContent(id string) (*models.Content, error) {
 	var content models.Content
 	var timestamp, lastUpdated int64
 	var mediaURLs string
 
-	err := m.db.QueryRow(`
-		SELECT id, author_id, content_type, content_text, media_urls,
-			   parent_id, timestamp, signature, hash, processing_status, last_updated
-		FROM content WHERE id = ?`, id).Scan(
+	stmt, err := m.readStmts.prepare(getContentQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %v", err)
+	}
+
+	err = stmt.QueryRow(id).Scan(
 		&content.ID, &content.AuthorID, &content.ContentType, &content.ContentText, &mediaURLs,
 		&content.ParentID, &timestamp, &content.Signature, &content.Hash,
 		&content.ProcessingStatus, &lastUpdated,
@@ -51,7 +64,7 @@ func (m *DBManager) GetContent(id string) (*models.Content, error) {
 		return nil, err
 	}
 
-	content.Timestamp = time.Unix(timestamp, 0)
+	content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
 	content.LastUpdated = time.Unix(lastUpdated, 0)
 	if err := content.UnmarshalMediaURLs(mediaURLs); err != nil {
 		return nil, err
@@ -118,14 +131,16 @@ func (m *DBManager) CreateUser(user *models.User) error {
 func (m *DBManager) GetUser(id string) (*models.User, error) {
 	var user models.User
 	var createdAt, lastActive int64
-	var sessionData string
+	var sessionData, domainExpertise string
 
 	err := m.db.QueryRow(`
 		SELECT id, public_key, username, created_at,
-			   authenticity_score, reputation_score, last_active, session_data
+			   authenticity_score, reputation_score, last_active, session_data,
+			   domain_expertise
 		FROM users WHERE id = ?`, id).Scan(
 		&user.ID, &user.PublicKey, &user.Username, &createdAt,
 		&user.AuthenticityScore, &user.ReputationScore, &lastActive, &sessionData,
+		&domainExpertise,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -139,6 +154,9 @@ func (m *DBManager) GetUser(id string) (*models.User, error) {
 	if err := user.UnmarshalSessionData(sessionData); err != nil {
 		return nil, err
 	}
+	if err := json.Unmarshal([]byte(domainExpertise), &user.DomainExpertise); err != nil {
+		return nil, err
+	}
 
 	return &user, nil
 }
@@ -184,49 +202,52 @@ func (m *DBManager) GetCache(key string) (string, error) {
 
 // Consensus Operations
 
+// RecordVote appends an immutable vote event and replays it into the
+// truth_consensus projection, rather than overwriting truth_consensus
+// directly - a voter flipping their vote leaves the prior vote in
+// vote_events instead of destroying it. See ReplayVoteEvents to rebuild
+// the projection from scratch.
 func (m *DBManager) RecordVote(contentID, voterID, voteType string, voteWeight float64, certaintyLevel int, evidenceIDs []string) error {
-	evidenceIDsJSON, err := json.Marshal(evidenceIDs)
-	if err != nil {
-		return fmt.Errorf("failed to marshal evidence IDs: %v", err)
-	}
-
 	return m.Transaction(func(tx *sql.Tx) error {
-		_, err := tx.Exec(`
-			INSERT OR REPLACE INTO truth_consensus (
-				content_id, voter_id, vote_type, vote_weight,
-				certainty_level, evidence_ids, timestamp, last_updated
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-			contentID, voterID, voteType, voteWeight,
-			certaintyLevel, string(evidenceIDsJSON), time.Now().Unix(), time.Now().Unix(),
-		)
-		return err
+		if _, err := m.appendVoteEvent(tx, contentID, voterID, voteType, voteWeight, certaintyLevel, evidenceIDs); err != nil {
+			return fmt.Errorf("failed to append vote event: %v", err)
+		}
+		if err := m.replayVoteEvents(tx, contentID); err != nil {
+			return fmt.Errorf("failed to replay vote events: %v", err)
+		}
+
+		vote := &db.VoteInfo{
+			ContentID:      contentID,
+			VoterID:        voterID,
+			VoteType:       voteType,
+			VoteWeight:     voteWeight,
+			CertaintyLevel: certaintyLevel,
+			EvidenceIDs:    evidenceIDs,
+		}
+		return m.enqueueHCS(tx, hcs.RecordTypeVote, hcs.VoteRecordID(contentID, voterID), vote)
 	})
 }
 
-type VoteInfo struct {
-	ContentID      string    `json:"content_id"`
-	VoterID        string    `json:"voter_id"`
-	VoteType       string    `json:"vote_type"`
-	VoteWeight     float64   `json:"vote_weight"`
-	CertaintyLevel int       `json:"certainty_level"`
-	EvidenceIDs    []string  `json:"evidence_ids"`
-	Timestamp      time.Time `json:"timestamp"`
-	LastUpdated    time.Time `json:"last_updated"`
-}
+const getContentVotesQuery = `
+	SELECT content_id, voter_id, vote_type, vote_weight,
+		   certainty_level, evidence_ids, timestamp, last_updated
+	FROM truth_consensus WHERE content_id = ?`
 
-func (m *DBManager) GetContentVotes(contentID string) ([]*VoteInfo, error) {
-	rows, err := m.db.Query(`
-		SELECT content_id, voter_id, vote_type, vote_weight,
-			   certainty_level, evidence_ids, timestamp, last_updated
-		FROM truth_consensus WHERE content_id = ?`, contentID)
+func (m *DBManager) GetContentVotes(contentID string) ([]*db.VoteInfo, error) {
+	stmt, err := m.readStmts.prepare(getContentVotesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare statement: %v", err)
+	}
+
+	rows, err := stmt.Query(contentID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var votes []*VoteInfo
+	var votes []*db.VoteInfo
 	for rows.Next() {
-		var vote VoteInfo
+		var vote db.VoteInfo
 		var timestamp, lastUpdated int64
 		var evidenceIDsJSON string
 
@@ -339,7 +360,7 @@ func (m *DBManager) GetUserVotes(userID string, since time.Time) ([]*models.Vote
 			return nil, err
 		}
 
-		vote.Timestamp = time.Unix(timestamp, 0)
+		vote.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
 		vote.LastUpdated = time.Unix(lastUpdated, 0)
 		if err := json.Unmarshal([]byte(evidenceIDsJSON), &vote.EvidenceIDs); err != nil {
 			return nil, err
@@ -381,7 +402,7 @@ func (m *DBManager) GetUserEvidence(userID string, since time.Time) ([]*models.E
 			return nil, err
 		}
 
-		evidence.Timestamp = time.Unix(timestamp, 0)
+		evidence.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
 		if err := json.Unmarshal([]byte(contextDataJSON), &evidence.ContextData); err != nil {
 			return nil, err
 		}
@@ -428,7 +449,71 @@ func (m *DBManager) GetUserContent(userID string, since time.Time) ([]*models.Co
 			return nil, err
 		}
 
-		content.Timestamp = time.Unix(timestamp, 0)
+		content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		content.LastUpdated = time.Unix(lastUpdated, 0)
+
+		if err := json.Unmarshal([]byte(mediaURLsJSON), &content.MediaURLs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &content.Metadata); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(evidenceChainsJSON), &content.EvidenceChains); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(topicsJSON), &content.Topics); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(entitiesJSON), &content.Entities); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(contextRefsJSON), &content.ContextRefs); err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+// GetRecentContent returns every content item across all authors created
+// or updated since the given time, for callers (the item-based CF
+// recommender) that need a corpus-wide view rather than one user's.
+func (m *DBManager) GetRecentContent(since time.Time) ([]*models.Content, error) {
+	rows, err := m.db.Query(`
+		SELECT id, author_id, content_type, content_text, media_urls,
+			   parent_id, timestamp, signature, hash, processing_status,
+			   last_updated, metadata, truth_score, visibility_score,
+			   evidence_chains, topics, entities, context_refs
+		FROM content
+		WHERE timestamp >= ?`,
+		since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		var timestamp, lastUpdated int64
+		var mediaURLsJSON, metadataJSON, evidenceChainsJSON string
+		var topicsJSON, entitiesJSON, contextRefsJSON string
+
+		err := rows.Scan(
+			&content.ID, &content.AuthorID, &content.ContentType,
+			&content.ContentText, &mediaURLsJSON, &content.ParentID,
+			&timestamp, &content.Signature, &content.Hash,
+			&content.ProcessingStatus, &lastUpdated, &metadataJSON,
+			&content.TruthScore, &content.VisibilityScore,
+			&evidenceChainsJSON, &topicsJSON, &entitiesJSON, &contextRefsJSON,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
 		content.LastUpdated = time.Unix(lastUpdated, 0)
 
 		if err := json.Unmarshal([]byte(mediaURLsJSON), &content.MediaURLs); err != nil {
@@ -456,6 +541,13 @@ func (m *DBManager) GetUserContent(userID string, since time.Time) ([]*models.Co
 	return contents, rows.Err()
 }
 
+// UpdateUser persists the non-reputation fields on user directly, and
+// appends a reputation event for the scoring fields (AuthenticityScore,
+// ReputationScore, TruthAccuracy, EvidenceQuality, EngagementQuality,
+// CommunityScore, VerificationLevel) rather than writing them as
+// authoritative state - they're a projection replayReputationEvents
+// derives from reputation_events. See RebuildAllProjections to re-derive
+// them for every user from scratch.
 func (m *DBManager) UpdateUser(user *models.User) error {
 	sessionData, err := user.MarshalSessionData()
 	if err != nil {
@@ -465,34 +557,27 @@ func (m *DBManager) UpdateUser(user *models.User) error {
 	return m.Transaction(func(tx *sql.Tx) error {
 		_, err := tx.Exec(`
 			UPDATE users SET
-				authenticity_score = ?,
-				reputation_score = ?,
-				truth_accuracy = ?,
-				evidence_quality = ?,
-				engagement_quality = ?,
-				community_score = ?,
 				last_active = ?,
 				session_data = ?,
 				stake_amount = ?,
 				stake_locked_until = ?,
-				verification_level = ?,
 				total_contributions = ?
 			WHERE id = ?`,
-			user.AuthenticityScore,
-			user.ReputationScore,
-			user.TruthAccuracy,
-			user.EvidenceQuality,
-			user.EngagementQuality,
-			user.CommunityScore,
 			user.LastActive.Unix(),
 			sessionData,
 			user.StakeAmount,
 			user.StakeLockedUntil,
-			user.VerificationLevel,
 			user.TotalContributions,
 			user.ID,
 		)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if _, err := m.appendReputationEvent(tx, user); err != nil {
+			return fmt.Errorf("failed to append reputation event: %v", err)
+		}
+		return m.replayReputationEvents(tx, user.ID)
 	})
 }
 
@@ -606,7 +691,10 @@ func (m *DBManager) CreateEvidence(evidence *models.Evidence) error {
 			evidence.Timestamp.Unix(), evidence.LastUpdated.Unix(),
 			string(metadata),
 		)
-		return err
+		if err != nil {
+			return err
+		}
+		return m.enqueueHCS(tx, hcs.RecordTypeEvidence, evidence.ID, evidence)
 	})
 }
 
@@ -633,7 +721,7 @@ func (m *DBManager) GetEvidence(id string) (*models.Evidence, error) {
 		return nil, err
 	}
 
-	evidence.Timestamp = time.Unix(timestamp, 0)
+	evidence.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
 	evidence.LastUpdated = time.Unix(lastUpdated, 0)
 
 	if err := json.Unmarshal([]byte(refsJSON), &evidence.References); err != nil {
@@ -676,7 +764,7 @@ func (m *DBManager) GetContentEvidence(contentID string) ([]*models.Evidence, er
 			return nil, err
 		}
 
-		e.Timestamp = time.Unix(timestamp, 0)
+		e.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
 		e.LastUpdated = time.Unix(lastUpdated, 0)
 
 		if err := json.Unmarshal([]byte(refsJSON), &e.References); err != nil {
@@ -725,6 +813,462 @@ func (m *DBManager) UpdateEvidence(evidence *models.Evidence) error {
 	})
 }
 
+// Content Bundle Operations
+
+// GetContentBundle loads a single feed-ready view of content - the content
+// itself, its author, an aggregated vote tally, its top evidence by
+// quality score, and viewerID's own vote on it, if any - in one query.
+// viewerID may be empty if there is no authenticated viewer.
+func (m *DBManager) GetContentBundle(contentID, viewerID string) (*db.ContentBundle, error) {
+	bundles, err := m.GetContentBundles([]string{contentID}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, nil
+	}
+	return bundles[0], nil
+}
+
+// GetContentBundles is the batched form of GetContentBundle: following the
+// pattern of status-go's communitiesBaseQuery, it LEFT JOINs content against
+// users, an aggregated vote tally, the viewer's own vote, and a
+// quality-ranked evidence window so a feed of any page size costs a single
+// query instead of four round trips per item.
+func (m *DBManager) GetContentBundles(ids []string, viewerID string) ([]*db.ContentBundle, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, viewerID)
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+
+	query := fmt.Sprintf(`
+		WITH tally AS (
+			SELECT content_id,
+				SUM(CASE WHEN vote_type = 'upvote' THEN 1 ELSE 0 END) AS upvotes,
+				SUM(CASE WHEN vote_type = 'downvote' THEN 1 ELSE 0 END) AS downvotes,
+				SUM(CASE WHEN vote_type = 'affirm' THEN 1 ELSE 0 END) AS affirms,
+				SUM(CASE WHEN vote_type = 'deny' THEN 1 ELSE 0 END) AS denies,
+				SUM(CASE WHEN vote_type = 'engage' THEN 1 ELSE 0 END) AS engages,
+				SUM(CASE WHEN vote_type = 'unengage' THEN 1 ELSE 0 END) AS unengages,
+				COUNT(*) AS total
+			FROM truth_consensus
+			GROUP BY content_id
+		),
+		ranked_evidence AS (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY content_id ORDER BY quality_score DESC) AS rn
+			FROM evidence
+		)
+		SELECT
+			c.id, c.author_id, c.content_type, c.content_text, c.media_urls, c.parent_id,
+			c.timestamp, c.signature, c.hash, c.processing_status, c.last_updated,
+			u.id, u.username, u.authenticity_score, u.reputation_score,
+			COALESCE(t.upvotes, 0), COALESCE(t.downvotes, 0), COALESCE(t.affirms, 0),
+			COALESCE(t.denies, 0), COALESCE(t.engages, 0), COALESCE(t.unengages, 0), COALESCE(t.total, 0),
+			vv.voter_id, vv.vote_type, vv.vote_weight, vv.certainty_level, vv.evidence_ids, vv.timestamp, vv.last_updated,
+			e.id, e.submitter_id, e.content_author_id, e.evidence_text, e.references, e.quality_score, e.timestamp, e.last_updated
+		FROM content c
+		LEFT JOIN users u ON u.id = c.author_id
+		LEFT JOIN tally t ON t.content_id = c.id
+		LEFT JOIN truth_consensus vv ON vv.content_id = c.id AND vv.voter_id = ?
+		LEFT JOIN ranked_evidence e ON e.content_id = c.id AND e.rn <= %d
+		WHERE c.id IN (%s)
+	`, db.BundleEvidenceLimit, strings.Join(placeholders, ", "))
+
+	rows, err := m.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content bundles: %v", err)
+	}
+	defer rows.Close()
+
+	order := make([]string, 0, len(ids))
+	byID := make(map[string]*db.ContentBundle, len(ids))
+
+	for rows.Next() {
+		var (
+			id, authorID, contentType, contentText, mediaURLsJSON string
+			parentID                                              sql.NullString
+			timestamp, lastUpdated                                int64
+			signature, hash, processingStatus                     string
+
+			authorRowID              sql.NullString
+			username                 sql.NullString
+			authenticity, reputation sql.NullFloat64
+
+			upvotes, downvotes, affirms, denies, engages, unengages, total int
+
+			viewerVoterID                      sql.NullString
+			viewerVoteType                     sql.NullString
+			viewerVoteWeight                   sql.NullFloat64
+			viewerCertainty                    sql.NullInt64
+			viewerEvidenceIDsJSON              sql.NullString
+			viewerTimestamp, viewerLastUpdated sql.NullInt64
+
+			evID, evSubmitterID, evContentAuthorID, evText, evRefsJSON sql.NullString
+			evQuality                                                  sql.NullFloat64
+			evTimestamp, evLastUpdated                                 sql.NullInt64
+		)
+
+		err := rows.Scan(
+			&id, &authorID, &contentType, &contentText, &mediaURLsJSON, &parentID,
+			&timestamp, &signature, &hash, &processingStatus, &lastUpdated,
+			&authorRowID, &username, &authenticity, &reputation,
+			&upvotes, &downvotes, &affirms, &denies, &engages, &unengages, &total,
+			&viewerVoterID, &viewerVoteType, &viewerVoteWeight, &viewerCertainty, &viewerEvidenceIDsJSON, &viewerTimestamp, &viewerLastUpdated,
+			&evID, &evSubmitterID, &evContentAuthorID, &evText, &evRefsJSON, &evQuality, &evTimestamp, &evLastUpdated,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan content bundle row: %v", err)
+		}
+
+		bundle, ok := byID[id]
+		if !ok {
+			content := &models.Content{
+				ID:               id,
+				AuthorID:         authorID,
+				ContentType:      contentType,
+				ContentText:      contentText,
+				Timestamp:        models.NewBabelTime(time.Unix(timestamp, 0)),
+				Signature:        signature,
+				Hash:             hash,
+				ProcessingStatus: processingStatus,
+				LastUpdated:      time.Unix(lastUpdated, 0),
+			}
+			if parentID.Valid {
+				content.ParentID = &parentID.String
+			}
+			if err := json.Unmarshal([]byte(mediaURLsJSON), &content.MediaURLs); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal media URLs: %v", err)
+			}
+
+			bundle = &db.ContentBundle{
+				Content: content,
+				VoteTally: db.VoteTally{
+					Upvotes:   upvotes,
+					Downvotes: downvotes,
+					Affirms:   affirms,
+					Denies:    denies,
+					Engages:   engages,
+					Unengages: unengages,
+					Total:     total,
+				},
+			}
+			if authorRowID.Valid {
+				bundle.Author = &db.AuthorSummary{
+					ID:                authorRowID.String,
+					Username:          username.String,
+					AuthenticityScore: authenticity.Float64,
+					ReputationScore:   reputation.Float64,
+				}
+			}
+			if viewerVoterID.Valid {
+				vote := &db.VoteInfo{
+					ContentID:      id,
+					VoterID:        viewerVoterID.String,
+					VoteType:       viewerVoteType.String,
+					VoteWeight:     viewerVoteWeight.Float64,
+					CertaintyLevel: int(viewerCertainty.Int64),
+					Timestamp:      time.Unix(viewerTimestamp.Int64, 0),
+					LastUpdated:    time.Unix(viewerLastUpdated.Int64, 0),
+				}
+				if viewerEvidenceIDsJSON.Valid {
+					if err := json.Unmarshal([]byte(viewerEvidenceIDsJSON.String), &vote.EvidenceIDs); err != nil {
+						return nil, fmt.Errorf("failed to unmarshal viewer vote evidence IDs: %v", err)
+					}
+				}
+				bundle.ViewerVote = vote
+			}
+
+			byID[id] = bundle
+			order = append(order, id)
+		}
+
+		if evID.Valid {
+			ev := &models.Evidence{
+				ID:              evID.String,
+				ContentID:       id,
+				SubmitterID:     evSubmitterID.String,
+				ContentAuthorID: evContentAuthorID.String,
+				EvidenceText:    evText.String,
+				QualityScore:    evQuality.Float64,
+				Timestamp:       models.NewBabelTime(time.Unix(evTimestamp.Int64, 0)),
+				LastUpdated:     time.Unix(evLastUpdated.Int64, 0),
+			}
+			if evRefsJSON.Valid {
+				if err := json.Unmarshal([]byte(evRefsJSON.String), &ev.References); err != nil {
+					return nil, fmt.Errorf("failed to unmarshal evidence references: %v", err)
+				}
+			}
+			bundle.TopEvidence = append(bundle.TopEvidence, ev)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	bundles := make([]*db.ContentBundle, 0, len(order))
+	for _, id := range order {
+		bundles = append(bundles, byID[id])
+	}
+	return bundles, nil
+}
+
+// placeholders returns n "?" placeholders joined for an IN (...) clause.
+func placeholders(n int) string {
+	ph := make([]string, n)
+	for i := range ph {
+		ph[i] = "?"
+	}
+	return strings.Join(ph, ", ")
+}
+
+// Bulk-by-key Operations
+//
+// These back the internal/loaders batching layer: each collects a request's
+// worth of keys into a single WHERE id IN (...) query instead of one round
+// trip per key.
+
+func (m *DBManager) GetUsersByIDs(ids []string) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, public_key, username, created_at,
+			   authenticity_score, reputation_score, last_active, session_data
+		FROM users WHERE id IN (%s)`, placeholders(len(ids))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		var user models.User
+		var createdAt, lastActive int64
+		var sessionData string
+
+		if err := rows.Scan(
+			&user.ID, &user.PublicKey, &user.Username, &createdAt,
+			&user.AuthenticityScore, &user.ReputationScore, &lastActive, &sessionData,
+		); err != nil {
+			return nil, err
+		}
+
+		user.CreatedAt = time.Unix(createdAt, 0)
+		user.LastActive = time.Unix(lastActive, 0)
+		if err := user.UnmarshalSessionData(sessionData); err != nil {
+			return nil, err
+		}
+
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+func (m *DBManager) GetContentByIDs(ids []string) ([]*models.Content, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, author_id, content_type, content_text, media_urls,
+			   parent_id, timestamp, signature, hash, processing_status, last_updated
+		FROM content WHERE id IN (%s)`, placeholders(len(ids))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*models.Content
+	for rows.Next() {
+		var content models.Content
+		var timestamp, lastUpdated int64
+		var mediaURLs string
+
+		if err := rows.Scan(
+			&content.ID, &content.AuthorID, &content.ContentType, &content.ContentText, &mediaURLs,
+			&content.ParentID, &timestamp, &content.Signature, &content.Hash,
+			&content.ProcessingStatus, &lastUpdated,
+		); err != nil {
+			return nil, err
+		}
+
+		content.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		content.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := content.UnmarshalMediaURLs(mediaURLs); err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+func (m *DBManager) GetContentVotesByContentIDs(contentIDs []string) (map[string][]*db.VoteInfo, error) {
+	if len(contentIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(contentIDs))
+	for i, id := range contentIDs {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT content_id, voter_id, vote_type, vote_weight,
+			   certainty_level, evidence_ids, timestamp, last_updated
+		FROM truth_consensus WHERE content_id IN (%s)`, placeholders(len(contentIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := make(map[string][]*db.VoteInfo, len(contentIDs))
+	for rows.Next() {
+		var vote db.VoteInfo
+		var timestamp, lastUpdated int64
+		var evidenceIDsJSON string
+
+		if err := rows.Scan(
+			&vote.ContentID, &vote.VoterID, &vote.VoteType, &vote.VoteWeight,
+			&vote.CertaintyLevel, &evidenceIDsJSON, &timestamp, &lastUpdated,
+		); err != nil {
+			return nil, err
+		}
+
+		vote.Timestamp = time.Unix(timestamp, 0)
+		vote.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := json.Unmarshal([]byte(evidenceIDsJSON), &vote.EvidenceIDs); err != nil {
+			return nil, err
+		}
+
+		votes[vote.ContentID] = append(votes[vote.ContentID], &vote)
+	}
+
+	return votes, rows.Err()
+}
+
+func (m *DBManager) GetContentEvidenceByContentIDs(contentIDs []string) (map[string][]*models.Evidence, error) {
+	if len(contentIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(contentIDs))
+	for i, id := range contentIDs {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, content_id, submitter_id, content_author_id,
+			   evidence_text, references, quality_score,
+			   timestamp, last_updated, metadata
+		FROM evidence WHERE content_id IN (%s)
+		ORDER BY quality_score DESC`, placeholders(len(contentIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	evidence := make(map[string][]*models.Evidence, len(contentIDs))
+	for rows.Next() {
+		var e models.Evidence
+		var refsJSON, metadataJSON string
+		var timestamp, lastUpdated int64
+
+		if err := rows.Scan(
+			&e.ID, &e.ContentID, &e.SubmitterID,
+			&e.ContentAuthorID, &e.EvidenceText,
+			&refsJSON, &e.QualityScore,
+			&timestamp, &lastUpdated, &metadataJSON,
+		); err != nil {
+			return nil, err
+		}
+
+		e.Timestamp = models.NewBabelTime(time.Unix(timestamp, 0))
+		e.LastUpdated = time.Unix(lastUpdated, 0)
+		if err := json.Unmarshal([]byte(refsJSON), &e.References); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal references: %v", err)
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &e.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+		}
+
+		evidence[e.ContentID] = append(evidence[e.ContentID], &e)
+	}
+
+	return evidence, rows.Err()
+}
+
+func (m *DBManager) GetUserVerificationsByUserIDs(userIDs []string) (map[string][]*models.UserVerification, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		args[i] = id
+	}
+
+	rows, err := m.db.Query(fmt.Sprintf(`
+		SELECT id, user_id, verification_type, status, verified_at,
+			   verifier_id, proof_data, metadata
+		FROM user_verification WHERE user_id IN (%s)`, placeholders(len(userIDs))), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	verifications := make(map[string][]*models.UserVerification, len(userIDs))
+	for rows.Next() {
+		var verification models.UserVerification
+		var verifiedAt sql.NullInt64
+		var proofDataJSON, metadataJSON string
+
+		if err := rows.Scan(
+			&verification.ID, &verification.UserID,
+			&verification.VerificationType, &verification.Status,
+			&verifiedAt, &verification.VerifierID,
+			&proofDataJSON, &metadataJSON,
+		); err != nil {
+			return nil, err
+		}
+
+		if verifiedAt.Valid {
+			t := time.Unix(verifiedAt.Int64, 0)
+			verification.VerifiedAt = &t
+		}
+		if err := json.Unmarshal([]byte(proofDataJSON), &verification.ProofData); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal proof data: %v", err)
+		}
+		if err := json.Unmarshal([]byte(metadataJSON), &verification.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %v", err)
+		}
+
+		verifications[verification.UserID] = append(verifications[verification.UserID], &verification)
+	}
+
+	return verifications, rows.Err()
+}
+
 // Direct Message Operations
 
 func (m *DBManager) CreateDirectMessage(message *models.DirectMessage) error {
@@ -739,3 +1283,153 @@ func (m *DBManager) CreateDirectMessage(message *models.DirectMessage) error {
 		return err
 	})
 }
+
+// Event Subscriber Operations
+
+func (m *DBManager) CreateEventSubscriber(sub *models.EventSubscriber) error {
+	topics, err := jsonArrayToString(sub.Topics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal topics: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO event_subscribers (
+				id, owner_id, secret, topics, delivery_url, transport,
+				active, created_at, last_updated
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			sub.ID, sub.OwnerID, sub.Secret, topics, sub.DeliveryURL, sub.Transport,
+			sub.Active, sub.CreatedAt.Unix(), sub.LastUpdated.Unix(),
+		)
+		return err
+	})
+}
+
+func (m *DBManager) GetEventSubscriber(id string) (*models.EventSubscriber, error) {
+	var sub models.EventSubscriber
+	var topics string
+	var createdAt, lastUpdated int64
+
+	err := m.db.QueryRow(`
+		SELECT id, owner_id, secret, topics, delivery_url, transport,
+			   active, created_at, last_updated
+		FROM event_subscribers WHERE id = ?`, id).Scan(
+		&sub.ID, &sub.OwnerID, &sub.Secret, &topics, &sub.DeliveryURL, &sub.Transport,
+		&sub.Active, &createdAt, &lastUpdated,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sub.CreatedAt = time.Unix(createdAt, 0)
+	sub.LastUpdated = time.Unix(lastUpdated, 0)
+	if sub.Topics, err = stringToJSONArray(topics); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (m *DBManager) ListEventSubscribers() ([]*models.EventSubscriber, error) {
+	rows, err := m.db.Query(`
+		SELECT id, owner_id, secret, topics, delivery_url, transport,
+			   active, created_at, last_updated
+		FROM event_subscribers WHERE active = 1`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event subscribers: %v", err)
+	}
+	defer rows.Close()
+
+	var subs []*models.EventSubscriber
+	for rows.Next() {
+		var sub models.EventSubscriber
+		var topics string
+		var createdAt, lastUpdated int64
+
+		if err := rows.Scan(
+			&sub.ID, &sub.OwnerID, &sub.Secret, &topics, &sub.DeliveryURL, &sub.Transport,
+			&sub.Active, &createdAt, &lastUpdated,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan event subscriber: %v", err)
+		}
+
+		sub.CreatedAt = time.Unix(createdAt, 0)
+		sub.LastUpdated = time.Unix(lastUpdated, 0)
+		if sub.Topics, err = stringToJSONArray(topics); err != nil {
+			return nil, err
+		}
+
+		subs = append(subs, &sub)
+	}
+
+	return subs, nil
+}
+
+func (m *DBManager) UpdateEventSubscriber(sub *models.EventSubscriber) error {
+	topics, err := jsonArrayToString(sub.Topics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal topics: %v", err)
+	}
+
+	return m.Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			UPDATE event_subscribers SET
+				secret = ?, topics = ?, delivery_url = ?, transport = ?,
+				active = ?, last_updated = ?
+			WHERE id = ?`,
+			sub.Secret, topics, sub.DeliveryURL, sub.Transport,
+			sub.Active, time.Now().Unix(), sub.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("event subscriber not found")
+		}
+
+		return nil
+	})
+}
+
+func (m *DBManager) DeleteEventSubscriber(id string) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM event_subscribers WHERE id = ?`, id)
+		if err != nil {
+			return err
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return fmt.Errorf("event subscriber not found")
+		}
+
+		return nil
+	})
+}
+
+// LogDeadLetterEvent persists a failed delivery for operator inspection and
+// replay once its retries are exhausted.
+func (m *DBManager) LogDeadLetterEvent(event *models.DeadLetterEvent) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			INSERT INTO event_dead_letters (
+				id, subscriber_id, event_type, payload, attempts,
+				last_error, first_attempt, last_attempt
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			event.ID, event.SubscriberID, event.EventType, event.Payload, event.Attempts,
+			event.LastError, event.FirstAttempt.Unix(), event.LastAttempt.Unix(),
+		)
+		return err
+	})
+}
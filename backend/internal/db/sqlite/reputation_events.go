@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// reputation_events is the append-only ledger backing a user's scoring
+// fields (AuthenticityScore, ReputationScore, TruthAccuracy, EvidenceQuality,
+// EngagementQuality, CommunityScore, VerificationLevel); the users table
+// columns for those fields are a projection rebuilt by replayReputationEvents,
+// the same pattern vote_events/truth_consensus uses.
+
+const projectionReputation = "reputation"
+
+// appendReputationEvent records user's current scoring fields as the next
+// immutable reputation event; it does not itself update the users row.
+func (m *DBManager) appendReputationEvent(tx *sql.Tx, user *models.User) (string, error) {
+	prevHash, err := m.lastEventHash(tx, "reputation_events", "user_id", user.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load prior reputation event: %v", err)
+	}
+
+	eventID := uuid.New().String()
+	ts := time.Now().Unix()
+	signature := signEvent(m.hcsNodeKey, eventID, user.ID,
+		fmt.Sprintf("%f", user.AuthenticityScore), fmt.Sprintf("%f", user.ReputationScore),
+		fmt.Sprintf("%f", user.TruthAccuracy), fmt.Sprintf("%f", user.EvidenceQuality),
+		fmt.Sprintf("%f", user.EngagementQuality), fmt.Sprintf("%f", user.CommunityScore),
+		fmt.Sprintf("%d", user.VerificationLevel), fmt.Sprintf("%d", ts), prevHash)
+
+	_, err = tx.Exec(`
+		INSERT INTO reputation_events (
+			event_id, user_id, authenticity_score, reputation_score, truth_accuracy,
+			evidence_quality, engagement_quality, community_score, verification_level,
+			ts, prev_event_hash, signature
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		eventID, user.ID, user.AuthenticityScore, user.ReputationScore, user.TruthAccuracy,
+		user.EvidenceQuality, user.EngagementQuality, user.CommunityScore, user.VerificationLevel,
+		ts, prevHash, signature,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return eventID, nil
+}
+
+// replayReputationEvents rebuilds userID's reputation columns on the users
+// table from the latest event after the projection's checkpoint.
+func (m *DBManager) replayReputationEvents(tx *sql.Tx, userID string) error {
+	checkpoint, err := m.checkpoint(tx, projectionReputation, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT event_id, authenticity_score, reputation_score, truth_accuracy,
+			   evidence_quality, engagement_quality, community_score, verification_level, ts
+		FROM reputation_events
+		WHERE user_id = ? AND ts >= ?
+		ORDER BY ts ASC, event_id ASC`, userID, checkpoint)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		eventID                                                                     string
+		authenticity, reputation, truthAccuracy, evidenceQuality, engagementQuality float64
+		communityScore                                                              float64
+		verificationLevel                                                           int
+		ts                                                                          int64
+		found                                                                       bool
+	)
+	for rows.Next() {
+		found = true
+		if err := rows.Scan(&eventID, &authenticity, &reputation, &truthAccuracy,
+			&evidenceQuality, &engagementQuality, &communityScore, &verificationLevel, &ts); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !found {
+		return nil
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE users SET
+			authenticity_score = ?,
+			reputation_score = ?,
+			truth_accuracy = ?,
+			evidence_quality = ?,
+			engagement_quality = ?,
+			community_score = ?,
+			verification_level = ?
+		WHERE id = ?`,
+		authenticity, reputation, truthAccuracy, evidenceQuality, engagementQuality, communityScore, verificationLevel, userID,
+	); err != nil {
+		return err
+	}
+
+	return m.setCheckpoint(tx, projectionReputation, userID, eventID, ts)
+}
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// stmtCache caches prepared statements against a single *sql.DB pool, keyed
+// by exact query text, so hot paths (GetContent, GetContentVotes, the
+// per-row UPDATE in UpdateContentBatch) compile their query plan once
+// instead of re-preparing it on every call. It's scoped to one pool (the
+// read pool or the write pool) because a *sql.Stmt prepared against one
+// *sql.DB can't run against connections from another.
+type stmtCache struct {
+	db *sql.DB
+
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+}
+
+func newStmtCache(db *sql.DB) *stmtCache {
+	return &stmtCache{db: db, stmts: make(map[string]*sql.Stmt)}
+}
+
+// prepare returns the cached *sql.Stmt for query, preparing and caching it
+// on first use.
+func (c *stmtCache) prepare(query string) (*sql.Stmt, error) {
+	c.mu.RLock()
+	stmt, ok := c.stmts[query]
+	c.mu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stmt, ok := c.stmts[query]; ok {
+		return stmt, nil
+	}
+
+	stmt, err := c.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	c.stmts[query] = stmt
+	return stmt, nil
+}
+
+// close closes every cached statement. Call once, when the owning
+// DBManager is closed.
+func (c *stmtCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var firstErr error
+	for query, stmt := range c.stmts {
+		if err := stmt.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(c.stmts, query)
+	}
+	return firstErr
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/user"
+)
+
+func (m *DBManager) CreateScoreOverride(o user.ScoreOverride) error {
+	scoresJSON, err := json.Marshal(o.Scores)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.writeDB.Exec(`
+		INSERT INTO score_overrides (id, user_id, moderator_id, reason, field, scores, reputation_score, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, o.ID, o.UserID, o.ModeratorID, o.Reason, o.Field, string(scoresJSON), o.ReputationScore, o.CreatedAt.Unix())
+	return err
+}
+
+func (m *DBManager) ListScoreOverrides(userID string) ([]user.ScoreOverride, error) {
+	rows, err := m.db.Query(`
+		SELECT id, user_id, moderator_id, reason, field, scores, reputation_score, created_at
+		FROM score_overrides WHERE user_id = ? ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []user.ScoreOverride
+	for rows.Next() {
+		var o user.ScoreOverride
+		var scoresJSON string
+		var createdAt int64
+		if err := rows.Scan(&o.ID, &o.UserID, &o.ModeratorID, &o.Reason, &o.Field, &scoresJSON, &o.ReputationScore, &createdAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(scoresJSON), &o.Scores); err != nil {
+			return nil, err
+		}
+		o.CreatedAt = time.Unix(createdAt, 0)
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// ListMetrics queries algorithm_metrics by algorithmName only - it does
+// not filter by the caller's user ID in SQL, since doing so would mean
+// relying on this build of go-sqlite3 having the JSON1 extension compiled
+// in for json_extract. user.Manager.Audit instead decodes Metadata here
+// and filters by user_id in Go, which is fine for a low-traffic admin
+// endpoint.
+func (m *DBManager) ListMetrics(algorithmName string, limit, offset int) ([]models.AlgorithmMetric, error) {
+	rows, err := m.db.Query(`
+		SELECT metric_name, value, timestamp, metadata FROM algorithm_metrics
+		WHERE algorithm_name = ?
+		ORDER BY timestamp DESC
+		LIMIT ? OFFSET ?
+	`, algorithmName, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []models.AlgorithmMetric
+	for rows.Next() {
+		var metric models.AlgorithmMetric
+		var timestamp int64
+		var metadataJSON string
+		if err := rows.Scan(&metric.MetricName, &metric.Value, &timestamp, &metadataJSON); err != nil {
+			return nil, err
+		}
+		metric.AlgorithmName = algorithmName
+		metric.Timestamp = time.Unix(timestamp, 0)
+		if metadataJSON != "" {
+			if err := json.Unmarshal([]byte(metadataJSON), &metric.Metadata); err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, metric)
+	}
+	return out, rows.Err()
+}
+
+var _ user.Store = (*DBManager)(nil)
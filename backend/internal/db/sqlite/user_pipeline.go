@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/user"
+)
+
+func (m *DBManager) EnqueuePendingScoreUpdate(p user.PendingScoreUpdate) error {
+	_, err := m.writeDB.Exec(`
+		INSERT INTO pending_score_updates (id, user_id, cause, at)
+		VALUES (?, ?, ?, ?)
+	`, p.ID, p.UserID, p.Cause, p.At.Unix())
+	return err
+}
+
+func (m *DBManager) ListPendingScoreUpdates() ([]user.PendingScoreUpdate, error) {
+	rows, err := m.db.Query(`SELECT id, user_id, cause, at FROM pending_score_updates ORDER BY at ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []user.PendingScoreUpdate
+	for rows.Next() {
+		var p user.PendingScoreUpdate
+		var at int64
+		if err := rows.Scan(&p.ID, &p.UserID, &p.Cause, &at); err != nil {
+			return nil, err
+		}
+		p.At = time.Unix(at, 0)
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func (m *DBManager) DeletePendingScoreUpdate(id string) error {
+	_, err := m.writeDB.Exec(`DELETE FROM pending_score_updates WHERE id = ?`, id)
+	return err
+}
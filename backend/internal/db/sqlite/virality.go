@@ -0,0 +1,45 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/saint/babel-protocol/backend/internal/virality"
+)
+
+// GetContentDescendants walks id's reply/context-post tree via a
+// recursive CTE over content's parent_id column, and unions in every vote
+// cast on id itself or any of those descendants, restricted to events at
+// or after since. It backs internal/virality.Calculator's descendant-tree
+// sum.
+func (m *DBManager) GetContentDescendants(id string, since time.Time) ([]virality.DescendantEvent, error) {
+	rows, err := m.db.Query(`
+		WITH RECURSIVE descendants(id) AS (
+			SELECT id FROM content WHERE parent_id = ?
+			UNION ALL
+			SELECT c.id FROM content c JOIN descendants d ON c.parent_id = d.id
+		)
+		SELECT id, author_id, timestamp, 'content' AS event_type
+		FROM content
+		WHERE id IN (SELECT id FROM descendants) AND timestamp >= ?
+		UNION ALL
+		SELECT content_id, voter_id, ts, 'vote' AS event_type
+		FROM vote_events
+		WHERE content_id IN (SELECT id FROM descendants UNION SELECT ?) AND ts >= ?
+	`, id, since.Unix(), id, since.Unix())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []virality.DescendantEvent
+	for rows.Next() {
+		var e virality.DescendantEvent
+		var ts int64
+		if err := rows.Scan(&e.ContentID, &e.UserID, &ts, &e.EventType); err != nil {
+			return nil, err
+		}
+		e.Timestamp = time.Unix(ts, 0)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,243 @@
+package sqlite
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// vote_events is the append-only ledger RecordVote writes to; truth_consensus
+// is a materialized projection rebuilt from it by replayVoteEvents, so a
+// voter flipping their vote never destroys the history of what they voted
+// before. Each event chains to the previous one for its content_id via
+// prev_event_hash/signature, the same HMAC-chaining pattern hcs.Message uses
+// for its own audit trail, just keyed by hcsNodeKey instead of submitted to
+// a Hedera topic.
+
+const projectionVote = "vote"
+
+// appendVoteEvent inserts the next immutable vote event for contentID and
+// returns it; it does not touch the truth_consensus projection.
+func (m *DBManager) appendVoteEvent(tx *sql.Tx, contentID, voterID, voteType string, voteWeight float64, certaintyLevel int, evidenceIDs []string) (string, error) {
+	evidenceIDsJSON, err := json.Marshal(evidenceIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal evidence IDs: %v", err)
+	}
+
+	prevHash, err := m.lastEventHash(tx, "vote_events", "content_id", contentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load prior vote event: %v", err)
+	}
+
+	eventID := uuid.New().String()
+	ts := time.Now().Unix()
+	signature := signEvent(m.hcsNodeKey, eventID, contentID, voterID, voteType,
+		fmt.Sprintf("%f", voteWeight), fmt.Sprintf("%d", certaintyLevel), string(evidenceIDsJSON),
+		fmt.Sprintf("%d", ts), prevHash)
+
+	_, err = tx.Exec(`
+		INSERT INTO vote_events (
+			event_id, content_id, voter_id, vote_type, vote_weight,
+			certainty_level, evidence_ids, ts, prev_event_hash, signature
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		eventID, contentID, voterID, voteType, voteWeight,
+		certaintyLevel, string(evidenceIDsJSON), ts, prevHash, signature,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return eventID, nil
+}
+
+// replayVoteEvents rebuilds the truth_consensus row for every voter on
+// contentID from vote_events, applying only events after the projection's
+// checkpoint so a routine RecordVote only replays the one new event. Later
+// events for the same (content_id, voter_id) overwrite earlier ones, same
+// as the INSERT OR REPLACE this projection used to be written by directly.
+func (m *DBManager) replayVoteEvents(tx *sql.Tx, contentID string) error {
+	checkpoint, err := m.checkpoint(tx, projectionVote, contentID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(`
+		SELECT event_id, voter_id, vote_type, vote_weight, certainty_level, evidence_ids, ts
+		FROM vote_events
+		WHERE content_id = ? AND ts >= ?
+		ORDER BY ts ASC, event_id ASC`, contentID, checkpoint)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var lastEventID string
+	var lastEventTS int64
+	for rows.Next() {
+		var eventID, voterID, voteType, evidenceIDsJSON string
+		var voteWeight float64
+		var certaintyLevel int
+		var ts int64
+
+		if err := rows.Scan(&eventID, &voterID, &voteType, &voteWeight, &certaintyLevel, &evidenceIDsJSON, &ts); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(`
+			INSERT OR REPLACE INTO truth_consensus (
+				content_id, voter_id, vote_type, vote_weight,
+				certainty_level, evidence_ids, timestamp, last_updated
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			contentID, voterID, voteType, voteWeight, certaintyLevel, evidenceIDsJSON, ts, time.Now().Unix(),
+		)
+		if err != nil {
+			return err
+		}
+
+		lastEventID, lastEventTS = eventID, ts
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if lastEventID == "" {
+		return nil
+	}
+	return m.setCheckpoint(tx, projectionVote, contentID, lastEventID, lastEventTS)
+}
+
+// ReplayVoteEvents rebuilds the truth_consensus projection for contentID
+// from scratch from vote_events, ignoring any checkpoint. It's idempotent -
+// safe to run repeatedly - and is the mechanism for verifiable re-scoring
+// after a dispute, or after fixing a bug in projection logic.
+func (m *DBManager) ReplayVoteEvents(contentID string) error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		if err := m.clearCheckpoint(tx, projectionVote, contentID); err != nil {
+			return err
+		}
+		return m.replayVoteEvents(tx, contentID)
+	})
+}
+
+// RebuildAllProjections replays every content_id's vote events and every
+// user_id's reputation events from scratch, ignoring checkpoints. It's a
+// maintenance operation - run it after a projection bug fix, or to verify
+// truth_consensus/users still agree with the event log.
+func (m *DBManager) RebuildAllProjections() error {
+	return m.Transaction(func(tx *sql.Tx) error {
+		contentIDs, err := distinctValues(tx, "vote_events", "content_id")
+		if err != nil {
+			return err
+		}
+		for _, contentID := range contentIDs {
+			if err := m.clearCheckpoint(tx, projectionVote, contentID); err != nil {
+				return err
+			}
+			if err := m.replayVoteEvents(tx, contentID); err != nil {
+				return fmt.Errorf("failed to replay vote events for %s: %v", contentID, err)
+			}
+		}
+
+		userIDs, err := distinctValues(tx, "reputation_events", "user_id")
+		if err != nil {
+			return err
+		}
+		for _, userID := range userIDs {
+			if err := m.clearCheckpoint(tx, projectionReputation, userID); err != nil {
+				return err
+			}
+			if err := m.replayReputationEvents(tx, userID); err != nil {
+				return fmt.Errorf("failed to replay reputation events for %s: %v", userID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// distinctValues returns every distinct value of column in table - used by
+// RebuildAllProjections to enumerate every content_id/user_id that has
+// events, without the caller needing to know them up front.
+func distinctValues(tx *sql.Tx, table, column string) ([]string, error) {
+	rows, err := tx.Query(fmt.Sprintf(`SELECT DISTINCT %s FROM %s`, column, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// lastEventHash returns the signature of the most recent event in table
+// scoped by keyColumn = key, or "" if this is the first event - the
+// genesis link in that key's hash chain.
+func (m *DBManager) lastEventHash(tx *sql.Tx, table, keyColumn, key string) (string, error) {
+	query := fmt.Sprintf(`
+		SELECT signature FROM %s WHERE %s = ? ORDER BY ts DESC, event_id DESC LIMIT 1`, table, keyColumn)
+	var signature string
+	err := tx.QueryRow(query, key).Scan(&signature)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return signature, nil
+}
+
+// signEvent computes the hex-encoded HMAC-SHA256, keyed by nodeKey, of an
+// event's fields in order - the same chaining approach hcs.Message uses,
+// just local to this projection's audit trail rather than submitted to HCS.
+func signEvent(nodeKey string, fields ...string) string {
+	mac := hmac.New(sha256.New, []byte(nodeKey))
+	for _, field := range fields {
+		mac.Write([]byte(field))
+		mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkpoint returns the last-applied event timestamp for projection/key,
+// or 0 if no checkpoint exists yet (replay from the beginning of history).
+func (m *DBManager) checkpoint(tx *sql.Tx, projection, key string) (int64, error) {
+	var lastEventTS int64
+	err := tx.QueryRow(`
+		SELECT last_event_ts FROM projection_checkpoints WHERE projection = ? AND projection_key = ?`,
+		projection, key,
+	).Scan(&lastEventTS)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return lastEventTS, nil
+}
+
+func (m *DBManager) setCheckpoint(tx *sql.Tx, projection, key, lastEventID string, lastEventTS int64) error {
+	_, err := tx.Exec(`
+		INSERT OR REPLACE INTO projection_checkpoints (projection, projection_key, last_event_id, last_event_ts)
+		VALUES (?, ?, ?, ?)`,
+		projection, key, lastEventID, lastEventTS,
+	)
+	return err
+}
+
+func (m *DBManager) clearCheckpoint(tx *sql.Tx, projection, key string) error {
+	_, err := tx.Exec(`DELETE FROM projection_checkpoints WHERE projection = ? AND projection_key = ?`, projection, key)
+	return err
+}
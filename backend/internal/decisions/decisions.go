@@ -0,0 +1,52 @@
+// Package decisions implements a CrowdSec-LAPI-style decision feed: a
+// small, TTL-indexed set of {scope, value, action} bans/throttles/captcha
+// challenges that api/middleware consults ahead of the token-bucket rate
+// limiter, fed either by operators (via the admin HTTP endpoints) or by
+// other subsystems (e.g. user.Manager, when a user's AuthenticityScore
+// drops) through the DecisionFeeder interface.
+package decisions
+
+import "time"
+
+// Scopes a Decision can target.
+const (
+	ScopeIP   = "ip"
+	ScopeUser = "user"
+	ScopeASN  = "asn"
+)
+
+// Actions a Decision can carry.
+const (
+	ActionBan      = "ban"
+	ActionCaptcha  = "captcha"
+	ActionThrottle = "throttle"
+)
+
+// ThrottleFraction is the fraction of a caller's normal rate-limit bucket
+// a "throttle" Decision leaves them - i.e. the token bucket's effective
+// rps/burst are multiplied by this before being checked.
+const ThrottleFraction = 0.1
+
+// Decision is one entry in the feed: value (an IP, user ID, or ASN,
+// depending on Scope) is subject to Action until ExpiresAt.
+type Decision struct {
+	ID        string    `json:"id"`
+	Scope     string    `json:"scope"`
+	Value     string    `json:"value"`
+	Action    string    `json:"action"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Expired reports whether d is no longer in effect as of now.
+func (d Decision) Expired(now time.Time) bool {
+	return !d.ExpiresAt.IsZero() && !d.ExpiresAt.After(now)
+}
+
+// DecisionFeeder lets other subsystems push a decision onto the feed
+// without importing the rest of this package - moderation tooling and
+// user.Manager both depend on it rather than on *Service directly.
+type DecisionFeeder interface {
+	Feed(scope, value, action, reason string, ttl time.Duration) (Decision, error)
+}
@@ -0,0 +1,205 @@
+package decisions
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// reapInterval is how often the background reaper sweeps expired
+// decisions out of both Store and the in-memory index.
+const reapInterval = time.Minute
+
+// Service is the decision feed: an in-memory TTL-indexed cache backed by
+// Store for durability, so every check (on the hot request path) is a map
+// lookup rather than a database round trip.
+type Service struct {
+	repo  db.Repository
+	store Store
+
+	mu    sync.RWMutex
+	index map[string]map[string]Decision // scope -> value -> latest Decision
+
+	stop chan struct{}
+}
+
+var _ DecisionFeeder = (*Service)(nil)
+
+// NewService builds a Service over repo/store and loads every
+// not-yet-expired decision already in store into the in-memory index.
+// Call Run to start the background reaper.
+func NewService(repo db.Repository, store Store) (*Service, error) {
+	s := &Service{
+		repo:  repo,
+		store: store,
+		index: make(map[string]map[string]Decision),
+		stop:  make(chan struct{}),
+	}
+
+	existing, err := store.ListDecisions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing decisions: %v", err)
+	}
+	now := time.Now()
+	for _, d := range existing {
+		if !d.Expired(now) {
+			s.set(d)
+		}
+	}
+
+	return s, nil
+}
+
+// Run starts the background reaper that evicts expired decisions from
+// both store and the in-memory index every reapInterval. Call Stop to end
+// it.
+func (s *Service) Run() {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.reap()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// Stop ends the background reaper started by Run.
+func (s *Service) Stop() {
+	close(s.stop)
+}
+
+func (s *Service) reap() {
+	now := time.Now()
+
+	n, err := s.store.DeleteExpiredDecisions(now.Unix())
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	for scope, byValue := range s.index {
+		for value, d := range byValue {
+			if d.Expired(now) {
+				delete(byValue, value)
+			}
+		}
+		if len(byValue) == 0 {
+			delete(s.index, scope)
+		}
+	}
+	s.mu.Unlock()
+
+	if n > 0 {
+		s.logMetric("decisions_reaped", float64(n), nil)
+	}
+}
+
+func (s *Service) set(d Decision) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byValue, ok := s.index[d.Scope]
+	if !ok {
+		byValue = make(map[string]Decision)
+		s.index[d.Scope] = byValue
+	}
+	byValue[d.Value] = d
+}
+
+func (s *Service) unset(scope, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.index[scope], value)
+}
+
+// Feed implements DecisionFeeder: it creates, persists, and indexes a new
+// decision in one call, for callers (moderation tooling, user.Manager)
+// that don't need the full CRUD surface CreateDecision/DeleteDecision/
+// ListDecisions exposes to the admin handlers.
+func (s *Service) Feed(scope, value, action, reason string, ttl time.Duration) (Decision, error) {
+	d := Decision{
+		ID:        uuid.New().String(),
+		Scope:     scope,
+		Value:     value,
+		Action:    action,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := s.CreateDecision(d); err != nil {
+		return Decision{}, err
+	}
+	return d, nil
+}
+
+// CreateDecision persists and indexes d.
+func (s *Service) CreateDecision(d Decision) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+
+	if err := s.store.CreateDecision(d); err != nil {
+		return fmt.Errorf("failed to create decision: %v", err)
+	}
+	s.set(d)
+	s.logMetric("decisions_created", 1, map[string]interface{}{"scope": d.Scope, "action": d.Action})
+	return nil
+}
+
+// DeleteDecision removes a decision by ID from both store and the index.
+// It has to look the decision up first since the index is keyed by
+// scope/value, not ID.
+func (s *Service) DeleteDecision(id string) error {
+	all, err := s.store.ListDecisions()
+	if err != nil {
+		return fmt.Errorf("failed to list decisions: %v", err)
+	}
+	for _, d := range all {
+		if d.ID == id {
+			s.unset(d.Scope, d.Value)
+			break
+		}
+	}
+	return s.store.DeleteDecision(id)
+}
+
+// ListDecisions returns every decision store currently holds, expired or
+// not (the admin-facing view; the in-memory index is what enforcement
+// actually consults).
+func (s *Service) ListDecisions() ([]Decision, error) {
+	return s.store.ListDecisions()
+}
+
+// Lookup returns the active decision for scope/value, if any.
+func (s *Service) Lookup(scope, value string) (Decision, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	d, ok := s.index[scope][value]
+	if !ok || d.Expired(time.Now()) {
+		return Decision{}, false
+	}
+	return d, true
+}
+
+func (s *Service) logMetric(name string, value float64, metadata map[string]interface{}) {
+	_ = s.repo.LogMetric(&models.AlgorithmMetric{
+		AlgorithmName: "decisions",
+		MetricName:    name,
+		Value:         value,
+		Timestamp:     time.Now(),
+		Metadata:      metadata,
+	})
+}
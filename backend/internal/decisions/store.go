@@ -0,0 +1,15 @@
+package decisions
+
+// Store is the persistence Service needs beyond db.Repository.
+// *sqlite.DBManager satisfies it; this package never imports
+// internal/db/sqlite, so there is no cycle - the same split
+// internal/federation.Store and internal/moderation.Store use.
+type Store interface {
+	CreateDecision(d Decision) error
+	DeleteDecision(id string) error
+	ListDecisions() ([]Decision, error)
+
+	// DeleteExpiredDecisions removes every decision whose ExpiresAt is at
+	// or before now, returning how many rows it deleted.
+	DeleteExpiredDecisions(now int64) (int, error)
+}
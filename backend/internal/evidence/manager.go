@@ -5,16 +5,16 @@ import (
 	"time"
 
 	"github.com/saint/babel-protocol/backend/api/models"
-	"github.com/saint/babel-protocol/backend/internal/db/sqlite"
+	"github.com/saint/babel-protocol/backend/internal/db"
 )
 
 // Manager handles context operations
 type Manager struct {
-	db *sqlite.DBManager
+	db db.Repository
 }
 
 // NewManager creates a new context manager
-func NewManager(db *sqlite.DBManager) *Manager {
+func NewManager(db db.Repository) *Manager {
 	return &Manager{db: db}
 }
 
@@ -40,7 +40,7 @@ func (m *Manager) AddContext(contentID, authorID string, contextText string, ref
 		Text:            contextText,
 		References:      references,
 		QualityScore:    qualityScore,
-		Timestamp:       time.Now(),
+		Timestamp:       models.Now(),
 		LastUpdated:     time.Now(),
 		Metadata: map[string]interface{}{
 			"is_author_context": true,
@@ -0,0 +1,34 @@
+// Package federation defines the transport-agnostic interface peer Babel
+// instances use to exchange models.Activity envelopes. Concrete transports
+// (HTTP push, libp2p, NATS) implement Inbox and Outbox without requiring
+// any change to the Activity envelope itself.
+package federation
+
+import (
+	"context"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// Outbox delivers outbound activities to a peer instance.
+type Outbox interface {
+	Deliver(ctx context.Context, activity *models.Activity) error
+}
+
+// Inbox receives inbound activities from peer instances. Receive returns a
+// channel of deliveries that have already passed transport-level framing
+// but not yet models.VerifyActivity; callers are expected to verify each
+// activity against the sender's known public key before acting on it.
+type Inbox interface {
+	Receive(ctx context.Context) (<-chan *InboundActivity, error)
+}
+
+// InboundActivity pairs a received Activity with the local actor its
+// transport address named - for HTTPInbox, the :actor_id path segment of
+// /federation/inbox/:actor_id the activity was POSTed to. The envelope
+// itself carries no "this is who I'm addressed to" field, so the
+// transport has to supply it out of band.
+type InboundActivity struct {
+	LocalActorID string
+	Activity     *models.Activity
+}
@@ -0,0 +1,81 @@
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// Federator turns local writes into signed activities and enqueues them
+// for delivery to actor's followers. It's the seam api/handlers calls
+// after a content/vote write succeeds locally, the same point those
+// handlers already call hub.BroadcastUpdate from.
+type Federator struct {
+	store Store
+}
+
+// NewFederator builds a Federator over store.
+func NewFederator(store Store) *Federator {
+	return &Federator{store: store}
+}
+
+// FederateContent announces content (a post, comment, or context item)
+// as a Create/Content activity signed by actor. A comment or context
+// post (content.ParentID != nil) carries InReplyTo so a receiving peer
+// can thread it under the right parent.
+func (f *Federator) FederateContent(content *models.Content, actor *models.User) error {
+	activity, err := models.NewContentCreate(content, actor)
+	if err != nil {
+		return fmt.Errorf("federation: building content activity: %w", err)
+	}
+	if content.ParentID != nil {
+		activity.InReplyTo = models.NewOptional(*content.ParentID)
+	}
+	return f.enqueue(actor.ID, activity)
+}
+
+// FederateVote announces vote as an Affirm/Vote activity signed by
+// actor.
+func (f *Federator) FederateVote(vote *models.Vote, actor *models.User) error {
+	activity, err := models.NewVoteAffirm(vote, actor)
+	if err != nil {
+		return fmt.Errorf("federation: building vote activity: %w", err)
+	}
+	return f.enqueue(actor.ID, activity)
+}
+
+// enqueue fans activity out to every follower of actorID, signing a
+// distinct copy for each with that follower's own SharedSecret and
+// queuing one federation_outbox row per follower for the Worker to
+// deliver. It's a no-op, not an error, when actorID has no followers.
+func (f *Federator) enqueue(actorID string, activity *models.Activity) error {
+	followers, err := f.store.GetFollowers(actorID)
+	if err != nil {
+		return fmt.Errorf("federation: loading followers for %s: %w", actorID, err)
+	}
+	if len(followers) == 0 {
+		return nil
+	}
+
+	deliveries := make([]Delivery, 0, len(followers))
+	for _, follower := range followers {
+		signed := *activity
+		signed.SignWith(follower.SharedSecret)
+
+		payload, err := json.Marshal(&signed)
+		if err != nil {
+			return fmt.Errorf("federation: marshaling activity %s for follower %s: %w", activity.ID, follower.ID, err)
+		}
+		deliveries = append(deliveries, Delivery{
+			FollowerID:  follower.ID,
+			RemoteInbox: follower.RemoteInbox,
+			Payload:     payload,
+		})
+	}
+
+	if err := f.store.EnqueueDelivery(activity.ID, deliveries); err != nil {
+		return fmt.Errorf("federation: enqueuing deliveries for activity %s: %w", activity.ID, err)
+	}
+	return nil
+}
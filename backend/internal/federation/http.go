@@ -0,0 +1,86 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// HTTPOutbox is the concrete Outbox: it POSTs activity's signed JSON
+// envelope to a single peer inbox URL. A new HTTPOutbox is cheap to
+// construct, so callers (the Worker) make one per delivery rather than
+// pooling them per destination.
+type HTTPOutbox struct {
+	inboxURL string
+	client   *http.Client
+}
+
+// NewHTTPOutbox builds an Outbox that delivers to inboxURL.
+func NewHTTPOutbox(inboxURL string) *HTTPOutbox {
+	return &HTTPOutbox{inboxURL: inboxURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Deliver implements Outbox by POSTing activity as
+// application/activity+json. The envelope is already signed (every
+// models.New*Activity constructor signs at construction time), so this
+// is a plain POST with no transport-level signature of its own.
+func (o *HTTPOutbox) Deliver(ctx context.Context, activity *models.Activity) error {
+	payload, err := json.Marshal(activity)
+	if err != nil {
+		return fmt.Errorf("federation: marshaling activity %s: %w", activity.ID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.inboxURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("federation: building request to %s: %w", o.inboxURL, err)
+	}
+	req.Header.Set("Content-Type", "application/activity+json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("federation: delivering to %s: %w", o.inboxURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("federation: inbox %s rejected activity %s with status %d", o.inboxURL, activity.ID, resp.StatusCode)
+	}
+	return nil
+}
+
+// HTTPInbox is the concrete Inbox backing the /federation/inbox/:actor_id
+// handler. The handler's job is only transport framing - decode the
+// posted JSON and Push it - so a slow or backlogged Processor never
+// blocks the sender's connection past the inbox's buffer.
+type HTTPInbox struct {
+	ch chan *InboundActivity
+}
+
+// NewHTTPInbox creates an HTTPInbox buffering up to capacity unprocessed
+// deliveries.
+func NewHTTPInbox(capacity int) *HTTPInbox {
+	return &HTTPInbox{ch: make(chan *InboundActivity, capacity)}
+}
+
+// Receive implements Inbox.
+func (i *HTTPInbox) Receive(ctx context.Context) (<-chan *InboundActivity, error) {
+	return i.ch, nil
+}
+
+// Push enqueues activity, addressed to localActorID, without blocking -
+// it returns false if the inbox's buffer is full so the HTTP handler can
+// answer 503 and let the sender's own retry/backoff handle it rather
+// than silently dropping the activity.
+func (i *HTTPInbox) Push(localActorID string, activity *models.Activity) bool {
+	select {
+	case i.ch <- &InboundActivity{LocalActorID: localActorID, Activity: activity}:
+		return true
+	default:
+		return false
+	}
+}
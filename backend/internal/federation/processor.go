@@ -0,0 +1,107 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// Processor reads activities off an Inbox, verifies each against the
+// Following relationship it claims to come from, and applies it to repo.
+// Unlike the Worker (which only needs Store), the Processor needs the
+// full db.Repository to replay a remote Create/Content as a local
+// CreateContent and a remote Affirm/Vote as a local RecordVote.
+type Processor struct {
+	inbox Inbox
+	store Store
+	repo  db.Repository
+}
+
+// NewProcessor builds a Processor that drains inbox against store and
+// repo.
+func NewProcessor(inbox Inbox, store Store, repo db.Repository) *Processor {
+	return &Processor{inbox: inbox, store: store, repo: repo}
+}
+
+// Run drains inbox until ctx is cancelled or the inbox's channel closes.
+// Call this in its own goroutine.
+func (p *Processor) Run(ctx context.Context) {
+	ch, err := p.inbox.Receive(ctx)
+	if err != nil {
+		log.Printf("federation: opening inbox: %v", err)
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case delivery, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := p.apply(delivery); err != nil {
+				log.Printf("federation: applying activity %s: %v", delivery.Activity.ID, err)
+			}
+		}
+	}
+}
+
+// apply verifies delivery's activity against the Following relationship
+// for its claimed actor and local target, then replays it as a local
+// write.
+func (p *Processor) apply(delivery *InboundActivity) error {
+	activity := delivery.Activity
+	following, err := p.store.GetFollowing(delivery.LocalActorID, activity.ActorID)
+	if err != nil {
+		return fmt.Errorf("looking up follow relationship: %w", err)
+	}
+	if following == nil {
+		return fmt.Errorf("no follow relationship for remote actor %s on local actor %s", activity.ActorID, delivery.LocalActorID)
+	}
+
+	if err := models.VerifyActivity(activity, following.SharedSecret); err != nil {
+		return err
+	}
+
+	switch {
+	case activity.Type == models.ActivityTypeCreate && activity.ObjectType == models.ObjectTypeContent:
+		return p.applyContent(activity)
+	case activity.Type == models.ActivityTypeAffirm && activity.ObjectType == models.ObjectTypeVote:
+		return p.applyVote(activity, following.TrustWeight)
+	default:
+		return fmt.Errorf("unsupported activity %s/%s", activity.Type, activity.ObjectType)
+	}
+}
+
+// remoteOrigin is the Content.Metadata key applyContent stamps onto
+// replayed remote content, so downstream code (recommenders, moderation)
+// can tell a local write from a federated one without a schema change.
+const remoteOrigin = "federation_origin"
+
+func (p *Processor) applyContent(activity *models.Activity) error {
+	var content models.Content
+	if err := json.Unmarshal(activity.Object, &content); err != nil {
+		return fmt.Errorf("decoding content object: %w", err)
+	}
+
+	if content.Metadata == nil {
+		content.Metadata = map[string]interface{}{}
+	}
+	content.Metadata[remoteOrigin] = activity.ActorID
+
+	return p.repo.CreateContent(&content)
+}
+
+func (p *Processor) applyVote(activity *models.Activity, trustWeight float64) error {
+	var vote models.Vote
+	if err := json.Unmarshal(activity.Object, &vote); err != nil {
+		return fmt.Errorf("decoding vote object: %w", err)
+	}
+
+	return p.repo.RecordVote(vote.ContentID, vote.UserID, vote.Type, vote.Weight*trustWeight, vote.CertaintyLevel, vote.EvidenceIDs)
+}
@@ -0,0 +1,106 @@
+package federation
+
+import "time"
+
+// Follower is a remote actor subscribed to a local actor's activities -
+// a row in federation_followers. RemoteInbox is where outgoing
+// activities for LocalActorID are delivered; SharedSecret is a value
+// agreed with the follower out of band when the follow was established
+// (see AddFollowerHandler) - every activity delivered to it is signed
+// with models.Activity.SignWith(SharedSecret), never LocalActorID's
+// public key.
+type Follower struct {
+	ID            string
+	LocalActorID  string
+	RemoteActorID string
+	RemoteInbox   string
+	SharedSecret  string
+	CreatedAt     time.Time
+}
+
+// Following is a remote actor a local actor follows - a row in
+// federation_following. Incoming activities claiming to be from
+// RemoteActorID are verified against SharedSecret; TrustWeight scales
+// the weight of any vote such an activity translates into, so a remote
+// instance the operator trusts less can be down-weighted rather than
+// rejected outright.
+type Following struct {
+	ID            string
+	LocalActorID  string
+	RemoteActorID string
+	RemoteInbox   string
+	SharedSecret  string
+	TrustWeight   float64
+	CreatedAt     time.Time
+}
+
+// Outbox delivery statuses.
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+// OutboxEntry is a row in federation_outbox: one signed activity queued
+// for delivery to one follower's inbox.
+type OutboxEntry struct {
+	ID            string
+	FollowerID    string
+	RemoteInbox   string
+	ActivityID    string
+	Payload       []byte
+	Status        string
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	DeliveredAt   *time.Time
+}
+
+// Delivery is one follower's signed copy of an activity, ready to queue
+// for delivery - each follower gets its own Payload, signed with its own
+// SharedSecret, so a payload never verifies against any secret but the
+// one it was addressed to.
+type Delivery struct {
+	FollowerID  string
+	RemoteInbox string
+	Payload     []byte
+}
+
+// Store is the persistence the Federator and Worker need. *sqlite.DBManager
+// satisfies it; this package never imports internal/db/sqlite, so there is
+// no cycle - the same split internal/consensus/hcs.Store uses.
+type Store interface {
+	AddFollower(f Follower) error
+	RemoveFollower(localActorID, remoteActorID string) error
+	GetFollowers(localActorID string) ([]Follower, error)
+
+	AddFollowing(f Following) error
+	GetFollowing(localActorID, remoteActorID string) (*Following, error)
+
+	// EnqueueDelivery queues one federation_outbox row per entry in
+	// deliveries, for the Worker to drain.
+	EnqueueDelivery(activityID string, deliveries []Delivery) error
+	ClaimPendingDeliveries(limit int) ([]*OutboxEntry, error)
+	MarkDelivered(id string) error
+	MarkDeliveryFailed(id string, deliveryErr error) error
+}
+
+// maxDeliveryAttempts bounds how many times the Worker retries a
+// delivery before giving up on it for good.
+const maxDeliveryAttempts = 8
+
+// Backoff returns how long to wait before retrying a delivery that has
+// failed attempts times so far: an exponential backoff capped at 15
+// minutes so a long-downed peer doesn't get hammered once it's back.
+func Backoff(attempts int) time.Duration {
+	const cap = 15 * time.Minute
+	d := time.Second
+	for i := 0; i < attempts; i++ {
+		d *= 2
+		if d >= cap {
+			return cap
+		}
+	}
+	return d
+}
@@ -0,0 +1,80 @@
+package federation
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// Worker periodically drains pending federation_outbox entries and
+// delivers each to its follower's inbox over a fresh HTTPOutbox, the
+// same claim-drain-retry shape hcs.Worker uses for HCS submission.
+type Worker struct {
+	store     Store
+	interval  time.Duration
+	batchSize int
+	stop      chan struct{}
+}
+
+// NewWorker creates a Worker that claims up to batchSize pending
+// deliveries from store every interval.
+func NewWorker(store Store, interval time.Duration, batchSize int) *Worker {
+	return &Worker{store: store, interval: interval, batchSize: batchSize, stop: make(chan struct{})}
+}
+
+// Run drains the outbox every w.interval until Stop is called. Call this
+// in its own goroutine.
+func (w *Worker) Run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.drain()
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// Stop halts the drain loop.
+func (w *Worker) Stop() {
+	close(w.stop)
+}
+
+func (w *Worker) drain() {
+	entries, err := w.store.ClaimPendingDeliveries(w.batchSize)
+	if err != nil {
+		log.Printf("federation: claiming pending deliveries: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		if err := w.deliver(entry); err != nil {
+			log.Printf("federation: delivering outbox entry %s to %s: %v", entry.ID, entry.RemoteInbox, err)
+			if markErr := w.store.MarkDeliveryFailed(entry.ID, err); markErr != nil {
+				log.Printf("federation: marking outbox entry %s failed: %v", entry.ID, markErr)
+			}
+			continue
+		}
+		if err := w.store.MarkDelivered(entry.ID); err != nil {
+			log.Printf("federation: marking outbox entry %s delivered: %v", entry.ID, err)
+		}
+	}
+}
+
+func (w *Worker) deliver(entry *OutboxEntry) error {
+	var activity models.Activity
+	if err := json.Unmarshal(entry.Payload, &activity); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	return NewHTTPOutbox(entry.RemoteInbox).Deliver(ctx, &activity)
+}
@@ -0,0 +1,30 @@
+package loaders
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// contextKey is the gin.Context key Middleware stashes a *Loaders under.
+const contextKey = "loaders"
+
+// Middleware builds a fresh Loaders backed by repo for each request and
+// stashes it on the request context under contextKey, so handlers can pull
+// it out with FromContext instead of hitting repo directly per item.
+func Middleware(repo db.Repository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(contextKey, New(repo))
+		c.Next()
+	}
+}
+
+// FromContext returns the *Loaders stashed by Middleware, or nil if none
+// was installed.
+func FromContext(c *gin.Context) *Loaders {
+	v, ok := c.Get(contextKey)
+	if !ok {
+		return nil
+	}
+	return v.(*Loaders)
+}
@@ -0,0 +1,140 @@
+// Package loaders implements a per-request batching layer on top of
+// db.Repository, modeled on the todo.sr.ht loader middleware pattern: feed
+// assembly and consensus scoring tend to call GetUser/GetContent/etc. once
+// per item, which turns an N-item feed into N+1 round trips. A Loader
+// collects the keys requested during a short window (or until a batch size
+// cap is hit) and resolves them all with a single WHERE id IN (...) query.
+package loaders
+
+import (
+	"sync"
+	"time"
+)
+
+// BatchFunc loads every value for keys in one round trip. It need not
+// return an entry for every key; missing keys resolve to V's zero value.
+type BatchFunc[K comparable, V any] func(keys []K) (map[K]V, error)
+
+type result[V any] struct {
+	val V
+	err error
+}
+
+// Loader batches and caches Load calls for a single key type. It is scoped
+// to one request: construct a fresh Loader (via Loaders, below) per
+// request and let it go out of scope afterward, since its cache never
+// expires or invalidates on its own.
+type Loader[K comparable, V any] struct {
+	batch    BatchFunc[K, V]
+	window   time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	cache   map[K]V
+	cached  map[K]bool
+	waiting map[K][]chan result[V]
+	pending []K
+	timer   *time.Timer
+}
+
+// NewLoader returns a Loader that calls batch to resolve keys, firing the
+// batch after window elapses since the first key in it was requested, or
+// as soon as maxBatch keys have queued, whichever comes first.
+func NewLoader[K comparable, V any](batch BatchFunc[K, V], window time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:    batch,
+		window:   window,
+		maxBatch: maxBatch,
+		cache:    make(map[K]V),
+		cached:   make(map[K]bool),
+		waiting:  make(map[K][]chan result[V]),
+	}
+}
+
+// Load returns the value for key, joining an in-flight or future batch if
+// one hasn't already resolved key. Safe to call concurrently.
+func (l *Loader[K, V]) Load(key K) (V, error) {
+	l.mu.Lock()
+	if l.cached[key] {
+		v := l.cache[key]
+		l.mu.Unlock()
+		return v, nil
+	}
+
+	ch := make(chan result[V], 1)
+	l.waiting[key] = append(l.waiting[key], ch)
+
+	if len(l.waiting[key]) == 1 {
+		l.pending = append(l.pending, key)
+	}
+
+	if len(l.pending) >= l.maxBatch {
+		if l.timer != nil {
+			l.timer.Stop()
+			l.timer = nil
+		}
+		keys := l.pending
+		l.pending = nil
+		l.mu.Unlock()
+		l.dispatch(keys)
+	} else {
+		if l.timer == nil {
+			l.timer = time.AfterFunc(l.window, l.fire)
+		}
+		l.mu.Unlock()
+	}
+
+	res := <-ch
+	return res.val, res.err
+}
+
+// LoadAll is a convenience wrapper that loads every key and preserves
+// input order in the returned slice.
+func (l *Loader[K, V]) LoadAll(keys []K) ([]V, error) {
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		v, err := l.Load(key)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (l *Loader[K, V]) fire() {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.timer = nil
+	l.mu.Unlock()
+
+	if len(keys) > 0 {
+		l.dispatch(keys)
+	}
+}
+
+func (l *Loader[K, V]) dispatch(keys []K) {
+	values, err := l.batch(keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		chans := l.waiting[key]
+		delete(l.waiting, key)
+
+		var res result[V]
+		if err != nil {
+			res.err = err
+		} else {
+			res.val = values[key]
+			l.cache[key] = res.val
+			l.cached[key] = true
+		}
+
+		for _, ch := range chans {
+			ch <- res
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package loaders
+
+import (
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// batchWindow and maxBatchSize bound how long a Loader waits to collect
+// keys before issuing its batched query, and how many keys it will collect
+// before dispatching early regardless of the window.
+const (
+	batchWindow  = 2 * time.Millisecond
+	maxBatchSize = 100
+)
+
+// Loaders is the set of per-request batched loaders built on top of a
+// db.Repository. Construct one per request with New and discard it once
+// the request completes - see Middleware.
+type Loaders struct {
+	UsersByID             *Loader[string, *models.User]
+	ContentByID           *Loader[string, *models.Content]
+	VotesByContentID      *Loader[string, []*db.VoteInfo]
+	EvidenceByContentID   *Loader[string, []*models.Evidence]
+	VerificationsByUserID *Loader[string, []*models.UserVerification]
+}
+
+// New builds a Loaders backed by repo. Each field batches through the
+// corresponding GetXByIDs bulk Repository method.
+func New(repo db.Repository) *Loaders {
+	return &Loaders{
+		UsersByID: NewLoader(func(ids []string) (map[string]*models.User, error) {
+			users, err := repo.GetUsersByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]*models.User, len(users))
+			for _, u := range users {
+				byID[u.ID] = u
+			}
+			return byID, nil
+		}, batchWindow, maxBatchSize),
+
+		ContentByID: NewLoader(func(ids []string) (map[string]*models.Content, error) {
+			contents, err := repo.GetContentByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]*models.Content, len(contents))
+			for _, c := range contents {
+				byID[c.ID] = c
+			}
+			return byID, nil
+		}, batchWindow, maxBatchSize),
+
+		VotesByContentID: NewLoader(repo.GetContentVotesByContentIDs, batchWindow, maxBatchSize),
+
+		EvidenceByContentID: NewLoader(repo.GetContentEvidenceByContentIDs, batchWindow, maxBatchSize),
+
+		VerificationsByUserID: NewLoader(repo.GetUserVerificationsByUserIDs, batchWindow, maxBatchSize),
+	}
+}
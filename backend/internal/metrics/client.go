@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// NewInstrumentedClient returns an *http.Client whose RoundTripper wraps
+// http.DefaultTransport with in-flight, counter, and duration
+// instrumentation, all labeled by the caller-supplied client name (e.g.
+// "consensus_oracle", "notifications_push"). This gives outbound calls the
+// same babel_http_client_requests_total{client,method,code} and duration
+// histogram series that RequestMiddleware records for inbound requests.
+func (m *Metrics) NewInstrumentedClient(name string) *http.Client {
+	labels := prometheus.Labels{"client": name}
+
+	transport := promhttp.InstrumentRoundTripperInFlight(
+		m.httpClientInFlight.With(labels),
+		promhttp.InstrumentRoundTripperCounter(
+			m.httpClientRequests.MustCurryWith(labels),
+			promhttp.InstrumentRoundTripperDuration(
+				m.httpClientDuration.MustCurryWith(labels),
+				http.DefaultTransport,
+			),
+		),
+	)
+
+	return &http.Client{Transport: transport}
+}
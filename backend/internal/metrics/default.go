@@ -0,0 +1,17 @@
+package metrics
+
+// defaultMetrics is the Metrics instance used by free-function handlers
+// (e.g. in the legacy api package) that aren't constructed with a Metrics
+// instance. Mirrors alert.SetDefault/alert.Raise and storage.SetDefault/
+// storage.Default.
+var defaultMetrics *Metrics
+
+// SetDefault replaces the package-level Metrics instance used by Default.
+func SetDefault(m *Metrics) {
+	defaultMetrics = m
+}
+
+// Default returns the package-level Metrics instance.
+func Default() *Metrics {
+	return defaultMetrics
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Domain-specific counters for the product events that actually happen in
+// this API, as opposed to the generic HTTP shape tracked by Metrics above.
+// They're package-level (rather than fields on Metrics) so the handler
+// functions that emit them - which aren't constructed with a Metrics
+// instance - can increment them directly.
+var (
+	UsersRegisteredTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "babel_users_registered_total",
+		Help: "Total number of successful user registrations",
+	})
+
+	PostsCreatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "babel_posts_created_total",
+		Help: "Total number of posts created",
+	})
+
+	CommentsCreatedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "babel_comments_created_total",
+		Help: "Total number of comments created",
+	})
+
+	LikesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "babel_likes_total",
+		Help: "Total number of likes and dislikes, labeled by action",
+	}, []string{"action"})
+
+	ConsensusEstablishedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "babel_consensus_established_total",
+		Help: "Total number of times consensus was established on content",
+	})
+
+	ContentVerifiedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "babel_content_verified_total",
+		Help: "Total number of content verification attempts, labeled by result",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		UsersRegisteredTotal,
+		PostsCreatedTotal,
+		CommentsCreatedTotal,
+		LikesTotal,
+		ConsensusEstablishedTotal,
+		ContentVerifiedTotal,
+	)
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+// SysMetricsHandler gathers the default Prometheus registry and renders it
+// as either Prometheus text format (the default, and ?format=prometheus) or
+// JSON (?format=json), for the authenticated /sys/metrics pull path.
+func SysMetricsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		families, err := prometheus.DefaultGatherer.Gather()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if c.Query("format") == "json" {
+			c.JSON(http.StatusOK, families)
+			return
+		}
+
+		c.Writer.Header().Set("Content-Type", string(expfmt.FmtText))
+		c.Writer.WriteHeader(http.StatusOK)
+		encoder := expfmt.NewEncoder(c.Writer, expfmt.FmtText)
+		for _, family := range families {
+			if err := encoder.Encode(family); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -2,20 +2,45 @@ package metrics
 
 import (
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// activeUsersWindow and activeUsersDailyWindow bound how long a user's last
+// request keeps them counted in the respective active-users gauge.
+const (
+	activeUsersWindow      = time.Hour
+	activeUsersDailyWindow = 24 * time.Hour
+	activeUsersTick        = time.Minute
+)
+
 // Metrics holds all Prometheus metrics
 type Metrics struct {
 	requestCounter  *prometheus.CounterVec
 	requestDuration *prometheus.HistogramVec
 	requestInFlight *prometheus.GaugeVec
+
+	activeUsers      prometheus.Gauge
+	activeUsersDaily prometheus.Gauge
+
+	httpClientRequests *prometheus.CounterVec
+	httpClientDuration *prometheus.HistogramVec
+	httpClientInFlight *prometheus.GaugeVec
+
+	wsDroppedFrames *prometheus.CounterVec
+
+	lastSeenMu sync.RWMutex
+	lastSeen   map[string]time.Time
+
+	stop chan struct{}
 }
 
-// NewMetrics creates and registers Prometheus metrics
+// NewMetrics creates and registers Prometheus metrics, and starts the
+// background goroutine that maintains the active-users gauges. Call Close
+// to stop it (e.g. in tests).
 func NewMetrics() *Metrics {
 	m := &Metrics{
 		requestCounter: prometheus.NewCounterVec(
@@ -40,16 +65,127 @@ func NewMetrics() *Metrics {
 			},
 			[]string{"method", "path"},
 		),
+		activeUsers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "babel_active_users",
+			Help: "Number of distinct authenticated users seen in the last hour",
+		}),
+		activeUsersDaily: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "babel_active_users_daily",
+			Help: "Number of distinct authenticated users seen in the last 24 hours",
+		}),
+		httpClientRequests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "babel_http_client_requests_total",
+				Help: "Total number of outbound HTTP requests made by instrumented clients",
+			},
+			[]string{"client", "method", "code"},
+		),
+		httpClientDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "babel_http_client_request_duration_seconds",
+				Help:    "Outbound HTTP request duration in seconds, by instrumented client",
+				Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			},
+			[]string{"client", "method"},
+		),
+		httpClientInFlight: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "babel_http_client_requests_in_flight",
+				Help: "Current number of in-flight outbound HTTP requests, by instrumented client",
+			},
+			[]string{"client"},
+		),
+		wsDroppedFrames: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "babel_ws_dropped_frames_total",
+				Help: "Total number of WebSocket frames dropped by the hub instead of delivered",
+			},
+			[]string{"reason"},
+		),
+		lastSeen: make(map[string]time.Time),
+		stop:     make(chan struct{}),
 	}
 
 	// Register metrics with Prometheus
 	prometheus.MustRegister(m.requestCounter)
 	prometheus.MustRegister(m.requestDuration)
 	prometheus.MustRegister(m.requestInFlight)
+	prometheus.MustRegister(m.activeUsers)
+	prometheus.MustRegister(m.activeUsersDaily)
+	prometheus.MustRegister(m.httpClientRequests)
+	prometheus.MustRegister(m.httpClientDuration)
+	prometheus.MustRegister(m.httpClientInFlight)
+	prometheus.MustRegister(m.wsDroppedFrames)
+
+	go m.runActiveUsersLoop()
 
 	return m
 }
 
+// Close stops the active-users background goroutine.
+func (m *Metrics) Close() {
+	close(m.stop)
+}
+
+// markUserSeen records that userID made a request just now. Safe to call
+// from request handling as well as periodic WebSocket pings, so long-lived
+// connections keep their user counted as active.
+func (m *Metrics) markUserSeen(userID string) {
+	if userID == "" {
+		return
+	}
+	m.lastSeenMu.Lock()
+	m.lastSeen[userID] = time.Now()
+	m.lastSeenMu.Unlock()
+}
+
+// MarkUserSeen is the exported form of markUserSeen, for call sites outside
+// RequestMiddleware (e.g. the WebSocket hub's ping loop) that want to keep a
+// long-lived connection's user counted as active.
+func (m *Metrics) MarkUserSeen(userID string) {
+	m.markUserSeen(userID)
+}
+
+// runActiveUsersLoop recomputes the active-users gauges every tick and
+// evicts entries that have aged out of even the daily window.
+func (m *Metrics) runActiveUsersLoop() {
+	ticker := time.NewTicker(activeUsersTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.refreshActiveUsers()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *Metrics) refreshActiveUsers() {
+	now := time.Now()
+	hourCutoff := now.Add(-activeUsersWindow)
+	dailyCutoff := now.Add(-activeUsersDailyWindow)
+
+	m.lastSeenMu.Lock()
+	defer m.lastSeenMu.Unlock()
+
+	var hourly, daily int
+	for userID, seenAt := range m.lastSeen {
+		if seenAt.Before(dailyCutoff) {
+			delete(m.lastSeen, userID)
+			continue
+		}
+		daily++
+		if seenAt.After(hourCutoff) {
+			hourly++
+		}
+	}
+
+	m.activeUsers.Set(float64(hourly))
+	m.activeUsersDaily.Set(float64(daily))
+}
+
 // RequestMiddleware returns a Gin middleware that records request metrics
 func (m *Metrics) RequestMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -70,6 +206,8 @@ func (m *Metrics) RequestMiddleware() gin.HandlerFunc {
 
 		m.requestCounter.WithLabelValues(method, path, strconv.Itoa(status)).Inc()
 		m.requestDuration.WithLabelValues(method, path).Observe(duration)
+
+		m.markUserSeen(c.GetString("user_id"))
 	}
 }
 
@@ -82,3 +220,11 @@ func (m *Metrics) RecordError(method, path string) {
 func (m *Metrics) RecordDuration(method, path string, duration time.Duration) {
 	m.requestDuration.WithLabelValues(method, path).Observe(duration.Seconds())
 }
+
+// RecordWSDroppedFrame records that the WebSocket hub dropped a frame
+// instead of delivering it, tagged with why (e.g. "queue_full",
+// "write_deadline_exceeded") so dashboards can tell a slow client from a
+// dead one.
+func (m *Metrics) RecordWSDroppedFrame(reason string) {
+	m.wsDroppedFrames.WithLabelValues(reason).Inc()
+}
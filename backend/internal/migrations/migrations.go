@@ -0,0 +1,94 @@
+// Package migrations embeds the project's numbered forward/backward SQL
+// migrations and the tooling to parse them. Each migration is a pair of
+// files under sql/ named NNNN_description.up.sql / NNNN_description.down.sql;
+// embed.FS bakes them into the binary so a deployment never depends on a
+// migrations directory existing alongside it. DBManager.Migrate applies
+// them against schema_migrations.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// Migration is one numbered schema change, with the SQL to apply it (Up)
+// and to reverse it (Down).
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads every embedded migration, ordered by Version ascending.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(sqlFiles, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("migrations: reading sql directory: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		version, name, direction, err := parseFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := sqlFiles.ReadFile("sql/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migrations: reading %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		switch direction {
+		case "up":
+			m.Up = string(content)
+		case "down":
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// parseFilename splits "0002_hcs_outbox.up.sql" into version 2, name
+// "hcs_outbox", direction "up".
+func parseFilename(filename string) (version int, name, direction string, err error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	parts := strings.SplitN(base, ".", 2)
+	if len(parts) != 2 {
+		return 0, "", "", fmt.Errorf("migrations: %q is missing an up/down suffix", filename)
+	}
+	direction = parts[1]
+	if direction != "up" && direction != "down" {
+		return 0, "", "", fmt.Errorf("migrations: %q has unknown direction %q", filename, direction)
+	}
+
+	versionAndName := strings.SplitN(parts[0], "_", 2)
+	if len(versionAndName) != 2 {
+		return 0, "", "", fmt.Errorf("migrations: %q is missing a version prefix", filename)
+	}
+	version, err = strconv.Atoi(versionAndName[0])
+	if err != nil {
+		return 0, "", "", fmt.Errorf("migrations: %q has a non-numeric version: %w", filename, err)
+	}
+
+	return version, versionAndName[1], direction, nil
+}
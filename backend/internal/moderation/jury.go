@@ -0,0 +1,51 @@
+package moderation
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// JurorCandidate is one eligible juror and the weight - their
+// ReputationScore - SelectJury samples them proportionally to.
+type JurorCandidate struct {
+	UserID          string
+	ReputationScore float64
+}
+
+// minJurorWeight floors a candidate's sampling weight so a brand-new
+// account with zero (or negative) reputation still has a small chance of
+// being sampled, rather than never being eligible at all.
+const minJurorWeight = 0.01
+
+// SelectJury samples up to size candidates without replacement, with
+// probability proportional to ReputationScore, using Efraimidis-Spirakis
+// weighted reservoir sampling: each candidate draws a key =
+// rand()^(1/weight), and the size candidates with the largest keys are
+// kept. If size exceeds len(candidates), every candidate is selected.
+func SelectJury(candidates []JurorCandidate, size int) []string {
+	type keyed struct {
+		userID string
+		key    float64
+	}
+
+	keys := make([]keyed, len(candidates))
+	for i, c := range candidates {
+		weight := c.ReputationScore
+		if weight < minJurorWeight {
+			weight = minJurorWeight
+		}
+		keys[i] = keyed{userID: c.UserID, key: math.Pow(rand.Float64(), 1/weight)}
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key > keys[j].key })
+
+	if size > len(keys) {
+		size = len(keys)
+	}
+	jurors := make([]string, size)
+	for i := 0; i < size; i++ {
+		jurors[i] = keys[i].userID
+	}
+	return jurors
+}
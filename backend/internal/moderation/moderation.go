@@ -0,0 +1,97 @@
+// Package moderation implements community content moderation: a flagged
+// piece of content is reviewed by a randomly-sampled, reputation-weighted
+// jury of other users, who cast moderation votes through the same
+// RecordVote/vote_events ledger regular content votes use. Once enough
+// jurors have voted, a verdict is resolved, and if the jury upholds the
+// flag the content's VisibilityScore is suppressed. An author can appeal
+// a suppress verdict to a larger jury. Service in service.go orchestrates
+// the flag -> jury -> verdict pipeline against db.Repository and Store;
+// the jury sampling itself is pure math in jury.go, mirroring
+// internal/recommend's split between "the algorithm" and its
+// orchestration.
+package moderation
+
+import "time"
+
+// ContentFlag statuses.
+const (
+	FlagStatusOpen     = "open"
+	FlagStatusResolved = "resolved"
+)
+
+// Jury statuses.
+const (
+	JuryStatusVoting   = "voting"
+	JuryStatusResolved = "resolved"
+)
+
+// Verdict outcomes.
+const (
+	VerdictSuppress = "suppress"
+	VerdictClear    = "clear"
+)
+
+// ContentFlag is a report against a piece of content - the trigger for
+// its first Jury.
+type ContentFlag struct {
+	ID         string
+	ContentID  string
+	ReporterID string
+	Category   string
+	Evidence   []string
+	Status     string
+	CreatedAt  time.Time
+}
+
+// Jury is one panel convened to review a ContentFlag, or - when IsAppeal
+// is true - a prior Jury's Verdict, in which case ParentJuryID names it.
+type Jury struct {
+	ID           string
+	ContentID    string
+	FlagID       string
+	Size         int
+	Quorum       int
+	Status       string
+	IsAppeal     bool
+	ParentJuryID string
+	CreatedAt    time.Time
+	ResolvedAt   *time.Time
+}
+
+// JuryMember is one juror invited onto a Jury. VotedAt is nil until they
+// cast their moderation vote.
+type JuryMember struct {
+	ID        string
+	JuryID    string
+	JurorID   string
+	InvitedAt time.Time
+	VotedAt   *time.Time
+}
+
+// Verdict is a Jury's resolved outcome.
+type Verdict struct {
+	ID        string
+	JuryID    string
+	ContentID string
+	Outcome   string
+	// VotesFor/VotesAgainst are jurors whose moderation vote's Weight was
+	// positive (upholds the flag) or negative (dismisses it).
+	VotesFor     int
+	VotesAgainst int
+	// VisibilityScore is the value applied to the content when Outcome is
+	// VerdictSuppress; zero when the flag was cleared, since clearing
+	// leaves the content's existing visibility untouched.
+	VisibilityScore float64
+	ResolvedAt      time.Time
+}
+
+// JurorVote is one juror's moderation vote on a Jury, tallied from
+// vote_events rather than truth_consensus: truth_consensus keeps only the
+// latest vote per (content_id, voter_id) regardless of type, so a juror
+// who later casts an ordinary upvote/downvote on the same content would
+// otherwise clobber their moderation vote there.
+type JurorVote struct {
+	JurorID   string
+	Weight    float64
+	Timestamp time.Time
+}
@@ -0,0 +1,310 @@
+package moderation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/websocket"
+)
+
+// suppressedVisibilityScore is the VisibilityScore applied to content a
+// jury upholds a flag against - low enough to drop it out of ranked
+// feeds without deleting it outright, leaving the appeal path meaningful.
+const suppressedVisibilityScore = 0.05
+
+// Config tunes jury size and quorum for an initial review and for an
+// appeal, and how large a candidate pool SelectJury samples from.
+type Config struct {
+	JurySize      int
+	Quorum        int
+	CandidatePool int
+
+	AppealJurySize int
+	AppealQuorum   int
+}
+
+// DefaultConfig reviews with a 7-juror panel (quorum 4) sampled from the
+// top 50 eligible candidates by reputation, and an appeal with a larger
+// 15-juror panel (quorum 8).
+func DefaultConfig() Config {
+	return Config{
+		JurySize:      7,
+		Quorum:        4,
+		CandidatePool: 50,
+
+		AppealJurySize: 15,
+		AppealQuorum:   8,
+	}
+}
+
+// Service orchestrates the flag -> jury -> verdict pipeline against
+// db.Repository's content/vote data and Store's moderation-specific
+// tables.
+type Service struct {
+	repo  db.Repository
+	store Store
+	hub   *websocket.WebSocketHub
+	cfg   Config
+}
+
+// NewService builds a Service over repo and store, announcing jury
+// invites and verdicts through hub.
+func NewService(repo db.Repository, store Store, hub *websocket.WebSocketHub, cfg Config) *Service {
+	return &Service{repo: repo, store: store, hub: hub, cfg: cfg}
+}
+
+// FlagContent records a report against contentID and convenes its first
+// jury, excluding the content's author and anyone who has already voted
+// on it.
+func (s *Service) FlagContent(contentID, reporterID, category string, evidence []string) (*ContentFlag, *Jury, error) {
+	content, err := s.repo.GetContent(contentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if content == nil {
+		return nil, nil, fmt.Errorf("content not found: %s", contentID)
+	}
+
+	flag := ContentFlag{
+		ID:         uuid.New().String(),
+		ContentID:  contentID,
+		ReporterID: reporterID,
+		Category:   category,
+		Evidence:   evidence,
+		Status:     FlagStatusOpen,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.store.CreateFlag(flag); err != nil {
+		return nil, nil, err
+	}
+
+	jury, err := s.conveneJury(content, flag.ID, "", s.cfg.JurySize, s.cfg.Quorum, false)
+	if err != nil {
+		return &flag, nil, err
+	}
+	return &flag, jury, nil
+}
+
+// conveneJury samples a jury over content, invites each juror over the
+// hub, and persists it.
+func (s *Service) conveneJury(content *models.Content, flagID, parentJuryID string, size, quorum int, isAppeal bool) (*Jury, error) {
+	exclude := []string{content.AuthorID}
+	priorVotes, err := s.repo.GetContentVotes(content.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range priorVotes {
+		exclude = append(exclude, v.VoterID)
+	}
+
+	pool, err := s.store.CandidateJurors(exclude, s.cfg.CandidatePool)
+	if err != nil {
+		return nil, err
+	}
+	jurorIDs := SelectJury(pool, size)
+	if len(jurorIDs) == 0 {
+		return nil, fmt.Errorf("no eligible jurors for content %s", content.ID)
+	}
+
+	jury := Jury{
+		ID:           uuid.New().String(),
+		ContentID:    content.ID,
+		FlagID:       flagID,
+		Size:         len(jurorIDs),
+		Quorum:       quorum,
+		Status:       JuryStatusVoting,
+		IsAppeal:     isAppeal,
+		ParentJuryID: parentJuryID,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.store.CreateJury(jury); err != nil {
+		return nil, err
+	}
+	if err := s.store.AddJuryMembers(jury.ID, jurorIDs); err != nil {
+		return nil, err
+	}
+
+	for _, jurorID := range jurorIDs {
+		s.hub.SendToUser(jurorID, websocket.EventModerationJuryInvite, map[string]interface{}{
+			"jury_id":    jury.ID,
+			"content_id": jury.ContentID,
+			"is_appeal":  isAppeal,
+		})
+	}
+
+	return &jury, nil
+}
+
+// CastJuryVote records jurorID's moderation vote on juryID through the
+// same RecordVote ledger a regular content vote uses, then resolves the
+// jury's verdict once quorum is reached. verdict is nil (with no error)
+// when the vote was recorded but quorum hasn't been met yet.
+func (s *Service) CastJuryVote(juryID, jurorID string, weight float64, certaintyLevel int, evidenceIDs []string) (*Verdict, error) {
+	jury, err := s.store.GetJury(juryID)
+	if err != nil {
+		return nil, err
+	}
+	if jury == nil {
+		return nil, fmt.Errorf("jury not found: %s", juryID)
+	}
+	if jury.Status != JuryStatusVoting {
+		return nil, fmt.Errorf("jury %s is no longer accepting votes", juryID)
+	}
+
+	isMember, err := s.store.IsJuryMember(juryID, jurorID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, fmt.Errorf("user %s is not a member of jury %s", jurorID, juryID)
+	}
+
+	vote := models.Vote{
+		ID:             uuid.New().String(),
+		ContentID:      jury.ContentID,
+		UserID:         jurorID,
+		Type:           models.VoteTypeModeration,
+		Weight:         weight,
+		CertaintyLevel: certaintyLevel,
+		EvidenceIDs:    evidenceIDs,
+		Timestamp:      models.Now(),
+		LastUpdated:    time.Now(),
+	}
+	if err := vote.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.RecordVote(vote.ContentID, vote.UserID, vote.Type, vote.Weight, vote.CertaintyLevel, vote.EvidenceIDs); err != nil {
+		return nil, err
+	}
+	if err := s.store.MarkJurorVoted(juryID, jurorID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	members, err := s.store.JuryMembers(juryID)
+	if err != nil {
+		return nil, err
+	}
+	var voted int
+	for _, m := range members {
+		if m.VotedAt != nil {
+			voted++
+		}
+	}
+	if voted < jury.Quorum {
+		return nil, nil
+	}
+
+	return s.resolveVerdict(jury)
+}
+
+// resolveVerdict tallies jury's jurors' moderation votes, persists the
+// verdict, applies content suppression if the flag was upheld, and
+// announces the outcome over the hub.
+func (s *Service) resolveVerdict(jury *Jury) (*Verdict, error) {
+	votes, err := s.store.GetJuryVotes(jury.ID, jury.ContentID, jury.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	var votesFor, votesAgainst int
+	for _, v := range votes {
+		if v.Weight > 0 {
+			votesFor++
+		} else {
+			votesAgainst++
+		}
+	}
+
+	verdict := Verdict{
+		ID:           uuid.New().String(),
+		JuryID:       jury.ID,
+		ContentID:    jury.ContentID,
+		VotesFor:     votesFor,
+		VotesAgainst: votesAgainst,
+		ResolvedAt:   time.Now(),
+	}
+	if votesFor > votesAgainst {
+		verdict.Outcome = VerdictSuppress
+		verdict.VisibilityScore = suppressedVisibilityScore
+	} else {
+		verdict.Outcome = VerdictClear
+	}
+
+	if err := s.store.ResolveJury(jury.ID, verdict); err != nil {
+		return nil, err
+	}
+
+	if verdict.Outcome == VerdictSuppress {
+		if err := s.suppressContent(jury.ContentID, verdict.VisibilityScore); err != nil {
+			return nil, err
+		}
+	}
+
+	if content, err := s.repo.GetContent(jury.ContentID); err == nil && content != nil {
+		s.hub.SendToUser(content.AuthorID, websocket.EventModerationVerdict, verdict)
+	}
+	s.hub.BroadcastUpdate(websocket.EventModerationVerdict, verdict)
+
+	return &verdict, nil
+}
+
+// suppressContent drops contentID's VisibilityScore to score, reusing
+// UpdateContentBatch - the one content update path that isn't gated by
+// author ownership - since this write comes from the moderation system,
+// not the author.
+func (s *Service) suppressContent(contentID string, score float64) error {
+	content, err := s.repo.GetContent(contentID)
+	if err != nil {
+		return err
+	}
+	if content == nil {
+		return fmt.Errorf("content not found: %s", contentID)
+	}
+	content.VisibilityScore = score
+	content.LastUpdated = time.Now()
+	return s.repo.UpdateContentBatch([]*models.Content{content})
+}
+
+// Appeal lets juryID's content's author request re-review of its
+// suppress verdict by a larger jury.
+func (s *Service) Appeal(juryID, requesterID string) (*Jury, error) {
+	jury, err := s.store.GetJury(juryID)
+	if err != nil {
+		return nil, err
+	}
+	if jury == nil {
+		return nil, fmt.Errorf("jury not found: %s", juryID)
+	}
+	if jury.Status != JuryStatusResolved {
+		return nil, fmt.Errorf("jury %s has not reached a verdict yet", juryID)
+	}
+
+	content, err := s.repo.GetContent(jury.ContentID)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, fmt.Errorf("content not found: %s", jury.ContentID)
+	}
+	if content.AuthorID != requesterID {
+		return nil, fmt.Errorf("only content's author may appeal its verdict")
+	}
+
+	appealJury, err := s.conveneJury(content, jury.FlagID, jury.ID, s.cfg.AppealJurySize, s.cfg.AppealQuorum, true)
+	if err != nil {
+		return nil, err
+	}
+
+	s.hub.SendToUser(requesterID, websocket.EventModerationAppeal, map[string]interface{}{
+		"jury_id":        appealJury.ID,
+		"parent_jury_id": jury.ID,
+		"content_id":     content.ID,
+	})
+
+	return appealJury, nil
+}
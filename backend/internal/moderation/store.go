@@ -0,0 +1,33 @@
+package moderation
+
+import "time"
+
+// Store is the persistence Service needs beyond db.Repository.
+// *sqlite.DBManager satisfies it; this package never imports
+// internal/db/sqlite, so there is no cycle - the same split
+// internal/federation.Store uses.
+type Store interface {
+	CreateFlag(flag ContentFlag) error
+	GetFlag(id string) (*ContentFlag, error)
+
+	// CandidateJurors returns up to limit users, excluding excludeIDs,
+	// ordered by ReputationScore descending - the pool SelectJury samples
+	// from.
+	CandidateJurors(excludeIDs []string, limit int) ([]JurorCandidate, error)
+
+	CreateJury(jury Jury) error
+	GetJury(id string) (*Jury, error)
+	AddJuryMembers(juryID string, jurorIDs []string) error
+	IsJuryMember(juryID, jurorID string) (bool, error)
+	MarkJurorVoted(juryID, jurorID string, votedAt time.Time) error
+	JuryMembers(juryID string) ([]JuryMember, error)
+
+	// GetJuryVotes returns jury's jurors' moderation votes on contentID
+	// cast at or after since (jury's CreatedAt), one per juror - their
+	// latest if they voted more than once.
+	GetJuryVotes(juryID, contentID string, since time.Time) ([]JurorVote, error)
+
+	// ResolveJury marks jury resolved and persists verdict in the same
+	// transaction.
+	ResolveJury(juryID string, verdict Verdict) error
+}
@@ -0,0 +1,63 @@
+package observability
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// propagationCarrier adapts a gin request's headers to propagation.TextMapCarrier.
+type propagationCarrier struct{ header *gin.Context }
+
+func (c propagationCarrier) Get(key string) string { return c.header.GetHeader(key) }
+func (c propagationCarrier) Set(key, value string) { c.header.Header(key, value) }
+func (c propagationCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.header.Request.Header))
+	for k := range c.header.Request.Header {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Middleware starts a server span per request named "METHOD /route",
+// extracting an incoming W3C traceparent header (if present) so the span
+// joins whatever trace the caller started, and stores the resulting
+// context on c.Request so downstream handlers, loaders, and the Logger all
+// see it via c.Request.Context(). It also sets "trace_id"/"span_id" gin
+// keys for handlers that want them without importing otel directly.
+func Middleware(p *Provider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(c.Request.Context(), propagationCarrier{c})
+
+		ctx, span := StartSpan(ctx, p.Tracer(), c.FullPath(),
+			semconv.HTTPMethod(c.Request.Method),
+			semconv.HTTPTarget(c.Request.URL.Path),
+			attribute.String("http.client_ip", c.ClientIP()),
+		)
+		defer span.End()
+
+		if traceID, spanID, ok := TraceAndSpanID(ctx); ok {
+			c.Set("trace_id", traceID)
+			c.Set("span_id", spanID)
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, c.Errors.String())
+		}
+	}
+}
+
+// SpanFromGin returns the span Middleware started for c's request, or a
+// no-op span if Middleware never ran.
+func SpanFromGin(c *gin.Context) trace.Span {
+	return trace.SpanFromContext(c.Request.Context())
+}
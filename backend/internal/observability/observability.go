@@ -0,0 +1,148 @@
+// Package observability wires up OpenTelemetry distributed tracing for the
+// API server: a TracerProvider exporting to an OTLP collector, a Gin
+// middleware that starts a server span per request and propagates W3C
+// traceparent headers, and a StartSpan helper the rest of the codebase
+// uses to open child spans against the request's context.
+package observability
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies this package's instrumentation scope to the
+// TracerProvider; every StartSpan call opens its span against the tracer
+// registered under this name.
+const TracerName = "github.com/saint/babel-protocol/backend"
+
+// Config configures the tracer provider. It mirrors config.ObservabilityConfig
+// field-for-field rather than importing it, so this package stays free of a
+// dependency on internal/config.
+type Config struct {
+	Enabled          bool
+	OTLPEndpoint     string
+	ServiceName      string
+	TraceSampleRatio float64
+}
+
+// Provider owns the process's TracerProvider/MeterProvider and the
+// tracer/meter the rest of the codebase opens spans and instruments
+// against. A nil *Provider is safe to call Tracer, Meter, and Shutdown on:
+// Tracer and Meter fall back to the no-op globals and Shutdown is a no-op,
+// so call sites don't need to special-case tracing/metrics being disabled.
+type Provider struct {
+	tp     *sdktrace.TracerProvider
+	tracer trace.Tracer
+	mp     *sdkmetric.MeterProvider
+	meter  metric.Meter
+}
+
+// NewProvider builds a Provider from cfg. When cfg.Enabled is false, it
+// returns a Provider backed by the no-op tracer and meter so instrumented
+// code still works, just without ever exporting a span or metric.
+// Otherwise it dials OTLP/gRPC trace and metric exporters at
+// cfg.OTLPEndpoint and registers itself as the global TracerProvider,
+// MeterProvider, and W3C trace-context propagator.
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	if !cfg.Enabled {
+		return &Provider{tracer: otel.Tracer(TracerName), meter: otel.Meter(TracerName)}, nil
+	}
+
+	traceExporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("observability: creating OTLP trace exporter: %w", err)
+	}
+
+	metricExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint), otlpmetricgrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("observability: creating OTLP metric exporter: %w", err)
+	}
+
+	resource, err := sdkresource.Merge(sdkresource.Default(), sdkresource.NewSchemaless(
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("observability: building resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(resource),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	)
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)),
+		sdkmetric.WithResource(resource),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return &Provider{tp: tp, tracer: tp.Tracer(TracerName), mp: mp, meter: mp.Meter(TracerName)}, nil
+}
+
+// Shutdown flushes and stops the underlying TracerProvider and
+// MeterProvider, if any were started. Call it once at process exit.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	if p.tp != nil {
+		if err := p.tp.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	if p.mp != nil {
+		return p.mp.Shutdown(ctx)
+	}
+	return nil
+}
+
+// Tracer returns the tracer spans should be started against.
+func (p *Provider) Tracer() trace.Tracer {
+	if p == nil || p.tracer == nil {
+		return otel.Tracer(TracerName)
+	}
+	return p.tracer
+}
+
+// Meter returns the meter counters/histograms should be registered against.
+func (p *Provider) Meter() metric.Meter {
+	if p == nil || p.meter == nil {
+		return otel.Meter(TracerName)
+	}
+	return p.meter
+}
+
+// StartSpan opens a child span named name under ctx's span (if any),
+// against tracer, with attrs attached. It's the call every instrumented
+// package (sqlite.DBManager, the Hashgraph handlers) uses so span creation
+// looks the same everywhere.
+func StartSpan(ctx context.Context, tracer trace.Tracer, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// TraceAndSpanID returns the hex-encoded trace and span IDs of the span
+// carried on ctx, if any, for attaching to a log record. ok is false if ctx
+// carries no span or the span context is invalid (e.g. tracing disabled).
+func TraceAndSpanID(ctx context.Context) (traceID, spanID string, ok bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", "", false
+	}
+	return sc.TraceID().String(), sc.SpanID().String(), true
+}
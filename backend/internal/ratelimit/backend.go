@@ -0,0 +1,20 @@
+// Package ratelimit implements reputation-weighted token-bucket rate
+// limiting behind a pluggable Backend, so a single-instance deployment can
+// use an in-memory bucket store and a multi-instance one can share buckets
+// over Redis without any change to the call sites in api/middleware.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Backend is a token-bucket store keyed by an arbitrary string (a user ID,
+// falling back to an IP). Allow atomically refills key's bucket at rps
+// tokens/second up to burst capacity and, if a token is available, takes
+// one and reports allowed=true. Otherwise it reports how long the caller
+// should wait before retrying.
+type Backend interface {
+	Allow(ctx context.Context, key string, rps float64, burst int) (allowed bool, retryAfter time.Duration, err error)
+	Close() error
+}
@@ -0,0 +1,46 @@
+package ratelimit
+
+import "sync"
+
+// concurrencySlots tracks how many requests are currently in flight per
+// route key, enforcing config.RouteLimit.ConcurrencyLimit independently of
+// the QPS token bucket. It's process-local (unlike Backend, which can be
+// shared over Redis) since "how many requests this instance is handling
+// right now" isn't meaningful to share across replicas.
+type concurrencySlots struct {
+	mu    sync.Mutex
+	inUse map[string]uint64
+}
+
+func newConcurrencySlots() *concurrencySlots {
+	return &concurrencySlots{inUse: make(map[string]uint64)}
+}
+
+// acquire takes a slot for routeKey if limit allows it. A limit of 0 means
+// unlimited - acquire always succeeds and there's nothing to release.
+func (s *concurrencySlots) acquire(routeKey string, limit uint64) bool {
+	if limit == 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inUse[routeKey] >= limit {
+		return false
+	}
+	s.inUse[routeKey]++
+	return true
+}
+
+// release gives back a slot taken by a successful acquire. Calling it for a
+// routeKey whose limit was 0 (acquire never incremented anything) is a
+// harmless no-op since inUse never goes negative.
+func (s *concurrencySlots) release(routeKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.inUse[routeKey] > 0 {
+		s.inUse[routeKey]--
+	}
+}
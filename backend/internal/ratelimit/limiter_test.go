@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+func testLimiter(cfg config.RateLimit) *Limiter {
+	cfg.Enabled = true
+	return NewLimiter(cfg, NewInMemoryBackend())
+}
+
+func TestCheckBaseLimit(t *testing.T) {
+	l := testLimiter(config.RateLimit{RequestsPerSecond: 1, Burst: 2})
+
+	for i := 0; i < 2; i++ {
+		allowed, _, err := l.Check(context.Background(), "user-1", "route", 0)
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		if !allowed {
+			t.Fatalf("call %d: got denied, want allowed (burst=2)", i)
+		}
+	}
+
+	allowed, retryAfter, err := l.Check(context.Background(), "user-1", "route", 0)
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if allowed {
+		t.Fatal("got allowed, want denied once burst is exhausted")
+	}
+	if retryAfter <= 0 {
+		t.Errorf("got retryAfter=%v, want a positive wait", retryAfter)
+	}
+}
+
+func TestCheckReputationTierScalesLimit(t *testing.T) {
+	l := testLimiter(config.RateLimit{
+		RequestsPerSecond: 1,
+		Burst:             1,
+		ReputationTiers: []config.ReputationTier{
+			{MinReputation: 0.5, RequestsPerSecond: 10, Burst: 5},
+		},
+	})
+
+	// A low-reputation caller only gets the base burst of 1.
+	for i := 0; i < 2; i++ {
+		allowed, _, _ := l.Check(context.Background(), "low-rep", "route", 0)
+		if i == 0 && !allowed {
+			t.Fatal("first call for low-rep caller: got denied, want allowed")
+		}
+		if i == 1 && allowed {
+			t.Fatal("second call for low-rep caller: got allowed, want denied (base burst=1)")
+		}
+	}
+
+	// A caller meeting the tier gets its larger burst instead.
+	for i := 0; i < 5; i++ {
+		allowed, _, _ := l.Check(context.Background(), "high-rep", "route", 0.9)
+		if !allowed {
+			t.Fatalf("call %d for high-rep caller: got denied, want allowed (tier burst=5)", i)
+		}
+	}
+}
+
+func TestCheckDisabledAlwaysAllows(t *testing.T) {
+	l := NewLimiter(config.RateLimit{Enabled: false, RequestsPerSecond: 1, Burst: 1}, NewInMemoryBackend())
+
+	for i := 0; i < 5; i++ {
+		allowed, _, err := l.Check(context.Background(), "user-1", "route", 0)
+		if err != nil {
+			t.Fatalf("Check: %v", err)
+		}
+		if !allowed {
+			t.Fatalf("call %d: got denied, want allowed (limiter disabled)", i)
+		}
+	}
+}
+
+func TestIsWhitelisted(t *testing.T) {
+	l := testLimiter(config.RateLimit{WhiteList: []string{"10.0.0.1"}})
+
+	if !l.IsWhitelisted("10.0.0.1") {
+		t.Error("got false, want true for a whitelisted IP")
+	}
+	if l.IsWhitelisted("10.0.0.2") {
+		t.Error("got true, want false for a non-whitelisted IP")
+	}
+}
@@ -0,0 +1,98 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// idleEvictAfter bounds how long an idle key's bucket is kept around -
+// without this, a deployment fronted by many short-lived anonymous (IP-
+// keyed) clients would leak one entry per caller forever.
+const idleEvictAfter = 10 * time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// InMemoryBackend is a Backend over a single process's memory - buckets
+// don't survive a restart and aren't shared across instances, but it needs
+// no external dependency, so it's the default for single-instance setups.
+type InMemoryBackend struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	stop    chan struct{}
+}
+
+// NewInMemoryBackend creates an InMemoryBackend and starts its idle-bucket
+// eviction loop.
+func NewInMemoryBackend() *InMemoryBackend {
+	b := &InMemoryBackend{
+		buckets: make(map[string]*bucket),
+		stop:    make(chan struct{}),
+	}
+	go b.evictLoop()
+	return b
+}
+
+// Allow implements Backend.
+func (b *InMemoryBackend) Allow(ctx context.Context, key string, rps float64, burst int) (bool, time.Duration, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	bk, ok := b.buckets[key]
+	if !ok {
+		bk = &bucket{tokens: float64(burst), updatedAt: now}
+		b.buckets[key] = bk
+	}
+
+	elapsed := now.Sub(bk.updatedAt).Seconds()
+	bk.tokens += elapsed * rps
+	if bk.tokens > float64(burst) {
+		bk.tokens = float64(burst)
+	}
+	bk.updatedAt = now
+
+	if bk.tokens >= 1 {
+		bk.tokens--
+		return true, 0, nil
+	}
+
+	deficit := 1 - bk.tokens
+	retryAfter := time.Duration(deficit / rps * float64(time.Second))
+	return false, retryAfter, nil
+}
+
+// Close stops the eviction loop.
+func (b *InMemoryBackend) Close() error {
+	close(b.stop)
+	return nil
+}
+
+func (b *InMemoryBackend) evictLoop() {
+	ticker := time.NewTicker(idleEvictAfter / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.evictIdle()
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+func (b *InMemoryBackend) evictIdle() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	cutoff := time.Now().Add(-idleEvictAfter)
+	for key, bk := range b.buckets {
+		if bk.updatedAt.Before(cutoff) {
+			delete(b.buckets, key)
+		}
+	}
+}
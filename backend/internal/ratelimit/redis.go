@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills the bucket at KEYS[1] to the current time (read
+// from Redis's own clock via TIME, so app-server clock skew can't throw off
+// the refill math) and, if a token is available, takes one. Everything
+// happens in one round trip so concurrent callers sharing a key can't both
+// observe a token and take it. Lua truncates floats returned from a script
+// to integers, so retry_after is computed in whole milliseconds rather than
+// returned as a fractional number of seconds.
+var tokenBucketScript = redis.NewScript(`
+local tokens_key = "tokens"
+local ts_key = "ts"
+local rps = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local bucket = redis.call("HMGET", KEYS[1], tokens_key, ts_key)
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+
+local time_parts = redis.call("TIME")
+local now = tonumber(time_parts[1]) + tonumber(time_parts[2]) / 1000000
+
+if tokens == nil then
+	tokens = burst
+	ts = now
+end
+
+local elapsed = now - ts
+if elapsed < 0 then
+	elapsed = 0
+end
+tokens = math.min(burst, tokens + elapsed * rps)
+
+local allowed = 0
+local retry_after_ms = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	local deficit = 1 - tokens
+	retry_after_ms = math.ceil(deficit / rps * 1000)
+end
+
+redis.call("HSET", KEYS[1], tokens_key, tokens, ts_key, now)
+redis.call("EXPIRE", KEYS[1], math.ceil(burst / rps) + 1)
+
+return {allowed, retry_after_ms}
+`)
+
+// RedisBackend is a Backend over Redis, so every API server instance
+// behind a load balancer enforces the same bucket for a given key instead
+// of each keeping its own.
+type RedisBackend struct {
+	client *redis.Client
+}
+
+// NewRedisBackend connects to the Redis instance at redisURL (a "redis://"
+// URL, as accepted by redis.ParseURL). It takes a plain string rather than
+// internal/config.RedisConfig for the same reason internal/cache.NewRedisBackend
+// does: a config import here would risk cycling back through whatever
+// eventually imports this package.
+func NewRedisBackend(redisURL string) (*RedisBackend, error) {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisBackend{client: redis.NewClient(opts)}, nil
+}
+
+// Allow implements Backend.
+func (b *RedisBackend) Allow(ctx context.Context, key string, rps float64, burst int) (bool, time.Duration, error) {
+	result, err := tokenBucketScript.Run(ctx, b.client, []string{key}, rps, burst).Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("ratelimit: unexpected script result %v", result)
+	}
+
+	allowed, ok := result[0].(int64)
+	if !ok {
+		return false, 0, fmt.Errorf("ratelimit: unexpected allowed value %v", result[0])
+	}
+	retryAfterMs, ok := result[1].(int64)
+	if !ok {
+		return false, 0, fmt.Errorf("ratelimit: unexpected retry_after value %v", result[1])
+	}
+
+	return allowed == 1, time.Duration(retryAfterMs) * time.Millisecond, nil
+}
+
+// Close implements Backend.
+func (b *RedisBackend) Close() error {
+	return b.client.Close()
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/saint/babel-protocol/backend/internal/config"
+)
+
+func TestCheckRouteOverrideReplacesBaseLimit(t *testing.T) {
+	l := testLimiter(config.RateLimit{
+		RequestsPerSecond: 100,
+		Burst:             100,
+		RouteOverrides: map[string]config.RouteLimit{
+			"tight-route": {RequestsPerSecond: 1, Burst: 1},
+		},
+	})
+
+	allowed, _, _ := l.Check(context.Background(), "user-1", "tight-route", 0)
+	if !allowed {
+		t.Fatal("first call on tight-route: got denied, want allowed")
+	}
+	allowed, _, _ = l.Check(context.Background(), "user-1", "tight-route", 0)
+	if allowed {
+		t.Fatal("second call on tight-route: got allowed, want denied (override burst=1)")
+	}
+
+	// The same key on a route with no override still gets the generous
+	// base limit.
+	allowed, _, _ = l.Check(context.Background(), "user-1", "other-route", 0)
+	if !allowed {
+		t.Fatal("call on other-route: got denied, want allowed (no override here)")
+	}
+}
+
+func TestUpdateAndDeleteRouteOverride(t *testing.T) {
+	l := testLimiter(config.RateLimit{RequestsPerSecond: 100, Burst: 100})
+
+	l.Update("route", config.RouteLimit{RequestsPerSecond: 1, Burst: 1})
+
+	allowed, _, _ := l.Check(context.Background(), "user-1", "route", 0)
+	if !allowed {
+		t.Fatal("first call after Update: got denied, want allowed")
+	}
+	allowed, _, _ = l.Check(context.Background(), "user-1", "route", 0)
+	if allowed {
+		t.Fatal("second call after Update: got allowed, want denied (updated burst=1)")
+	}
+
+	l.Delete("route")
+	allowed, _, _ = l.Check(context.Background(), "user-2", "route", 0)
+	if !allowed {
+		t.Fatal("call after Delete: got denied, want allowed (falls back to base burst=100)")
+	}
+}
+
+func TestAcquireConcurrencyLimit(t *testing.T) {
+	l := testLimiter(config.RateLimit{
+		RequestsPerSecond: 100,
+		Burst:             100,
+		RouteOverrides: map[string]config.RouteLimit{
+			"upload": {RequestsPerSecond: 100, Burst: 100, ConcurrencyLimit: 1},
+		},
+	})
+
+	release1, ok := l.AcquireConcurrency("upload")
+	if !ok {
+		t.Fatal("first AcquireConcurrency: got false, want true")
+	}
+
+	if _, ok := l.AcquireConcurrency("upload"); ok {
+		t.Fatal("second concurrent AcquireConcurrency: got true, want false (ConcurrencyLimit=1)")
+	}
+
+	release1()
+
+	if _, ok := l.AcquireConcurrency("upload"); !ok {
+		t.Fatal("AcquireConcurrency after release: got false, want true (slot freed)")
+	}
+}
+
+func TestAcquireConcurrencyUnlimitedByDefault(t *testing.T) {
+	l := testLimiter(config.RateLimit{RequestsPerSecond: 100, Burst: 100})
+
+	for i := 0; i < 10; i++ {
+		if _, ok := l.AcquireConcurrency("no-override-route"); !ok {
+			t.Fatalf("call %d: got false, want true (ConcurrencyLimit=0 means unlimited)", i)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package recommend implements item-based collaborative filtering over a
+// sparse user x item engagement matrix. The math here is pure and
+// DB-independent, mirroring internal/bandit's split between "the
+// algorithm" and whatever orchestrates it against a db.Repository; see
+// Recommender in recommender.go for the orchestration side.
+package recommend
+
+import (
+	"math"
+	"sort"
+)
+
+// Rating is one weighted engagement between a user and an item - a vote,
+// a comment, or a context addition. Multiple ratings for the same
+// (UserID, ItemID) pair are summed by BuildMatrix.
+type Rating struct {
+	UserID string
+	ItemID string
+	Weight float64
+}
+
+// Matrix is a sparse user x item rating matrix: Matrix[userID][itemID].
+type Matrix map[string]map[string]float64
+
+// BuildMatrix sums ratings into a sparse user x item matrix, so e.g. an
+// upvote (+1) and a context add (+0.7) on the same item by the same user
+// combine into a single 1.7 entry rather than overwriting each other.
+func BuildMatrix(ratings []Rating) Matrix {
+	m := make(Matrix)
+	for _, r := range ratings {
+		row, ok := m[r.UserID]
+		if !ok {
+			row = make(map[string]float64)
+			m[r.UserID] = row
+		}
+		row[r.ItemID] += r.Weight
+	}
+	return m
+}
+
+// Neighbor is one item's similarity to another, as stored per item in
+// db.ItemSimilarity.
+type Neighbor struct {
+	ItemID     string
+	Similarity float64
+}
+
+// userMeans returns each user's mean rating across every item they've
+// rated, used by AdjustedCosineSimilarities to correct for the fact that
+// some users rate everything high (or low) regardless of item quality.
+func userMeans(matrix Matrix) map[string]float64 {
+	means := make(map[string]float64, len(matrix))
+	for userID, row := range matrix {
+		var sum float64
+		for _, rating := range row {
+			sum += rating
+		}
+		if len(row) > 0 {
+			means[userID] = sum / float64(len(row))
+		}
+	}
+	return means
+}
+
+// itemRaters inverts matrix into item -> (user -> rating), the shape
+// AdjustedCosineSimilarities iterates over to find each pair's co-raters.
+func itemRaters(matrix Matrix) map[string]map[string]float64 {
+	items := make(map[string]map[string]float64)
+	for userID, row := range matrix {
+		for itemID, rating := range row {
+			raters, ok := items[itemID]
+			if !ok {
+				raters = make(map[string]float64)
+				items[itemID] = raters
+			}
+			raters[userID] = rating
+		}
+	}
+	return items
+}
+
+// AdjustedCosineSimilarities computes item-item similarity for every pair
+// of items in candidateItems that share at least minCoRaters raters,
+// subtracting each user's mean rating (userMeans) to correct for rating
+// bias before taking the cosine of the two item vectors. Only the topK
+// highest-similarity neighbors are kept per item. An item with no
+// sufficiently co-rated neighbor is simply absent from the result.
+func AdjustedCosineSimilarities(matrix Matrix, candidateItems []string, minCoRaters, topK int) map[string][]Neighbor {
+	means := userMeans(matrix)
+	raters := itemRaters(matrix)
+
+	result := make(map[string][]Neighbor, len(candidateItems))
+	for _, i := range candidateItems {
+		var neighbors []Neighbor
+		for _, j := range candidateItems {
+			if i == j {
+				continue
+			}
+			sim, ok := adjustedCosine(raters[i], raters[j], means, minCoRaters)
+			if !ok {
+				continue
+			}
+			neighbors = append(neighbors, Neighbor{ItemID: j, Similarity: sim})
+		}
+
+		sort.Slice(neighbors, func(a, b int) bool { return neighbors[a].Similarity > neighbors[b].Similarity })
+		if len(neighbors) > topK {
+			neighbors = neighbors[:topK]
+		}
+		if len(neighbors) > 0 {
+			result[i] = neighbors
+		}
+	}
+	return result
+}
+
+// adjustedCosine computes the adjusted cosine similarity between two
+// items' rater maps, gated on sharing at least minCoRaters users. ok is
+// false if the pair doesn't meet minCoRaters or either item's vector has
+// zero magnitude over the shared raters (e.g. every co-rater rated it
+// exactly at their own mean).
+func adjustedCosine(ratersI, ratersJ map[string]float64, means map[string]float64, minCoRaters int) (sim float64, ok bool) {
+	var numerator, magI, magJ float64
+	coRaters := 0
+
+	for userID, ri := range ratersI {
+		rj, shared := ratersJ[userID]
+		if !shared {
+			continue
+		}
+		coRaters++
+
+		di := ri - means[userID]
+		dj := rj - means[userID]
+		numerator += di * dj
+		magI += di * di
+		magJ += dj * dj
+	}
+
+	if coRaters < minCoRaters || magI == 0 || magJ == 0 {
+		return 0, false
+	}
+
+	return numerator / (math.Sqrt(magI) * math.Sqrt(magJ)), true
+}
@@ -0,0 +1,119 @@
+package recommend
+
+import "testing"
+
+func TestBuildMatrixSumsRatingsForSameUserItem(t *testing.T) {
+	matrix := BuildMatrix([]Rating{
+		{UserID: "u1", ItemID: "i1", Weight: 1},
+		{UserID: "u1", ItemID: "i1", Weight: 0.7},
+		{UserID: "u1", ItemID: "i2", Weight: -1},
+	})
+
+	if got := matrix["u1"]["i1"]; got != 1.7 {
+		t.Errorf("matrix[u1][i1]: got %v, want 1.7 (upvote + context add combined)", got)
+	}
+	if got := matrix["u1"]["i2"]; got != -1 {
+		t.Errorf("matrix[u1][i2]: got %v, want -1", got)
+	}
+}
+
+// TestAdjustedCosineSimilaritiesIdentifiesPositiveCorrelation builds two
+// items rated in lockstep by every user (same relative deviation from
+// each user's mean) and one rated in the opposite direction, and checks
+// the in-lockstep pair comes out with a strongly positive similarity
+// ranked ahead of the anti-correlated one.
+func TestAdjustedCosineSimilaritiesIdentifiesPositiveCorrelation(t *testing.T) {
+	matrix := Matrix{
+		"u1": {"i1": 1, "i2": 1, "i3": -1},
+		"u2": {"i1": -1, "i2": -1, "i3": 1},
+		"u3": {"i1": 1, "i2": 1, "i3": -1},
+	}
+
+	result := AdjustedCosineSimilarities(matrix, []string{"i1", "i2", "i3"}, 2, 2)
+
+	neighbors, ok := result["i1"]
+	if !ok || len(neighbors) == 0 {
+		t.Fatalf("expected i1 to have neighbors, got %+v", result)
+	}
+	if neighbors[0].ItemID != "i2" {
+		t.Errorf("i1's top neighbor: got %s, want i2 (rated identically by every user)", neighbors[0].ItemID)
+	}
+	if neighbors[0].Similarity <= 0 {
+		t.Errorf("i1/i2 similarity: got %v, want > 0", neighbors[0].Similarity)
+	}
+
+	for _, n := range neighbors {
+		if n.ItemID == "i3" && n.Similarity >= neighbors[0].Similarity {
+			t.Errorf("i3 (anti-correlated with i1) scored %v, want it ranked below i2's %v", n.Similarity, neighbors[0].Similarity)
+		}
+	}
+}
+
+func TestAdjustedCosineSimilaritiesRespectsMinCoRaters(t *testing.T) {
+	matrix := Matrix{
+		"u1": {"i1": 1, "i2": 1},
+	}
+
+	result := AdjustedCosineSimilarities(matrix, []string{"i1", "i2"}, 2, 5)
+	if len(result) != 0 {
+		t.Errorf("got %+v, want no similarities (only 1 co-rater, minCoRaters=2)", result)
+	}
+}
+
+func TestAdjustedCosineSimilaritiesCapsAtTopK(t *testing.T) {
+	// i2 tracks i1 closely; i3 and i4 move opposite to it, so i1 has
+	// three candidate neighbors but only the strongest should survive a
+	// topK of 1.
+	matrix := Matrix{
+		"u1": {"i1": 5, "i2": 4, "i3": 3, "i4": 1},
+		"u2": {"i1": 4, "i2": 5, "i3": 2, "i4": 2},
+		"u3": {"i1": 3, "i2": 3, "i3": 5, "i4": 5},
+	}
+
+	result := AdjustedCosineSimilarities(matrix, []string{"i1", "i2", "i3", "i4"}, 2, 1)
+	neighbors := result["i1"]
+	if len(neighbors) != 1 {
+		t.Fatalf("i1 neighbors: got %d, want 1 (topK=1)", len(neighbors))
+	}
+	if neighbors[0].ItemID != "i2" {
+		t.Errorf("i1's sole neighbor: got %s, want i2 (strongest positive correlation)", neighbors[0].ItemID)
+	}
+}
+
+func TestPredictWeightsByNeighborSimilarity(t *testing.T) {
+	matrix := Matrix{
+		"u1": {"i2": 4, "i3": 2},
+	}
+	neighbors := []Neighbor{
+		{ItemID: "i2", Similarity: 0.8},
+		{ItemID: "i3", Similarity: 0.2},
+	}
+
+	got, ok := Predict(matrix, neighbors, "u1")
+	if !ok {
+		t.Fatal("Predict: got ok=false, want true")
+	}
+
+	want := (0.8*4 + 0.2*2) / (0.8 + 0.2)
+	if got != want {
+		t.Errorf("Predict: got %v, want %v", got, want)
+	}
+}
+
+func TestPredictFalseWhenUserRatedNoNeighbors(t *testing.T) {
+	matrix := Matrix{"u1": {"i9": 5}}
+	neighbors := []Neighbor{{ItemID: "i2", Similarity: 0.8}}
+
+	if _, ok := Predict(matrix, neighbors, "u1"); ok {
+		t.Error("Predict: got ok=true, want false (user never rated i2)")
+	}
+}
+
+func TestPredictFalseForUnknownUser(t *testing.T) {
+	matrix := Matrix{"u1": {"i2": 5}}
+	neighbors := []Neighbor{{ItemID: "i2", Similarity: 0.8}}
+
+	if _, ok := Predict(matrix, neighbors, "ghost"); ok {
+		t.Error("Predict: got ok=true, want false for a user absent from the matrix")
+	}
+}
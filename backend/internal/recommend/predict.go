@@ -0,0 +1,35 @@
+package recommend
+
+// Predict estimates user's rating of item from their ratings of item's
+// neighbors: p(u,i) = sum(sim(i,j) * r(u,j)) / sum(|sim(i,j)|), over the
+// neighbors j the user has actually rated. ok is false if the user has
+// rated none of item's neighbors, in which case the caller has no signal
+// to rank item for this user.
+func Predict(matrix Matrix, neighbors []Neighbor, userID string) (prediction float64, ok bool) {
+	userRatings := matrix[userID]
+	if len(userRatings) == 0 {
+		return 0, false
+	}
+
+	var numerator, denominator float64
+	for _, n := range neighbors {
+		rating, rated := userRatings[n.ItemID]
+		if !rated {
+			continue
+		}
+		numerator += n.Similarity * rating
+		denominator += abs(n.Similarity)
+	}
+
+	if denominator == 0 {
+		return 0, false
+	}
+	return numerator / denominator, true
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,300 @@
+package recommend
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/cache"
+	"github.com/saint/babel-protocol/backend/internal/db"
+)
+
+// Engagement weights for BuildMatrix. Comment weight is defined for
+// parity with the spec this recommender implements, but votes and
+// context posts are the only two engagement types db.Repository
+// currently persists in a form this package can replay - comments aren't
+// (see api/models.Comment, which has no backing Repository methods yet) -
+// so commentWeight has no caller until that lands.
+const (
+	upvoteWeight     = 1.0
+	downvoteWeight   = -1.0
+	commentWeight    = 0.5
+	contextAddWeight = 0.7
+)
+
+// voteWeight maps a vote's type to its flat engagement weight. Vote types
+// outside upvote/downvote (affirm, deny, engage, unengage) carry no
+// engagement signal for this recommender; ok is false for those so
+// callers can skip them rather than silently scoring them as zero.
+func voteWeight(voteType string) (weight float64, ok bool) {
+	switch voteType {
+	case models.VoteTypeUpvote:
+		return upvoteWeight, true
+	case models.VoteTypeDownvote:
+		return downvoteWeight, true
+	default:
+		return 0, false
+	}
+}
+
+// Config tunes the recommender's gating and output size.
+type Config struct {
+	// MinCoRaters is the minimum number of shared raters two items need
+	// before AdjustedCosineSimilarities will consider them similar.
+	MinCoRaters int
+	// TopK is how many neighbors are kept per item.
+	TopK int
+	// MinInteractions is how many items a user must have rated before
+	// Recommend trusts CF predictions over the trending fallback.
+	MinInteractions int
+	// RefreshWindow bounds how far back Refresh looks for content to
+	// (re)index - content older than this never enters the similarity
+	// matrix, keeping a refresh cheap as the corpus grows.
+	RefreshWindow time.Duration
+}
+
+// DefaultConfig matches the thresholds from the recommender's design doc:
+// 3 shared raters to trust a similarity, top 20 neighbors per item, a
+// 5-interaction floor before trusting CF over trending, reindexing the
+// last 90 days of activity.
+func DefaultConfig() Config {
+	return Config{
+		MinCoRaters:     3,
+		TopK:            20,
+		MinInteractions: 5,
+		RefreshWindow:   90 * 24 * time.Hour,
+	}
+}
+
+// Recommender is an item-based collaborative filtering recommender over
+// db.Repository's engagement data, with Refresh precomputing item-item
+// similarities into the item_similarities table and Recommend serving
+// predictions (or a trending fallback) from it.
+type Recommender struct {
+	repo   db.Repository
+	scores *cache.Cache
+	cfg    Config
+}
+
+// NewRecommender builds a Recommender against repo's engagement data.
+// scores supplies each candidate's TruthScore (falling back to the
+// content row's own TruthScore field on a cache miss) so low-truth
+// content is down-weighted in ranking.
+func NewRecommender(repo db.Repository, scores *cache.Cache, cfg Config) *Recommender {
+	return &Recommender{repo: repo, scores: scores, cfg: cfg}
+}
+
+// Recommendation is one scored candidate item.
+type Recommendation struct {
+	ContentID string
+	Score     float64
+	// Source is "predicted" when Score came from a CF prediction
+	// combined with TruthScore, or "trending" when the user fell below
+	// MinInteractions and got the cold-start fallback instead.
+	Source string
+}
+
+// Refresh rebuilds item-item similarities for every candidate item
+// touched within cfg.RefreshWindow. It recomputes the whole window's
+// pairwise similarities rather than only the items that changed since the
+// last run - true incremental recompute would need a persisted dirty-row
+// log, which nothing upstream of this package currently writes - but
+// RefreshWindow keeps each run bounded to recent activity instead of the
+// full corpus, so it stays tractable as older content accumulates.
+func (r *Recommender) Refresh(ctx context.Context) error {
+	since := time.Now().Add(-r.cfg.RefreshWindow)
+	content, err := r.repo.GetRecentContent(since)
+	if err != nil {
+		return err
+	}
+
+	ratings, itemIDs := buildRatingsFromContent(content)
+
+	contentVotes, err := r.repo.GetContentVotesByContentIDs(itemIDs)
+	if err != nil {
+		return err
+	}
+	for itemID, votes := range contentVotes {
+		for _, v := range votes {
+			weight, ok := voteWeight(v.VoteType)
+			if !ok {
+				continue
+			}
+			ratings = append(ratings, Rating{UserID: v.VoterID, ItemID: itemID, Weight: weight})
+		}
+	}
+
+	matrix := BuildMatrix(ratings)
+	similarities := AdjustedCosineSimilarities(matrix, itemIDs, r.cfg.MinCoRaters, r.cfg.TopK)
+
+	for itemID, neighbors := range similarities {
+		if err := r.repo.UpsertItemSimilarities(itemID, toStoredNeighbors(neighbors)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildRatingsFromContent turns content's context-add events (a user
+// adding context to a parent item is a +contextAddWeight rating of that
+// parent) into Ratings, and returns the deduplicated set of non-context
+// item IDs Refresh should compute similarities over.
+func buildRatingsFromContent(content []*models.Content) (ratings []Rating, itemIDs []string) {
+	seen := make(map[string]bool, len(content))
+	for _, c := range content {
+		if c.ContentType == "context" {
+			if c.ParentID != nil {
+				ratings = append(ratings, Rating{UserID: c.AuthorID, ItemID: *c.ParentID, Weight: contextAddWeight})
+			}
+			continue
+		}
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			itemIDs = append(itemIDs, c.ID)
+		}
+	}
+	return ratings, itemIDs
+}
+
+func toStoredNeighbors(neighbors []Neighbor) []db.ItemSimilarity {
+	stored := make([]db.ItemSimilarity, len(neighbors))
+	for i, n := range neighbors {
+		stored[i] = db.ItemSimilarity{NeighborID: n.ItemID, Similarity: n.Similarity}
+	}
+	return stored
+}
+
+// Recommend returns userID's top topN recommendations. A user with fewer
+// than cfg.MinInteractions rated items has no reliable CF signal and
+// instead gets trending() - cfg.RefreshWindow's candidate items ranked by
+// TruthScore*VisibilityScore.
+func (r *Recommender) Recommend(ctx context.Context, userID string, topN int) ([]Recommendation, error) {
+	userRatings, err := r.userRatings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := r.repo.GetRecentContent(time.Now().Add(-r.cfg.RefreshWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(userRatings) < r.cfg.MinInteractions {
+		return r.trending(candidates, userRatings, topN), nil
+	}
+
+	matrix := BuildMatrix(userRatings)
+	rated := matrix[userID]
+
+	var scored []Recommendation
+	for _, c := range candidates {
+		if _, isRated := rated[c.ID]; c.ContentType == "context" || isRated {
+			continue
+		}
+		neighbors, err := r.repo.GetItemSimilarities(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(neighbors) == 0 {
+			continue
+		}
+		prediction, ok := Predict(matrix, toNeighbors(neighbors), userID)
+		if !ok {
+			continue
+		}
+		scored = append(scored, Recommendation{
+			ContentID: c.ID,
+			Score:     prediction * r.truthScore(ctx, c),
+			Source:    "predicted",
+		})
+	}
+
+	if len(scored) == 0 {
+		return r.trending(candidates, userRatings, topN), nil
+	}
+
+	sort.Slice(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+	if len(scored) > topN {
+		scored = scored[:topN]
+	}
+	return scored, nil
+}
+
+// userRatings builds userID's own Rating list from every vote they've
+// cast and every context post they've authored, all time (since the
+// zero time), the same weighting Refresh uses for the corpus-wide matrix.
+func (r *Recommender) userRatings(userID string) ([]Rating, error) {
+	var ratings []Rating
+
+	votes, err := r.repo.GetUserVotes(userID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range votes {
+		weight, ok := voteWeight(v.Type)
+		if !ok {
+			continue
+		}
+		ratings = append(ratings, Rating{UserID: userID, ItemID: v.ContentID, Weight: weight})
+	}
+
+	contexts, err := r.repo.GetUserContexts(userID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range contexts {
+		if c.ParentID == nil {
+			continue
+		}
+		ratings = append(ratings, Rating{UserID: userID, ItemID: *c.ParentID, Weight: contextAddWeight})
+	}
+
+	return ratings, nil
+}
+
+// trending ranks candidates by TruthScore*VisibilityScore, skipping
+// anything the user has already rated (when ratings are known) and
+// content posts, which aren't recommendable items themselves.
+func (r *Recommender) trending(candidates []*models.Content, userRatings []Rating, topN int) []Recommendation {
+	rated := make(map[string]bool, len(userRatings))
+	for _, rt := range userRatings {
+		rated[rt.ItemID] = true
+	}
+
+	var trending []Recommendation
+	for _, c := range candidates {
+		if c.ContentType == "context" || rated[c.ID] {
+			continue
+		}
+		trending = append(trending, Recommendation{
+			ContentID: c.ID,
+			Score:     c.TruthScore * c.VisibilityScore,
+			Source:    "trending",
+		})
+	}
+
+	sort.Slice(trending, func(i, j int) bool { return trending[i].Score > trending[j].Score })
+	if len(trending) > topN {
+		trending = trending[:topN]
+	}
+	return trending
+}
+
+// truthScore prefers the cached TruthScore (kept fresh by whatever last
+// scored this content) and falls back to the content row's own field on
+// a cache miss.
+func (r *Recommender) truthScore(ctx context.Context, c *models.Content) float64 {
+	if scores, hit := r.scores.GetContentScores(ctx, c.ID); hit {
+		return scores.TruthScore
+	}
+	return c.TruthScore
+}
+
+func toNeighbors(stored []db.ItemSimilarity) []Neighbor {
+	neighbors := make([]Neighbor, len(stored))
+	for i, s := range stored {
+		neighbors[i] = Neighbor{ItemID: s.NeighborID, Similarity: s.Similarity}
+	}
+	return neighbors
+}
@@ -0,0 +1,16 @@
+package storage
+
+// defaultDatastore is the Datastore used by the legacy api package's
+// free-function handlers, which (unlike the handlers in api/handlers) take
+// no constructor-injected dependencies. Mirrors alert.SetDefault/alert.Raise.
+var defaultDatastore Datastore = NewMemoryDatastore()
+
+// SetDefault replaces the package-level Datastore used by Default.
+func SetDefault(ds Datastore) {
+	defaultDatastore = ds
+}
+
+// Default returns the package-level Datastore.
+func Default() Datastore {
+	return defaultDatastore
+}
@@ -0,0 +1,94 @@
+package storage
+
+// FindKeyValueAndRollback opens a session, looks up key, and rolls the
+// session back. Use for read-only lookups so handlers don't have to repeat
+// the Begin/defer-Rollback boilerplate.
+func FindKeyValueAndRollback(ds Datastore, key string) (string, error) {
+	session, err := ds.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer session.Rollback()
+
+	return session.FindKeyValue(key)
+}
+
+// FindUserAndRollback opens a session, looks up username, and rolls the
+// session back.
+func FindUserAndRollback(ds Datastore, username string) (*User, error) {
+	session, err := ds.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Rollback()
+
+	return session.FindUser(username)
+}
+
+// FindPostAndRollback opens a session, looks up id, and rolls the session
+// back.
+func FindPostAndRollback(ds Datastore, id string) (*Post, error) {
+	session, err := ds.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer session.Rollback()
+
+	return session.FindPost(id)
+}
+
+// PersistPostAndCommit opens a session, inserts post, and commits.
+func PersistPostAndCommit(ds Datastore, post *Post) error {
+	session, err := ds.Begin()
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	if err := session.InsertPost(post); err != nil {
+		return err
+	}
+	return session.Commit()
+}
+
+// PersistCommentAndCommit opens a session, inserts comment, and commits.
+func PersistCommentAndCommit(ds Datastore, comment *Comment) error {
+	session, err := ds.Begin()
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	if err := session.InsertComment(comment); err != nil {
+		return err
+	}
+	return session.Commit()
+}
+
+// RecordVoteAndCommit opens a session, records vote, and commits.
+func RecordVoteAndCommit(ds Datastore, vote *Vote) error {
+	session, err := ds.Begin()
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	if err := session.RecordVote(vote); err != nil {
+		return err
+	}
+	return session.Commit()
+}
+
+// UpsertUserAndCommit opens a session, upserts user, and commits.
+func UpsertUserAndCommit(ds Datastore, user *User) error {
+	session, err := ds.Begin()
+	if err != nil {
+		return err
+	}
+	defer session.Rollback()
+
+	if err := session.UpsertUser(user); err != nil {
+		return err
+	}
+	return session.Commit()
+}
@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MemoryDatastore is an in-memory Datastore for tests. Sessions serialize
+// against a single mutex and stage their writes locally, applying them to
+// the shared maps only on Commit.
+type MemoryDatastore struct {
+	mu       sync.Mutex
+	users    map[string]*User
+	posts    map[string]*Post
+	comments map[string]*Comment
+	votes    map[string]*Vote
+	kv       map[string]string
+}
+
+// NewMemoryDatastore creates an empty MemoryDatastore.
+func NewMemoryDatastore() *MemoryDatastore {
+	return &MemoryDatastore{
+		users:    make(map[string]*User),
+		posts:    make(map[string]*Post),
+		comments: make(map[string]*Comment),
+		votes:    make(map[string]*Vote),
+		kv:       make(map[string]string),
+	}
+}
+
+// Begin locks the datastore for the duration of the session, mirroring the
+// isolation a real transactional backend would give a caller.
+func (d *MemoryDatastore) Begin() (Session, error) {
+	d.mu.Lock()
+	return &memorySession{
+		ds:       d,
+		users:    map[string]*User{},
+		posts:    map[string]*Post{},
+		comments: map[string]*Comment{},
+		votes:    map[string]*Vote{},
+		kv:       map[string]string{},
+	}, nil
+}
+
+type memorySession struct {
+	ds   *MemoryDatastore
+	done bool
+
+	users    map[string]*User
+	posts    map[string]*Post
+	comments map[string]*Comment
+	votes    map[string]*Vote
+	kv       map[string]string
+}
+
+func (s *memorySession) FindUser(username string) (*User, error) {
+	if user, ok := s.users[username]; ok {
+		return user, nil
+	}
+	if user, ok := s.ds.users[username]; ok {
+		return user, nil
+	}
+	return nil, nil
+}
+
+func (s *memorySession) UpsertUser(user *User) error {
+	s.users[user.Username] = user
+	return nil
+}
+
+func (s *memorySession) InsertPost(post *Post) error {
+	if _, exists := s.ds.posts[post.ID]; exists {
+		return fmt.Errorf("storage: post %s already exists", post.ID)
+	}
+	s.posts[post.ID] = post
+	return nil
+}
+
+func (s *memorySession) FindPost(id string) (*Post, error) {
+	if post, ok := s.posts[id]; ok {
+		return post, nil
+	}
+	if post, ok := s.ds.posts[id]; ok {
+		return post, nil
+	}
+	return nil, nil
+}
+
+func (s *memorySession) InsertComment(comment *Comment) error {
+	if _, exists := s.ds.comments[comment.ID]; exists {
+		return fmt.Errorf("storage: comment %s already exists", comment.ID)
+	}
+	s.comments[comment.ID] = comment
+	return nil
+}
+
+func (s *memorySession) RecordVote(vote *Vote) error {
+	s.votes[vote.ID] = vote
+	return nil
+}
+
+func (s *memorySession) FindKeyValue(key string) (string, error) {
+	if value, ok := s.kv[key]; ok {
+		return value, nil
+	}
+	return s.ds.kv[key], nil
+}
+
+func (s *memorySession) UpsertKeyValue(key, value string) error {
+	s.kv[key] = value
+	return nil
+}
+
+func (s *memorySession) Commit() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+	defer s.ds.mu.Unlock()
+
+	for k, v := range s.users {
+		s.ds.users[k] = v
+	}
+	for k, v := range s.posts {
+		s.ds.posts[k] = v
+	}
+	for k, v := range s.comments {
+		s.ds.comments[k] = v
+	}
+	for k, v := range s.votes {
+		s.ds.votes[k] = v
+	}
+	for k, v := range s.kv {
+		s.ds.kv[k] = v
+	}
+	return nil
+}
+
+func (s *memorySession) Rollback() error {
+	if s.done {
+		return nil
+	}
+	s.done = true
+	s.ds.mu.Unlock()
+	return nil
+}
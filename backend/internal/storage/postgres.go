@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"database/sql"
+)
+
+// PostgresDatastore is a Postgres-backed Datastore. Callers are expected to
+// open db with the "postgres" driver (e.g. github.com/lib/pq) registered.
+type PostgresDatastore struct {
+	db *sql.DB
+}
+
+// NewPostgresDatastore wraps an already-opened *sql.DB.
+func NewPostgresDatastore(db *sql.DB) *PostgresDatastore {
+	return &PostgresDatastore{db: db}
+}
+
+func (d *PostgresDatastore) Begin() (Session, error) {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &postgresSession{tx: tx}, nil
+}
+
+type postgresSession struct {
+	tx        *sql.Tx
+	committed bool
+}
+
+func (s *postgresSession) FindUser(username string) (*User, error) {
+	user := &User{}
+	row := s.tx.QueryRow(
+		`SELECT id, username, password_hash, bio, created_at FROM users WHERE username = $1`,
+		username,
+	)
+	if err := row.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.Bio, &user.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *postgresSession) UpsertUser(user *User) error {
+	_, err := s.tx.Exec(
+		`INSERT INTO users (id, username, password_hash, bio, created_at)
+		 VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (username) DO UPDATE
+		 SET password_hash = EXCLUDED.password_hash, bio = EXCLUDED.bio`,
+		user.ID, user.Username, user.PasswordHash, user.Bio, user.CreatedAt,
+	)
+	return err
+}
+
+func (s *postgresSession) InsertPost(post *Post) error {
+	_, err := s.tx.Exec(
+		`INSERT INTO posts (id, author_id, text, created_at) VALUES ($1, $2, $3, $4)`,
+		post.ID, post.AuthorID, post.Text, post.CreatedAt,
+	)
+	return err
+}
+
+func (s *postgresSession) FindPost(id string) (*Post, error) {
+	post := &Post{}
+	row := s.tx.QueryRow(
+		`SELECT id, author_id, text, created_at FROM posts WHERE id = $1`,
+		id,
+	)
+	if err := row.Scan(&post.ID, &post.AuthorID, &post.Text, &post.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return post, nil
+}
+
+func (s *postgresSession) InsertComment(comment *Comment) error {
+	_, err := s.tx.Exec(
+		`INSERT INTO comments (id, post_id, author_id, text, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		comment.ID, comment.PostID, comment.AuthorID, comment.Text, comment.CreatedAt,
+	)
+	return err
+}
+
+func (s *postgresSession) RecordVote(vote *Vote) error {
+	_, err := s.tx.Exec(
+		`INSERT INTO votes (id, post_id, user_id, type, created_at) VALUES ($1, $2, $3, $4, $5)`,
+		vote.ID, vote.PostID, vote.UserID, vote.Type, vote.CreatedAt,
+	)
+	return err
+}
+
+func (s *postgresSession) FindKeyValue(key string) (string, error) {
+	var value string
+	row := s.tx.QueryRow(`SELECT value FROM kv_store WHERE key = $1`, key)
+	if err := row.Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return value, nil
+}
+
+func (s *postgresSession) UpsertKeyValue(key, value string) error {
+	_, err := s.tx.Exec(
+		`INSERT INTO kv_store (key, value) VALUES ($1, $2)
+		 ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value`,
+		key, value,
+	)
+	return err
+}
+
+func (s *postgresSession) Commit() error {
+	if s.committed {
+		return nil
+	}
+	s.committed = true
+	return s.tx.Commit()
+}
+
+// Rollback is a no-op once Commit has succeeded, so callers can always
+// `defer session.Rollback()` right after Begin.
+func (s *postgresSession) Rollback() error {
+	if s.committed {
+		return nil
+	}
+	return s.tx.Rollback()
+}
@@ -0,0 +1,66 @@
+// Package storage defines a transactional datastore abstraction for the
+// legacy api package's user/post/comment/vote data, replacing the
+// append-only YAML file the original seeder script wrote to.
+package storage
+
+import "time"
+
+// Datastore opens transactional Sessions against a backing store.
+type Datastore interface {
+	Begin() (Session, error)
+}
+
+// Session is a single transaction. Callers must call exactly one of Commit
+// or Rollback; Rollback is safe to call after a successful Commit (it's a
+// no-op), which is what lets callers always `defer session.Rollback()`.
+type Session interface {
+	FindUser(username string) (*User, error)
+	UpsertUser(user *User) error
+
+	InsertPost(post *Post) error
+	FindPost(id string) (*Post, error)
+
+	InsertComment(comment *Comment) error
+	RecordVote(vote *Vote) error
+
+	FindKeyValue(key string) (string, error)
+	UpsertKeyValue(key, value string) error
+
+	Commit() error
+	Rollback() error
+}
+
+// User is a registered account.
+type User struct {
+	ID           string
+	Username     string
+	PasswordHash string
+	Bio          string
+	CreatedAt    time.Time
+}
+
+// Post is a user-authored piece of content.
+type Post struct {
+	ID        string
+	AuthorID  string
+	Text      string
+	CreatedAt time.Time
+}
+
+// Comment is a reply to a Post.
+type Comment struct {
+	ID        string
+	PostID    string
+	AuthorID  string
+	Text      string
+	CreatedAt time.Time
+}
+
+// Vote records a like or dislike on a Post.
+type Vote struct {
+	ID        string
+	PostID    string
+	UserID    string
+	Type      string // "like" or "dislike"
+	CreatedAt time.Time
+}
@@ -2,6 +2,7 @@ package user
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,47 +27,56 @@ func NewAlgorithmClient(baseURL string) *AlgorithmClient {
 	}
 }
 
-// AnalyzeUserActivity sends user activity data to the algorithm service for analysis
+// AnalyzeUserActivity sends user activity data to the algorithm service for
+// analysis. It's equivalent to AnalyzeUserActivityCtx(context.Background(), req).
 func (c *AlgorithmClient) AnalyzeUserActivity(req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
-	jsonData, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request: %v", err)
-	}
+	return c.AnalyzeUserActivityCtx(context.Background(), req)
+}
 
-	resp, err := c.httpClient.Post(
-		fmt.Sprintf("%s/analyze/user/activity", c.baseURL),
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %v", err)
-	}
-	defer resp.Body.Close()
+// AnalyzeUserActivityCtx sends user activity data to the algorithm service
+// for analysis. The call is canceled as soon as ctx is, so a caller driven
+// by an inbound request (e.g. UpdateReputationHandler) can give up on a
+// slow algorithm service instead of holding the connection open.
+func (c *AlgorithmClient) AnalyzeUserActivityCtx(ctx context.Context, req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
+	return c.post(ctx, "/analyze/user/activity", req)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("algorithm service returned status %d", resp.StatusCode)
-	}
+// AnalyzeUserAuthenticity sends user verification data to the algorithm
+// service. It's equivalent to AnalyzeUserAuthenticityCtx(context.Background(), req).
+func (c *AlgorithmClient) AnalyzeUserAuthenticity(req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
+	return c.AnalyzeUserAuthenticityCtx(context.Background(), req)
+}
 
-	var result models.AlgorithmResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
-	}
+// AnalyzeUserAuthenticityCtx sends user verification data to the algorithm
+// service, canceling the outbound call as soon as ctx is.
+func (c *AlgorithmClient) AnalyzeUserAuthenticityCtx(ctx context.Context, req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
+	return c.post(ctx, "/analyze/user/authenticity", req)
+}
 
-	return &result, nil
+// AnalyzeUserActivityBatchCtx sends a coalesced batch of dirty users to
+// the algorithm service in one round trip - the batched counterpart to
+// AnalyzeUserActivityCtx the score-update pipeline (pipeline.go) uses
+// instead of one call per user.
+func (c *AlgorithmClient) AnalyzeUserActivityBatchCtx(ctx context.Context, req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
+	return c.post(ctx, "/analyze/user/activity/batch", req)
 }
 
-// AnalyzeUserAuthenticity sends user verification data to the algorithm service
-func (c *AlgorithmClient) AnalyzeUserAuthenticity(req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
+// post marshals req, POSTs it to path under baseURL with ctx bound to the
+// request so it's aborted if ctx is canceled or times out, and decodes the
+// response.
+func (c *AlgorithmClient) post(ctx context.Context, path string, req *models.AlgorithmRequest) (*models.AlgorithmResponse, error) {
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %v", err)
 	}
 
-	resp, err := c.httpClient.Post(
-		fmt.Sprintf("%s/analyze/user/authenticity", c.baseURL),
-		"application/json",
-		bytes.NewBuffer(jsonData),
-	)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send request: %v", err)
 	}
@@ -1,66 +1,143 @@
 package user
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/saint/babel-protocol/backend/api/models"
 	"github.com/saint/babel-protocol/backend/internal/cache"
-	"github.com/saint/babel-protocol/backend/internal/db/sqlite"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/internal/decisions"
+	"github.com/saint/babel-protocol/backend/internal/observability"
 )
 
+var userTracer = otel.Tracer(observability.TracerName)
+
+// authenticityThrottleThreshold is the AuthenticityScore below which
+// UpdateUserAuthenticity feeds a throttle Decision for the user, rather
+// than waiting for them to trip a moderation flag on their own.
+const authenticityThrottleThreshold = 0.3
+
+// authenticityThrottleTTL bounds how long that throttle decision stays in
+// effect before the user's next authenticity check can re-evaluate it.
+const authenticityThrottleTTL = time.Hour
+
 // Manager handles user-related operations and score updates
 type Manager struct {
-	db    *sqlite.DBManager
-	algo  *AlgorithmClient
-	cache *cache.Cache
+	db     db.Repository
+	algo   *AlgorithmClient
+	cache  *cache.Cache
+	feeder decisions.DecisionFeeder
+	store  Store
+	*pipeline
 }
 
 // NewManager creates a new user manager
-func NewManager(db *sqlite.DBManager, algoURL string) *Manager {
+func NewManager(db db.Repository, algoURL string) *Manager {
 	return &Manager{
-		db:    db,
-		algo:  NewAlgorithmClient(algoURL),
-		cache: cache.NewCache(5 * time.Minute),
+		db:       db,
+		algo:     NewAlgorithmClient(algoURL),
+		cache:    cache.NewInMemoryCache(5 * time.Minute),
+		pipeline: newPipeline(),
 	}
 }
 
-// UpdateUserScores updates a user's scores based on their recent activity
-func (m *Manager) UpdateUserScores(userID string) error {
-	// Check cache first
-	if _, exists := m.cache.GetUserScores(userID); exists {
+// SetDecisionFeeder wires feeder into the manager so UpdateUserAuthenticity
+// can push a throttle decision when a user's score drops, the same
+// optional-dependency pattern websocket.WebSocketHub's SetMetrics/
+// SetEventDispatcher use.
+func (m *Manager) SetDecisionFeeder(feeder decisions.DecisionFeeder) {
+	m.feeder = feeder
+}
+
+// SetStore wires store into the manager so the admin override/audit API
+// (GetScores, OverrideScores, OverrideAuthenticity, Recompute, Audit) has
+// somewhere to persist and read from - same optional-dependency pattern
+// as SetDecisionFeeder.
+func (m *Manager) SetStore(store Store) {
+	m.store = store
+}
+
+// UpdateUserScores refreshes a user's scores based on their recent
+// activity. It no longer calls the algorithm service itself: past the
+// same cache-freshness/too-soon gates the synchronous version used to
+// apply up front, it enqueues the user onto the batch pipeline (see
+// pipeline.go) and waits for that user's batch to land, so fan-out from
+// handlers like LikePostHandler/AddCommentHandler coalesces into shared
+// AlgorithmClient round trips instead of one HTTP call each. Callers that
+// don't need to wait should call Enqueue directly.
+func (m *Manager) UpdateUserScores(ctx context.Context, userID string) error {
+	ctx, span := observability.StartSpan(ctx, userTracer, "user.UpdateUserScores", attribute.String("user.id", userID))
+	defer span.End()
+
+	if _, _, exists := m.cache.GetUserScores(ctx, userID); exists {
 		return nil // Scores are fresh enough
 	}
 
-	// Get user's recent activity
 	user, err := m.db.GetUser(userID)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %v", err)
 	}
-
-	lastUpdate := user.LastActive
-	if time.Since(lastUpdate) < time.Minute {
+	if user == nil {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+	if time.Since(user.LastActive) < time.Minute {
 		return nil // Too soon to update
 	}
 
-	// Get only new activity since last update
+	waitCh := m.registerWaiter(userID)
+	if err := m.Enqueue(userID, "manual_update"); err != nil {
+		m.unregisterWaiter(userID, waitCh)
+		return err
+	}
+
+	select {
+	case <-waitCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Recompute forces a single-user algorithm-service round trip for userID,
+// bypassing both the pipeline's debounce window and UpdateUserScores'
+// cache-freshness/too-soon gates - a moderator-triggered escape hatch for
+// when those are stale (e.g. right after an OverrideScores call, or
+// while chasing a live abuse incident) rather than something the normal
+// request path should do.
+func (m *Manager) Recompute(ctx context.Context, userID string) error {
+	ctx, span := observability.StartSpan(ctx, userTracer, "user.Recompute", attribute.String("user.id", userID))
+	defer span.End()
+
+	user, err := m.db.GetUser(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	lastUpdate := user.LastActive
 	activities, err := m.db.GetUserActivities(userID, lastUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to get user activities: %v", err)
 	}
-
 	votes, err := m.db.GetUserVotes(userID, lastUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to get user votes: %v", err)
 	}
-
 	evidence, err := m.db.GetUserEvidence(userID, lastUpdate)
 	if err != nil {
 		return fmt.Errorf("failed to get user evidence: %v", err)
 	}
 
-	// Send activity for analysis
-	result, err := m.algo.AnalyzeUserActivity(&models.AlgorithmRequest{
+	result, err := m.algo.AnalyzeUserActivityCtx(ctx, &models.AlgorithmRequest{
 		Type:   "user_analysis_incremental",
 		UserID: userID,
 		Parameters: map[string]interface{}{
@@ -76,50 +153,70 @@ func (m *Manager) UpdateUserScores(userID string) error {
 		},
 		Timestamp: time.Now(),
 	})
-
 	if err != nil {
 		return fmt.Errorf("failed to analyze user activity: %v", err)
 	}
-
 	if result.Status != "success" {
 		return fmt.Errorf("analysis failed: %v", result.Error)
 	}
 
-	// Update user scores
-	if scores, ok := result.Results["scores"].(map[string]interface{}); ok {
-		user.TruthAccuracy = getFloat64(scores, "truth_accuracy")
-		user.EvidenceQuality = getFloat64(scores, "evidence_quality")
-		user.EngagementQuality = getFloat64(scores, "engagement_quality")
-		user.CommunityScore = getFloat64(scores, "community_score")
+	scores, ok := result.Results["scores"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return m.applyUserScores(ctx, userID, scores)
+}
 
-		// Calculate overall reputation
-		user.ReputationScore = calculateReputationScore(map[string]float64{
-			"truth_accuracy":     user.TruthAccuracy,
-			"evidence_quality":   user.EvidenceQuality,
-			"engagement_quality": user.EngagementQuality,
-			"community_score":    user.CommunityScore,
-		})
+// applyUserScores writes an algorithm-service score result (single-user
+// from Recompute, or one user's slice of a batch from dispatchBatch) back
+// to the database and cache, recalculating ReputationScore through
+// calculateReputationScore so both paths stay consistent.
+func (m *Manager) applyUserScores(ctx context.Context, userID string, scores map[string]interface{}) error {
+	user, err := m.db.GetUser(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found: %s", userID)
+	}
 
-		// Update user in database
-		if err := m.db.UpdateUser(user); err != nil {
-			return fmt.Errorf("failed to update user: %v", err)
-		}
+	user.TruthAccuracy = getFloat64(scores, "truth_accuracy")
+	user.EvidenceQuality = getFloat64(scores, "evidence_quality")
+	user.EngagementQuality = getFloat64(scores, "engagement_quality")
+	user.CommunityScore = getFloat64(scores, "community_score")
 
-		// Update cache
-		m.cache.SetUserScores(userID, map[string]float64{
-			"truth_accuracy":     user.TruthAccuracy,
-			"evidence_quality":   user.EvidenceQuality,
-			"engagement_quality": user.EngagementQuality,
-			"community_score":    user.CommunityScore,
-			"reputation_score":   user.ReputationScore,
-		})
+	user.ReputationScore = calculateReputationScore(map[string]float64{
+		"truth_accuracy":     user.TruthAccuracy,
+		"evidence_quality":   user.EvidenceQuality,
+		"engagement_quality": user.EngagementQuality,
+		"community_score":    user.CommunityScore,
+	})
+
+	if err := m.db.UpdateUser(user); err != nil {
+		return fmt.Errorf("failed to update user: %v", err)
+	}
+
+	// cachedVersion is 0 on a miss unless another goroutine raced us to
+	// it, in which case ErrStale just means its scores (computed from the
+	// same activity) already won.
+	_, cachedVersion, _ := m.cache.GetUserScores(ctx, userID)
+	if _, err := m.cache.SetUserScores(ctx, userID, map[string]float64{
+		"truth_accuracy":     user.TruthAccuracy,
+		"evidence_quality":   user.EvidenceQuality,
+		"engagement_quality": user.EngagementQuality,
+		"community_score":    user.CommunityScore,
+		"reputation_score":   user.ReputationScore,
+	}, cachedVersion); err != nil && err != cache.ErrStale {
+		return fmt.Errorf("failed to cache user scores: %v", err)
 	}
 
 	return nil
 }
 
-// UpdateUserAuthenticity updates a user's authenticity score and verification level
-func (m *Manager) UpdateUserAuthenticity(userID string) error {
+// UpdateUserAuthenticity updates a user's authenticity score and
+// verification level. ctx bounds the outbound call to the algorithm
+// service so a canceled inbound request doesn't leave it running.
+func (m *Manager) UpdateUserAuthenticity(ctx context.Context, userID string) error {
 	user, err := m.db.GetUser(userID)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %v", err)
@@ -131,7 +228,7 @@ func (m *Manager) UpdateUserAuthenticity(userID string) error {
 		return fmt.Errorf("failed to get verifications: %v", err)
 	}
 
-	result, err := m.algo.AnalyzeUserAuthenticity(&models.AlgorithmRequest{
+	result, err := m.algo.AnalyzeUserAuthenticityCtx(ctx, &models.AlgorithmRequest{
 		Type:   "user_authenticity",
 		UserID: userID,
 		Parameters: map[string]interface{}{
@@ -160,11 +257,277 @@ func (m *Manager) UpdateUserAuthenticity(userID string) error {
 		if err := m.db.UpdateUser(user); err != nil {
 			return fmt.Errorf("failed to update user authenticity: %v", err)
 		}
+
+		if m.feeder != nil && user.AuthenticityScore < authenticityThrottleThreshold {
+			if _, err := m.feeder.Feed(decisions.ScopeUser, userID, decisions.ActionThrottle, "low authenticity score", authenticityThrottleTTL); err != nil {
+				m.logMetric("decision_feed_error", 1, map[string]interface{}{"error": err.Error()})
+			}
+		}
+	}
+
+	return nil
+}
+
+// RecordVerification bumps userID's VerificationLevel from an external
+// verification signal (e.g. an OIDC provider's email_verified/amr claims)
+// without the round trip to the algorithm service UpdateUserAuthenticity
+// makes - method and evidence are kept for the audit trail via logMetric.
+// level only ever raises VerificationLevel, never lowers what was
+// previously earned.
+func (m *Manager) RecordVerification(ctx context.Context, userID, method string, level int, evidence map[string]interface{}) error {
+	user, err := m.db.GetUser(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	if level > user.VerificationLevel {
+		user.VerificationLevel = level
+		if err := m.db.UpdateUser(user); err != nil {
+			return fmt.Errorf("failed to update user verification level: %v", err)
+		}
+	}
+
+	m.logMetric("verification_recorded", float64(level), map[string]interface{}{
+		"user_id":  userID,
+		"method":   method,
+		"evidence": evidence,
+	})
+	return nil
+}
+
+// overridableScoreFields are the user score fields OverrideScores accepts
+// and the ones calculateReputationScore weighs - the same set
+// UpdateUserScores writes back from the algorithm service.
+var overridableScoreFields = []string{"truth_accuracy", "evidence_quality", "engagement_quality", "community_score"}
+
+// ScoreSnapshot is GetScores' view of a user's scores: Persisted is what's
+// in the database right now, Cached is what UpdateUserScores last wrote to
+// the cache (CacheHit is false if nothing is cached or it expired).
+type ScoreSnapshot struct {
+	Persisted         map[string]float64
+	ReputationScore   float64
+	AuthenticityScore float64
+	VerificationLevel int
+	Cached            map[string]float64
+	CacheHit          bool
+}
+
+// GetScores returns userID's current persisted scores alongside whatever
+// is in the cache, for the admin scores endpoint to show a moderator both
+// without forcing a recompute.
+func (m *Manager) GetScores(ctx context.Context, userID string) (*ScoreSnapshot, error) {
+	user, err := m.db.GetUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %v", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found: %s", userID)
+	}
+
+	cached, _, hit := m.cache.GetUserScores(ctx, userID)
+
+	return &ScoreSnapshot{
+		Persisted: map[string]float64{
+			"truth_accuracy":     user.TruthAccuracy,
+			"evidence_quality":   user.EvidenceQuality,
+			"engagement_quality": user.EngagementQuality,
+			"community_score":    user.CommunityScore,
+		},
+		ReputationScore:   user.ReputationScore,
+		AuthenticityScore: user.AuthenticityScore,
+		VerificationLevel: user.VerificationLevel,
+		Cached:            cached,
+		CacheHit:          hit,
+	}, nil
+}
+
+// OverrideScores applies a moderator's manual correction to the score
+// fields present in scores (any of overridableScoreFields; others are
+// ignored), recalculates ReputationScore through the same
+// calculateReputationScore the algorithm-service path uses so the two
+// stay consistent, and records the change in score_overrides for Audit.
+func (m *Manager) OverrideScores(ctx context.Context, userID, moderatorID, reason string, scores map[string]float64) error {
+	user, err := m.db.GetUser(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	applied := map[string]float64{}
+	for _, field := range overridableScoreFields {
+		value, ok := scores[field]
+		if !ok {
+			continue
+		}
+		applied[field] = value
+		switch field {
+		case "truth_accuracy":
+			user.TruthAccuracy = value
+		case "evidence_quality":
+			user.EvidenceQuality = value
+		case "engagement_quality":
+			user.EngagementQuality = value
+		case "community_score":
+			user.CommunityScore = value
+		}
+	}
+	if len(applied) == 0 {
+		return fmt.Errorf("no recognized score fields in override")
+	}
+
+	user.ReputationScore = calculateReputationScore(map[string]float64{
+		"truth_accuracy":     user.TruthAccuracy,
+		"evidence_quality":   user.EvidenceQuality,
+		"engagement_quality": user.EngagementQuality,
+		"community_score":    user.CommunityScore,
+	})
+
+	if err := m.db.UpdateUser(user); err != nil {
+		return fmt.Errorf("failed to update user: %v", err)
+	}
+	m.cache.Invalidate(ctx, userID)
+
+	if m.store != nil {
+		if err := m.store.CreateScoreOverride(ScoreOverride{
+			ID:              uuid.NewString(),
+			UserID:          userID,
+			ModeratorID:     moderatorID,
+			Reason:          reason,
+			Field:           "scores",
+			Scores:          applied,
+			ReputationScore: user.ReputationScore,
+			CreatedAt:       time.Now(),
+		}); err != nil {
+			return fmt.Errorf("failed to record score override: %v", err)
+		}
 	}
 
+	m.logMetric("admin_override", user.ReputationScore, map[string]interface{}{
+		"user_id":      userID,
+		"moderator_id": moderatorID,
+		"reason":       reason,
+		"field":        "scores",
+		"applied":      applied,
+	})
 	return nil
 }
 
+// OverrideAuthenticity applies a moderator's manual correction to a
+// user's AuthenticityScore and VerificationLevel, the authenticity
+// counterpart to OverrideScores.
+func (m *Manager) OverrideAuthenticity(ctx context.Context, userID, moderatorID, reason string, authenticityScore float64, verificationLevel int) error {
+	user, err := m.db.GetUser(userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %v", err)
+	}
+	if user == nil {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+
+	user.AuthenticityScore = authenticityScore
+	user.VerificationLevel = verificationLevel
+	if err := m.db.UpdateUser(user); err != nil {
+		return fmt.Errorf("failed to update user authenticity: %v", err)
+	}
+	m.cache.Invalidate(ctx, userID)
+
+	if m.store != nil {
+		if err := m.store.CreateScoreOverride(ScoreOverride{
+			ID:          uuid.NewString(),
+			UserID:      userID,
+			ModeratorID: moderatorID,
+			Reason:      reason,
+			Field:       "authenticity",
+			Scores: map[string]float64{
+				"authenticity_score": authenticityScore,
+				"verification_level": float64(verificationLevel),
+			},
+			ReputationScore: user.ReputationScore,
+			CreatedAt:       time.Now(),
+		}); err != nil {
+			return fmt.Errorf("failed to record authenticity override: %v", err)
+		}
+	}
+
+	m.logMetric("admin_override", authenticityScore, map[string]interface{}{
+		"user_id":      userID,
+		"moderator_id": moderatorID,
+		"reason":       reason,
+		"field":        "authenticity",
+	})
+	return nil
+}
+
+// AuditEntry is one row of Audit's history - either an algorithm_metrics
+// row logged by UpdateUserScores/UpdateUserAuthenticity/RecordVerification,
+// or a moderator's ScoreOverride, normalized to the same shape so the
+// admin audit endpoint can render them in one timeline.
+type AuditEntry struct {
+	Source    string // "metric" or "override"
+	Name      string
+	Value     float64
+	Metadata  map[string]interface{}
+	Timestamp time.Time
+}
+
+// Audit returns userID's score-change history, most recent first: every
+// algorithm_metrics row user_manager logged for this user, merged with
+// their score_overrides rows. limit/offset paginate the metrics query;
+// overrides are few enough per user that they're fetched in full and
+// merged in Go.
+func (m *Manager) Audit(userID string, limit, offset int) ([]AuditEntry, error) {
+	if m.store == nil {
+		return nil, fmt.Errorf("audit store not configured")
+	}
+
+	metrics, err := m.store.ListMetrics("user_manager", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metrics: %v", err)
+	}
+
+	overrides, err := m.store.ListScoreOverrides(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list score overrides: %v", err)
+	}
+
+	entries := make([]AuditEntry, 0, len(metrics)+len(overrides))
+	for _, metric := range metrics {
+		if uid, ok := metric.Metadata["user_id"].(string); !ok || uid != userID {
+			continue
+		}
+		entries = append(entries, AuditEntry{
+			Source:    "metric",
+			Name:      metric.MetricName,
+			Value:     metric.Value,
+			Metadata:  metric.Metadata,
+			Timestamp: metric.Timestamp,
+		})
+	}
+	for _, override := range overrides {
+		entries = append(entries, AuditEntry{
+			Source: "override",
+			Name:   "override:" + override.Field,
+			Value:  override.ReputationScore,
+			Metadata: map[string]interface{}{
+				"moderator_id": override.ModeratorID,
+				"reason":       override.Reason,
+				"scores":       override.Scores,
+			},
+			Timestamp: override.CreatedAt,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+	return entries, nil
+}
+
 // Helper functions
 
 func getFloat64(m map[string]interface{}, key string) float64 {
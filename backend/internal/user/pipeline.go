@@ -0,0 +1,405 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+const (
+	// dirtyQueueCapacity bounds Enqueue's backpressure - a caller on the
+	// request path gets ErrQueueFull back immediately instead of blocking
+	// on a stuck algorithm service.
+	dirtyQueueCapacity = 4096
+
+	// scoreDebounceWindow is how long a dirty user sits before its batch
+	// is dispatched, coalescing any further events for that user that
+	// arrive in the meantime.
+	scoreDebounceWindow = 2 * time.Second
+
+	// scoreFlushInterval is how often the flusher checks for users whose
+	// debounce window has elapsed. It's independent of scoreDebounceWindow
+	// so a batch fires within scoreFlushInterval of going ready, not only
+	// exactly every scoreDebounceWindow.
+	scoreFlushInterval = 250 * time.Millisecond
+
+	// scoreBatchWorkers is how many goroutines drain dirty events into the
+	// pending set concurrently.
+	scoreBatchWorkers = 4
+
+	// maxBatchSize caps how many users one AlgorithmRequest carries.
+	maxBatchSize = 50
+)
+
+// ErrQueueFull is returned by Enqueue when the dirty channel is at
+// dirtyQueueCapacity - the event is not silently dropped, it's spilled
+// over to Store.EnqueuePendingScoreUpdate when a Store is configured.
+var ErrQueueFull = fmt.Errorf("score update queue is full")
+
+// UserDirtyEvent says userID's scores may be stale because of Cause (e.g.
+// "vote_cast", "comment_posted") as of At. Enqueue is the only producer;
+// the worker pool and flusher are the only consumers.
+type UserDirtyEvent struct {
+	UserID string
+	Cause  string
+	At     time.Time
+}
+
+// pendingEntry coalesces every UserDirtyEvent for one user since the
+// first one started its debounce window.
+type pendingEntry struct {
+	firstSeen time.Time
+	latest    UserDirtyEvent
+}
+
+// pipeline is the score-update batching state embedded in Manager. It's
+// broken out from Manager's other fields onto its own zero value so
+// NewManager only needs to initialize the channel/map fields once.
+type pipeline struct {
+	dirty chan UserDirtyEvent
+
+	pendingMu sync.Mutex
+	pending   map[string]*pendingEntry
+
+	waitersMu sync.Mutex
+	waiters   map[string][]chan struct{}
+
+	stopCh      chan struct{}
+	stopOnce    sync.Once
+	workerWG    sync.WaitGroup
+	flusherDone chan struct{}
+
+	queueDepth    int64
+	droppedEvents int64
+}
+
+func newPipeline() *pipeline {
+	return &pipeline{
+		dirty:   make(chan UserDirtyEvent, dirtyQueueCapacity),
+		pending: make(map[string]*pendingEntry),
+		waiters: make(map[string][]chan struct{}),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// StartPipeline replays any pending_score_updates left over from a prior
+// process (only possible if SetStore was called), then starts the worker
+// pool and flusher that drain Enqueue going forward. Call it once after
+// SetStore, before traffic starts calling Enqueue/UpdateUserScores.
+func (m *Manager) StartPipeline() error {
+	if m.store != nil {
+		pending, err := m.store.ListPendingScoreUpdates()
+		if err != nil {
+			return fmt.Errorf("failed to load pending score updates: %v", err)
+		}
+		for _, p := range pending {
+			if err := m.Enqueue(p.UserID, p.Cause); err != nil {
+				m.logMetric("score_update_replay_dropped", 1, map[string]interface{}{"user_id": p.UserID})
+				continue
+			}
+			if err := m.store.DeletePendingScoreUpdate(p.ID); err != nil {
+				m.logMetric("score_update_replay_cleanup_error", 1, map[string]interface{}{"id": p.ID, "error": err.Error()})
+			}
+		}
+		if len(pending) > 0 {
+			m.logMetric("score_update_replayed", float64(len(pending)), nil)
+		}
+	}
+
+	for i := 0; i < scoreBatchWorkers; i++ {
+		m.workerWG.Add(1)
+		go m.runDirtyWorker()
+	}
+
+	m.flusherDone = make(chan struct{})
+	go m.runFlusher(m.flusherDone)
+
+	return nil
+}
+
+// Shutdown stops the pipeline: it tells the worker pool and flusher to
+// wind down, waits for the workers to drain whatever is already in dirty
+// into pending, waits for the flusher to exit so nothing else is reading
+// pending concurrently, then does one last forced flush of everything
+// left so no enqueued event is lost. It gives up and returns ctx.Err()
+// if ctx is done first.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.stopOnce.Do(func() { close(m.stopCh) })
+
+	done := make(chan struct{})
+	go func() {
+		m.workerWG.Wait()
+		if m.flusherDone != nil {
+			<-m.flusherDone
+		}
+		m.dispatchReady(true)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Enqueue marks userID dirty with cause, the entry point score-affecting
+// handlers (vote cast, comment posted, ...) call instead of the old
+// synchronous UpdateUserScores. If the queue is full, the event is
+// spilled over to Store when one is configured rather than silently
+// dropped, and ErrQueueFull is returned so the caller knows no batch will
+// pick this event up until StartPipeline replays it.
+func (m *Manager) Enqueue(userID, cause string) error {
+	event := UserDirtyEvent{UserID: userID, Cause: cause, At: time.Now()}
+
+	select {
+	case m.dirty <- event:
+		atomic.AddInt64(&m.queueDepth, 1)
+		return nil
+	default:
+	}
+
+	atomic.AddInt64(&m.droppedEvents, 1)
+	m.logMetric("score_update_dropped", 1, map[string]interface{}{"user_id": userID, "cause": cause})
+
+	if m.store != nil {
+		if err := m.store.EnqueuePendingScoreUpdate(PendingScoreUpdate{
+			ID:     uuid.NewString(),
+			UserID: userID,
+			Cause:  cause,
+			At:     event.At,
+		}); err != nil {
+			m.logMetric("score_update_spillover_error", 1, map[string]interface{}{"user_id": userID, "error": err.Error()})
+		} else {
+			m.logMetric("score_update_spillover_persisted", 1, map[string]interface{}{"user_id": userID})
+		}
+	}
+
+	return ErrQueueFull
+}
+
+func (m *Manager) runDirtyWorker() {
+	defer m.workerWG.Done()
+	for {
+		select {
+		case event := <-m.dirty:
+			atomic.AddInt64(&m.queueDepth, -1)
+			m.markDirty(event)
+		case <-m.stopCh:
+			// Drain whatever was already queued before returning, so
+			// Shutdown's final flush sees it.
+			for {
+				select {
+				case event := <-m.dirty:
+					atomic.AddInt64(&m.queueDepth, -1)
+					m.markDirty(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (m *Manager) markDirty(e UserDirtyEvent) {
+	m.pendingMu.Lock()
+	defer m.pendingMu.Unlock()
+
+	entry, ok := m.pending[e.UserID]
+	if !ok {
+		m.pending[e.UserID] = &pendingEntry{firstSeen: e.At, latest: e}
+		return
+	}
+	entry.latest = e
+}
+
+func (m *Manager) runFlusher(done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(scoreFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.logMetric("score_queue_depth", float64(atomic.LoadInt64(&m.queueDepth)), nil)
+			m.dispatchReady(false)
+		case <-m.stopCh:
+			return
+		}
+	}
+}
+
+// flushReady removes and returns every pending entry whose debounce
+// window elapsed (or all of them, if force is set).
+func (m *Manager) flushReady(force bool) []UserDirtyEvent {
+	now := time.Now()
+
+	m.pendingMu.Lock()
+	defer m.pendingMu.Unlock()
+
+	var ready []UserDirtyEvent
+	for userID, entry := range m.pending {
+		if force || now.Sub(entry.firstSeen) >= scoreDebounceWindow {
+			ready = append(ready, entry.latest)
+			delete(m.pending, userID)
+		}
+	}
+	return ready
+}
+
+// dispatchReady flushes whatever's ready and dispatches it in chunks no
+// larger than maxBatchSize.
+func (m *Manager) dispatchReady(force bool) {
+	ready := m.flushReady(force)
+	for len(ready) > 0 {
+		batchSize := len(ready)
+		if batchSize > maxBatchSize {
+			batchSize = maxBatchSize
+		}
+		m.dispatchBatch(ready[:batchSize])
+		ready = ready[batchSize:]
+	}
+}
+
+// dispatchBatch builds one batched AlgorithmRequest for events, sends it,
+// and applies the per-user results. It runs on a background goroutine, so
+// it uses context.Background() rather than any inbound request's context
+// - there is no single caller left to cancel it by the time a batch
+// fires.
+func (m *Manager) dispatchBatch(events []UserDirtyEvent) {
+	ctx := context.Background()
+
+	payloads := make([]map[string]interface{}, 0, len(events))
+	for _, e := range events {
+		user, err := m.db.GetUser(e.UserID)
+		if err != nil || user == nil {
+			m.logMetric("score_batch_user_lookup_error", 1, map[string]interface{}{"user_id": e.UserID})
+			m.notifyWaiters(e.UserID)
+			continue
+		}
+
+		activities, err := m.db.GetUserActivities(e.UserID, user.LastActive)
+		if err != nil {
+			m.logMetric("score_batch_user_lookup_error", 1, map[string]interface{}{"user_id": e.UserID, "error": err.Error()})
+			m.notifyWaiters(e.UserID)
+			continue
+		}
+		votes, err := m.db.GetUserVotes(e.UserID, user.LastActive)
+		if err != nil {
+			m.logMetric("score_batch_user_lookup_error", 1, map[string]interface{}{"user_id": e.UserID, "error": err.Error()})
+			m.notifyWaiters(e.UserID)
+			continue
+		}
+		evidence, err := m.db.GetUserEvidence(e.UserID, user.LastActive)
+		if err != nil {
+			m.logMetric("score_batch_user_lookup_error", 1, map[string]interface{}{"user_id": e.UserID, "error": err.Error()})
+			m.notifyWaiters(e.UserID)
+			continue
+		}
+
+		payloads = append(payloads, map[string]interface{}{
+			"user_id":    e.UserID,
+			"cause":      e.Cause,
+			"activities": activities,
+			"votes":      votes,
+			"evidence":   evidence,
+			"current_scores": map[string]float64{
+				"truth_accuracy":     user.TruthAccuracy,
+				"evidence_quality":   user.EvidenceQuality,
+				"engagement_quality": user.EngagementQuality,
+				"community_score":    user.CommunityScore,
+			},
+		})
+	}
+
+	if len(payloads) == 0 {
+		return
+	}
+
+	result, err := m.algo.AnalyzeUserActivityBatchCtx(ctx, &models.AlgorithmRequest{
+		Type: "user_analysis_batch",
+		Parameters: map[string]interface{}{
+			"users": payloads,
+		},
+		Timestamp: time.Now(),
+	})
+
+	if err != nil || result.Status != "success" {
+		errMsg := "analysis failed"
+		if err != nil {
+			errMsg = err.Error()
+		} else if result.Error != nil {
+			errMsg = result.Error.ErrorMessage
+		}
+		m.logMetric("score_batch_dispatch_error", float64(len(payloads)), map[string]interface{}{"error": errMsg})
+
+		for _, e := range events {
+			if m.store != nil {
+				if spillErr := m.store.EnqueuePendingScoreUpdate(PendingScoreUpdate{
+					ID:     uuid.NewString(),
+					UserID: e.UserID,
+					Cause:  e.Cause,
+					At:     e.At,
+				}); spillErr != nil {
+					m.logMetric("score_update_spillover_error", 1, map[string]interface{}{"user_id": e.UserID, "error": spillErr.Error()})
+				} else {
+					m.logMetric("score_update_spillover_persisted", 1, map[string]interface{}{"user_id": e.UserID})
+				}
+			}
+			m.notifyWaiters(e.UserID)
+		}
+		return
+	}
+
+	perUser, _ := result.Results["users"].(map[string]interface{})
+	for _, e := range events {
+		scores, ok := perUser[e.UserID].(map[string]interface{})
+		if ok {
+			if err := m.applyUserScores(ctx, e.UserID, scores); err != nil {
+				m.logMetric("score_batch_apply_error", 1, map[string]interface{}{"user_id": e.UserID, "error": err.Error()})
+			}
+		}
+		m.notifyWaiters(e.UserID)
+	}
+
+	m.logMetric("score_batch_dispatched", float64(len(payloads)), nil)
+}
+
+func (m *Manager) registerWaiter(userID string) chan struct{} {
+	ch := make(chan struct{})
+	m.waitersMu.Lock()
+	m.waiters[userID] = append(m.waiters[userID], ch)
+	m.waitersMu.Unlock()
+	return ch
+}
+
+func (m *Manager) unregisterWaiter(userID string, ch chan struct{}) {
+	m.waitersMu.Lock()
+	defer m.waitersMu.Unlock()
+	waiters := m.waiters[userID]
+	for i, w := range waiters {
+		if w == ch {
+			m.waiters[userID] = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *Manager) notifyWaiters(userID string) {
+	m.waitersMu.Lock()
+	waiters := m.waiters[userID]
+	delete(m.waiters, userID)
+	m.waitersMu.Unlock()
+
+	for _, ch := range waiters {
+		close(ch)
+	}
+}
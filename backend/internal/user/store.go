@@ -0,0 +1,52 @@
+package user
+
+import (
+	"time"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+)
+
+// Store is the persistence Manager needs for the admin override/audit API,
+// beyond db.Repository - *sqlite.DBManager satisfies it; this package never
+// imports internal/db/sqlite, so there is no cycle, the same split
+// internal/decisions.Store and internal/moderation.Store use.
+type Store interface {
+	CreateScoreOverride(o ScoreOverride) error
+	ListScoreOverrides(userID string) ([]ScoreOverride, error)
+
+	// ListMetrics returns algorithmName's AlgorithmMetric rows, most recent
+	// first, for Audit to merge with score overrides and filter by user ID.
+	ListMetrics(algorithmName string, limit, offset int) ([]models.AlgorithmMetric, error)
+
+	// EnqueuePendingScoreUpdate persists a UserDirtyEvent the batch
+	// pipeline couldn't take (Enqueue hit a full queue) or couldn't
+	// dispatch (the algorithm service errored), so StartPipeline can
+	// replay it on the next startup instead of losing it.
+	EnqueuePendingScoreUpdate(p PendingScoreUpdate) error
+	ListPendingScoreUpdates() ([]PendingScoreUpdate, error)
+	DeletePendingScoreUpdate(id string) error
+}
+
+// ScoreOverride records a moderator's manual correction to a user's scores
+// or authenticity fields, so Manager.Audit can show why a value changed
+// outside the normal algorithm-service round trip.
+type ScoreOverride struct {
+	ID              string
+	UserID          string
+	ModeratorID     string
+	Reason          string
+	Field           string // "scores" or "authenticity"
+	Scores          map[string]float64
+	ReputationScore float64
+	CreatedAt       time.Time
+}
+
+// PendingScoreUpdate is a UserDirtyEvent spilled over to sqlite because the
+// pipeline's in-process channel was full or a batch dispatch failed - see
+// Store.EnqueuePendingScoreUpdate.
+type PendingScoreUpdate struct {
+	ID     string
+	UserID string
+	Cause  string
+	At     time.Time
+}
@@ -0,0 +1,46 @@
+// Package virality scores a content item's reshare graph with a
+// Hawkes-process-inspired intensity function: every reply, context post,
+// and vote in its descendant tree contributes a reputation-weighted pulse
+// that decays exponentially with age. The math here is pure and
+// DB-independent, mirroring internal/recommend's split between "the
+// algorithm" (this file) and whatever orchestrates it against a
+// db.Repository (see Calculator in calculator.go).
+package virality
+
+import (
+	"math"
+	"time"
+)
+
+// Event is one weighted, timestamped action in a content item's
+// descendant tree - the author of a reply/context post, or a voter
+// casting a vote - either of which contributes a pulse to V(c).
+type Event struct {
+	UserID    string
+	Weight    float64
+	Timestamp time.Time
+}
+
+// decayRate returns λ = ln(2)/halfLife, the rate at which an event's
+// contribution to Score halves every halfLife.
+func decayRate(halfLife time.Duration) float64 {
+	return math.Ln2 / halfLife.Seconds()
+}
+
+// Score computes V(c) = Σ w(user_i)·exp(-λ·(now - t_i)) over events,
+// decaying each one by halfLife. An event timestamped after now (clock
+// skew, or a caller passing a stale now) contributes its full weight
+// rather than a negative age inflating it further.
+func Score(events []Event, now time.Time, halfLife time.Duration) float64 {
+	lambda := decayRate(halfLife)
+
+	var v float64
+	for _, e := range events {
+		age := now.Sub(e.Timestamp).Seconds()
+		if age < 0 {
+			age = 0
+		}
+		v += e.Weight * math.Exp(-lambda*age)
+	}
+	return v
+}
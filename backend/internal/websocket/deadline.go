@@ -0,0 +1,84 @@
+package websocket
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer mirrors the timer-plus-cancellation-channel pattern Go's
+// netstack gonet adapter uses to give a connection read/write deadlines:
+// arming a deadline starts a timer that, on expiry, closes a cancel channel
+// so anything selecting on it wakes up immediately instead of waiting out
+// the blocking call. Here the underlying gorilla conn already honors real
+// SetReadDeadline/SetWriteDeadline calls, so deadlineTimer drives those
+// directly and exposes the cancel channels as a second, select-friendly
+// signal the hub can use to tell a merely-slow client from one to disconnect.
+type deadlineTimer struct {
+	conn deadlineConn
+
+	mu            sync.Mutex
+	writeCancelCh chan struct{}
+	readCancelCh  chan struct{}
+	writeTimer    *time.Timer
+	readTimer     *time.Timer
+}
+
+// deadlineConn is the subset of *websocket.Conn a deadlineTimer needs.
+type deadlineConn interface {
+	SetWriteDeadline(t time.Time) error
+	SetReadDeadline(t time.Time) error
+}
+
+func newDeadlineTimer(conn deadlineConn) *deadlineTimer {
+	return &deadlineTimer{
+		conn:          conn,
+		writeCancelCh: make(chan struct{}),
+		readCancelCh:  make(chan struct{}),
+	}
+}
+
+// setWriteDeadline arms the write deadline. A zero deadline disarms it
+// (no timeout).
+func (d *deadlineTimer) setWriteDeadline(deadline time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.writeTimer != nil {
+		d.writeTimer.Stop()
+	}
+	d.conn.SetWriteDeadline(deadline)
+	d.writeCancelCh = make(chan struct{})
+	if deadline.IsZero() {
+		return
+	}
+
+	cancelCh := d.writeCancelCh
+	d.writeTimer = time.AfterFunc(time.Until(deadline), func() { close(cancelCh) })
+}
+
+// setReadDeadline arms the read deadline the same way setWriteDeadline arms
+// the write one.
+func (d *deadlineTimer) setReadDeadline(deadline time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.readTimer != nil {
+		d.readTimer.Stop()
+	}
+	d.conn.SetReadDeadline(deadline)
+	d.readCancelCh = make(chan struct{})
+	if deadline.IsZero() {
+		return
+	}
+
+	cancelCh := d.readCancelCh
+	d.readTimer = time.AfterFunc(time.Until(deadline), func() { close(cancelCh) })
+}
+
+// writeCancel returns the channel that closes when the current write
+// deadline expires.
+func (d *deadlineTimer) writeCancel() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.writeCancelCh
+}
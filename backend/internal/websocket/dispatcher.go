@@ -0,0 +1,172 @@
+package websocket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/saint/babel-protocol/backend/api/models"
+	"github.com/saint/babel-protocol/backend/internal/db"
+	"github.com/saint/babel-protocol/backend/pkg/eventsign"
+)
+
+const (
+	dispatchMaxAttempts  = 5
+	dispatchInitialDelay = time.Second
+	signatureHeader      = "X-Babel-Signature"
+)
+
+// EventDispatcher delivers dispatched WebSocketMessages to external
+// subscribers (moderation bots, analytics, push gateways) over signed
+// webhooks, retrying with exponential backoff and recording exhausted
+// deliveries to a dead-letter log.
+type EventDispatcher struct {
+	db         db.Repository
+	httpClient *http.Client
+}
+
+// NewEventDispatcher creates a dispatcher backed by db for subscriber and
+// dead-letter persistence.
+func NewEventDispatcher(db db.Repository) *EventDispatcher {
+	return &EventDispatcher{
+		db: db,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Dispatch fans message out to every active subscriber whose topic filter
+// matches its type. Each delivery runs in its own goroutine so a slow or
+// unreachable subscriber can't block the hub.
+func (d *EventDispatcher) Dispatch(message *WebSocketMessage) {
+	subs, err := d.db.ListEventSubscribers()
+	if err != nil {
+		log.Printf("error listing event subscribers: %v", err)
+		return
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("error marshaling message for dispatch: %v", err)
+		return
+	}
+
+	for _, sub := range subs {
+		if !matchesTopic(sub.Topics, message.Type) {
+			continue
+		}
+		go d.deliverWithRetry(sub, message.Type, body)
+	}
+}
+
+// matchesTopic reports whether topics subscribes to eventType, treating an
+// empty filter as "all topics".
+func matchesTopic(topics []string, eventType string) bool {
+	if len(topics) == 0 {
+		return true
+	}
+	for _, t := range topics {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// deliverWithRetry attempts delivery with exponential backoff, logging a
+// dead-letter entry if every attempt fails.
+func (d *EventDispatcher) deliverWithRetry(sub *models.EventSubscriber, eventType string, body []byte) {
+	first := time.Now()
+	delay := dispatchInitialDelay
+	var lastErr error
+
+	for attempt := 1; attempt <= dispatchMaxAttempts; attempt++ {
+		if err := d.deliver(sub, body); err != nil {
+			lastErr = err
+			log.Printf("event dispatch attempt %d/%d to subscriber %s failed: %v", attempt, dispatchMaxAttempts, sub.ID, err)
+			if attempt < dispatchMaxAttempts {
+				time.Sleep(delay)
+				delay *= 2
+			}
+			continue
+		}
+		return
+	}
+
+	dead := &models.DeadLetterEvent{
+		ID:           uuid.New().String(),
+		SubscriberID: sub.ID,
+		EventType:    eventType,
+		Payload:      string(body),
+		Attempts:     dispatchMaxAttempts,
+		FirstAttempt: first,
+		LastAttempt:  time.Now(),
+	}
+	if lastErr != nil {
+		dead.LastError = lastErr.Error()
+	}
+	if err := d.db.LogDeadLetterEvent(dead); err != nil {
+		log.Printf("error logging dead letter event for subscriber %s: %v", sub.ID, err)
+	}
+}
+
+// deliver performs a single signed delivery attempt. WebSocket-transport
+// subscribers are delivered as a signed envelope frame over the same
+// connection semantics; webhook subscribers get an HTTP POST.
+func (d *EventDispatcher) deliver(sub *models.EventSubscriber, body []byte) error {
+	switch sub.Transport {
+	case models.SubscriberTransportWebSocket:
+		return d.deliverWebSocketEnvelope(sub, body)
+	default:
+		return d.deliverWebhook(sub, body)
+	}
+}
+
+func (d *EventDispatcher) deliverWebhook(sub *models.EventSubscriber, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, sub.DeliveryURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(signatureHeader, eventsign.Header(sub.Secret, body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook subscriber returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signedEnvelope wraps a dispatched message with its signature so WebSocket
+// subscribers can call eventsign.VerifySignature the same way webhook
+// subscribers do.
+type signedEnvelope struct {
+	Signature string          `json:"signature"`
+	Body      json.RawMessage `json:"body"`
+}
+
+func (d *EventDispatcher) deliverWebSocketEnvelope(sub *models.EventSubscriber, body []byte) error {
+	conn, _, err := websocket.DefaultDialer.Dial(sub.DeliveryURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to dial subscriber websocket: %v", err)
+	}
+	defer conn.Close()
+
+	envelope := signedEnvelope{
+		Signature: eventsign.Header(sub.Secret, body),
+		Body:      body,
+	}
+	return conn.WriteJSON(envelope)
+}
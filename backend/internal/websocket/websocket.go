@@ -2,15 +2,36 @@ package websocket
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+
+	"github.com/saint/babel-protocol/backend/internal/alert"
+	"github.com/saint/babel-protocol/backend/internal/bus"
+	"github.com/saint/babel-protocol/backend/internal/cache"
+	"github.com/saint/babel-protocol/backend/internal/metrics"
 )
 
+// BroadcastTopic is the bus topic that feed-wide events (content updates,
+// votes, consensus changes, etc.) are published and subscribed on.
+const BroadcastTopic = "broadcast:content"
+
+// userTopic returns the bus topic used for direct messages to a given user.
+func userTopic(userID string) string {
+	return "user:" + userID
+}
+
+// dedupeTTL bounds how long a delivered message ID is remembered so a
+// publisher that also has the target client connected locally doesn't
+// deliver the same message twice (once locally, once via the bus echo).
+const dedupeTTL = 30 * time.Second
+
 // Event types for WebSocket messages
 const (
 	// Content updates
@@ -31,32 +52,95 @@ const (
 	EventNotifyConsensus  = "NOTIFY_CONSENSUS"  // Consensus reached on user's content
 	EventNotifyEvidence   = "NOTIFY_EVIDENCE"   // New evidence on user's content
 	EventNotifyReputation = "NOTIFY_REPUTATION" // Reputation change notification
+
+	// Score cache
+	EventScoreUpdate = "SCORE_UPDATE" // A user's or content item's cached score changed
+
+	// Community moderation
+	EventModerationJuryInvite = "MODERATION_JURY_INVITE" // A user is invited onto a content's review jury
+	EventModerationVerdict    = "MODERATION_VERDICT"     // A jury has resolved its verdict
+	EventModerationAppeal     = "MODERATION_APPEAL"      // A verdict has been appealed to a new jury
 )
 
 // WebSocketMessage represents a structured message
 type WebSocketMessage struct {
+	ID        string      `json:"id,omitempty"`
 	Type      string      `json:"type"`
 	Data      interface{} `json:"data"`
 	Timestamp time.Time   `json:"timestamp"`
 	UserID    string      `json:"user_id,omitempty"`   // Sender's user ID
 	TargetID  string      `json:"target_id,omitempty"` // Target user ID for DMs
+	OriginHub string      `json:"origin_hub,omitempty"`
+
+	// Deadline, if set, bounds how long the hub will wait to hand this
+	// message off to its destination client's connection before dropping
+	// it rather than letting a stuck client back-pressure delivery. It is
+	// local bookkeeping only - never serialized to the wire or published
+	// to the bus.
+	Deadline time.Time `json:"-"`
 }
 
-// WebSocketHub maintains the set of active clients
+// WebSocketHub maintains the set of active clients and fans events out
+// through a Bus so that multiple hub instances can share state.
 type WebSocketHub struct {
+	// HubID uniquely identifies this hub instance. It tags published
+	// messages so the publishing hub can recognize (and skip) its own echo
+	// coming back from the bus.
+	HubID string
+
 	// Registered clients mapped by user ID
 	clients    map[string]*WebSocketClient
 	broadcast  chan *WebSocketMessage
 	register   chan *WebSocketClient
 	unregister chan *WebSocketClient
 	mu         sync.RWMutex
+
+	bus          bus.Bus
+	broadcastSub <-chan []byte
+
+	userSubsMu sync.Mutex
+	userSubs   map[string]<-chan []byte
+
+	dedupeMu sync.Mutex
+	dedupe   map[string]time.Time
+
+	dispatcher *EventDispatcher
+	metrics    *metrics.Metrics
+}
+
+// SetEventDispatcher attaches an EventDispatcher so dispatched messages are
+// also delivered to registered third-party subscribers. Call this once
+// during startup, before Run.
+func (h *WebSocketHub) SetEventDispatcher(d *EventDispatcher) {
+	h.dispatcher = d
+}
+
+// SetMetrics attaches a Metrics instance so connected clients' pings keep
+// their user counted in babel_active_users even if they never issue a
+// plain HTTP request during the window.
+func (h *WebSocketHub) SetMetrics(m *metrics.Metrics) {
+	h.metrics = m
+}
+
+// sendQueueSize bounds each client's outbound queue. Once full, enqueue
+// drops the oldest queued frame to make room for the new one rather than
+// blocking the caller or disconnecting the client outright.
+const sendQueueSize = 256
+
+// queuedMessage pairs a message with the deadline its sender wants applied
+// to the write, so writePump knows how long to let that one write block
+// before giving up on the connection.
+type queuedMessage struct {
+	message  *WebSocketMessage
+	deadline time.Time
 }
 
 // WebSocketClient represents a connected client
 type WebSocketClient struct {
 	hub     *WebSocketHub
 	conn    *websocket.Conn
-	send    chan *WebSocketMessage
+	send    chan *queuedMessage
+	dl      *deadlineTimer
 	userID  string
 	isAlive bool
 	mu      sync.RWMutex
@@ -70,24 +154,34 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
-// NewWebSocketHub creates a new WebSocketHub
-func NewWebSocketHub() *WebSocketHub {
+// NewWebSocketHub creates a new WebSocketHub backed by the given Bus. Pass
+// bus.NewInMemoryBus() for single-instance deployments.
+func NewWebSocketHub(b bus.Bus) *WebSocketHub {
 	return &WebSocketHub{
+		HubID:      uuid.New().String(),
 		broadcast:  make(chan *WebSocketMessage),
 		register:   make(chan *WebSocketClient),
 		unregister: make(chan *WebSocketClient),
 		clients:    make(map[string]*WebSocketClient),
+		bus:        b,
+		userSubs:   make(map[string]<-chan []byte),
+		dedupe:     make(map[string]time.Time),
 	}
 }
 
 // Run starts the WebSocketHub
 func (h *WebSocketHub) Run() {
+	h.subscribeBroadcast()
+	h.subscribeCacheInvalidations()
+	go h.cleanupDedupe()
+
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client.userID] = client
 			h.mu.Unlock()
+			h.subscribeUser(client.userID)
 
 		case client := <-h.unregister:
 			h.mu.Lock()
@@ -96,33 +190,228 @@ func (h *WebSocketHub) Run() {
 				close(client.send)
 			}
 			h.mu.Unlock()
+			h.unsubscribeUser(client.userID)
 
 		case message := <-h.broadcast:
-			h.mu.RLock()
-			switch message.Type {
-			case EventDirectMessage:
-				// Send to specific user
-				if client, ok := h.clients[message.TargetID]; ok {
-					select {
-					case client.send <- message:
-					default:
-						close(client.send)
-						delete(h.clients, client.userID)
-					}
-				}
-			default:
-				// Broadcast to all clients
-				for _, client := range h.clients {
-					select {
-					case client.send <- message:
-					default:
-						close(client.send)
-						delete(h.clients, client.userID)
-					}
-				}
+			if message.ID == "" {
+				message.ID = uuid.New().String()
+			}
+			message.OriginHub = h.HubID
+			h.markDelivered(message.ID)
+			h.deliverLocally(message)
+			h.publish(message)
+			if h.dispatcher != nil {
+				h.dispatcher.Dispatch(message)
+			}
+		}
+	}
+}
+
+// deliverLocally fans a message out to clients connected to this hub
+// instance only.
+func (h *WebSocketHub) deliverLocally(message *WebSocketMessage) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	switch message.Type {
+	case EventDirectMessage:
+		// Send to specific user
+		if client, ok := h.clients[message.TargetID]; ok {
+			h.enqueue(client, message)
+		}
+	default:
+		// Broadcast to all clients
+		for _, client := range h.clients {
+			h.enqueue(client, message)
+		}
+	}
+}
+
+// enqueue hands message to client's send queue, dropping the oldest queued
+// frame to make room if it's full. A slow client sheds load this way
+// instead of back-pressuring deliverLocally/BroadcastUpdate; a genuinely
+// stuck one (its writePump blocked past a write deadline) gets disconnected
+// independently, in writePump itself.
+func (h *WebSocketHub) enqueue(client *WebSocketClient, message *WebSocketMessage) {
+	qm := &queuedMessage{message: message, deadline: message.Deadline}
+
+	select {
+	case client.send <- qm:
+		return
+	default:
+	}
+
+	// Queue is full: drop the oldest frame and retry once.
+	select {
+	case <-client.send:
+		h.raiseDroppedFrame(client.userID, "queue_full")
+	default:
+	}
+
+	select {
+	case client.send <- qm:
+	default:
+		// Someone else refilled the slot we just freed; give up on this
+		// frame rather than spin.
+		h.raiseDroppedFrame(client.userID, "queue_full")
+	}
+}
+
+// raiseDroppedFrame records a dropped-frame metric and, since repeated
+// drops for the same user mean their connection can't keep up, a rate
+// limited alert.
+func (h *WebSocketHub) raiseDroppedFrame(userID, reason string) {
+	if h.metrics != nil {
+		h.metrics.RecordWSDroppedFrame(reason)
+	}
+	alert.Raise(alert.SeverityWarning, "websocket.frame_dropped",
+		fmt.Sprintf("dropped a queued frame for user %s (%s)", userID, reason),
+		map[string]interface{}{"user_id": userID, "reason": reason, "hub_id": h.HubID})
+}
+
+// publish marshals a message and ships it to the bus topic matching its
+// type so other hub instances can forward it to their own clients.
+func (h *WebSocketHub) publish(message *WebSocketMessage) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("error marshaling message for bus publish: %v", err)
+		return
+	}
+
+	topic := BroadcastTopic
+	if message.Type == EventDirectMessage {
+		topic = userTopic(message.TargetID)
+	}
+
+	if err := h.bus.Publish(topic, payload); err != nil {
+		log.Printf("error publishing message to bus topic %s: %v", topic, err)
+	}
+}
+
+// subscribeBroadcast subscribes this hub to feed-wide events so it can
+// forward events published by other hub instances to its local clients.
+func (h *WebSocketHub) subscribeBroadcast() {
+	ch, err := h.bus.Subscribe(BroadcastTopic)
+	if err != nil {
+		log.Printf("error subscribing to broadcast topic: %v", err)
+		return
+	}
+	h.broadcastSub = ch
+	go h.consume(ch)
+}
+
+// subscribeCacheInvalidations subscribes this hub to internal/cache's
+// invalidation topic, pushing each one to connected clients as a
+// SCORE_UPDATE event so frontends showing a cached truth/reputation score
+// know to refetch it rather than displaying a stale value until their own
+// poll interval comes around.
+func (h *WebSocketHub) subscribeCacheInvalidations() {
+	ch, err := h.bus.Subscribe(cache.InvalidationTopic)
+	if err != nil {
+		log.Printf("error subscribing to cache invalidation topic: %v", err)
+		return
+	}
+	go h.consumeCacheInvalidations(ch)
+}
+
+// consumeCacheInvalidations reads cache.InvalidationEvents off ch and
+// rebroadcasts each as a SCORE_UPDATE message, rather than going through
+// consume/deliverLocally - these aren't WebSocketMessages on the bus, so
+// BroadcastUpdate is the entry point that builds one around them.
+func (h *WebSocketHub) consumeCacheInvalidations(ch <-chan []byte) {
+	for payload := range ch {
+		var evt cache.InvalidationEvent
+		if err := json.Unmarshal(payload, &evt); err != nil {
+			log.Printf("error unmarshaling cache invalidation event: %v", err)
+			continue
+		}
+		h.BroadcastUpdate(EventScoreUpdate, evt)
+	}
+}
+
+// subscribeUser subscribes this hub to direct messages targeting userID so
+// they reach the client if it's connected here.
+func (h *WebSocketHub) subscribeUser(userID string) {
+	h.userSubsMu.Lock()
+	defer h.userSubsMu.Unlock()
+
+	if _, exists := h.userSubs[userID]; exists {
+		return
+	}
+
+	ch, err := h.bus.Subscribe(userTopic(userID))
+	if err != nil {
+		log.Printf("error subscribing to user topic for %s: %v", userID, err)
+		return
+	}
+	h.userSubs[userID] = ch
+	go h.consume(ch)
+}
+
+// unsubscribeUser tears down the per-user bus subscription once no local
+// client is registered for that user.
+func (h *WebSocketHub) unsubscribeUser(userID string) {
+	h.userSubsMu.Lock()
+	defer h.userSubsMu.Unlock()
+
+	ch, exists := h.userSubs[userID]
+	if !exists {
+		return
+	}
+	delete(h.userSubs, userID)
+	if err := h.bus.Unsubscribe(userTopic(userID), ch); err != nil {
+		log.Printf("error unsubscribing from user topic for %s: %v", userID, err)
+	}
+}
+
+// consume reads bus payloads from ch, applies the dedup guard, and delivers
+// them to locally-connected clients.
+func (h *WebSocketHub) consume(ch <-chan []byte) {
+	for payload := range ch {
+		var message WebSocketMessage
+		if err := json.Unmarshal(payload, &message); err != nil {
+			log.Printf("error unmarshaling bus message: %v", err)
+			continue
+		}
+
+		// Skip messages this hub already delivered locally, whether it
+		// originated here (bus echo) or was already processed.
+		if h.alreadyDelivered(message.ID) {
+			continue
+		}
+		h.markDelivered(message.ID)
+		h.deliverLocally(&message)
+	}
+}
+
+// markDelivered records that a message ID has been handled locally.
+func (h *WebSocketHub) markDelivered(id string) {
+	h.dedupeMu.Lock()
+	h.dedupe[id] = time.Now()
+	h.dedupeMu.Unlock()
+}
+
+// alreadyDelivered reports whether a message ID was already handled.
+func (h *WebSocketHub) alreadyDelivered(id string) bool {
+	h.dedupeMu.Lock()
+	defer h.dedupeMu.Unlock()
+	_, seen := h.dedupe[id]
+	return seen
+}
+
+// cleanupDedupe periodically forgets old message IDs.
+func (h *WebSocketHub) cleanupDedupe() {
+	ticker := time.NewTicker(dedupeTTL)
+	defer ticker.Stop()
+	for range ticker.C {
+		cutoff := time.Now().Add(-dedupeTTL)
+		h.dedupeMu.Lock()
+		for id, seenAt := range h.dedupe {
+			if seenAt.Before(cutoff) {
+				delete(h.dedupe, id)
 			}
-			h.mu.RUnlock()
 		}
+		h.dedupeMu.Unlock()
 	}
 }
 
@@ -143,7 +432,8 @@ func (h *WebSocketHub) HandleWebSocket(c *gin.Context) {
 	client := &WebSocketClient{
 		hub:     h,
 		conn:    conn,
-		send:    make(chan *WebSocketMessage, 256),
+		send:    make(chan *queuedMessage, sendQueueSize),
+		dl:      newDeadlineTimer(conn),
 		userID:  userID,
 		isAlive: true,
 	}
@@ -166,26 +456,25 @@ func (c *WebSocketClient) writePump() {
 
 	for {
 		select {
-		case message, ok := <-c.send:
+		case qm, ok := <-c.send:
 			if !ok {
+				c.dl.setWriteDeadline(time.Now().Add(writeWait))
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
 
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
+			deadline := qm.deadline
+			if deadline.IsZero() {
+				deadline = time.Now().Add(writeWait)
 			}
+			c.dl.setWriteDeadline(deadline)
 
-			jsonData, err := json.Marshal(message)
-			if err != nil {
+			if !c.writeQueued(qm.message) {
 				return
 			}
 
-			w.Write(jsonData)
-			w.Close()
-
 		case <-ticker.C:
+			c.dl.setWriteDeadline(time.Now().Add(writeWait))
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
 				return
 			}
@@ -193,6 +482,41 @@ func (c *WebSocketClient) writePump() {
 	}
 }
 
+// writeQueued writes message to the connection, bailing out the moment
+// either the write itself errors (including timing out against the
+// deadline armed by the caller) or the deadline's cancel channel fires -
+// whichever observes the timeout first. A client that can't keep up with
+// its own deadline is disconnected rather than left to block writePump
+// indefinitely.
+func (c *WebSocketClient) writeQueued(message *WebSocketMessage) bool {
+	done := make(chan error, 1)
+	go func() {
+		w, err := c.conn.NextWriter(websocket.TextMessage)
+		if err != nil {
+			done <- err
+			return
+		}
+		jsonData, err := json.Marshal(message)
+		if err != nil {
+			done <- err
+			return
+		}
+		if _, err := w.Write(jsonData); err != nil {
+			done <- err
+			return
+		}
+		done <- w.Close()
+	}()
+
+	select {
+	case err := <-done:
+		return err == nil
+	case <-c.dl.writeCancel():
+		c.hub.raiseDroppedFrame(c.userID, "write_deadline_exceeded")
+		return false
+	}
+}
+
 // readPump pumps messages from the websocket connection to the hub
 func (c *WebSocketClient) readPump() {
 	defer func() {
@@ -201,12 +525,12 @@ func (c *WebSocketClient) readPump() {
 	}()
 
 	c.conn.SetReadLimit(maxMessageSize)
-	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.dl.setReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error {
 		c.mu.Lock()
 		c.isAlive = true
 		c.mu.Unlock()
-		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		c.dl.setReadDeadline(time.Now().Add(pongWait))
 		return nil
 	})
 
@@ -246,19 +570,36 @@ func (c *WebSocketClient) pingPump() {
 			return
 		}
 
+		if c.hub.metrics != nil {
+			c.hub.metrics.MarkUserSeen(c.userID)
+		}
+
 		c.mu.Lock()
 		c.isAlive = false
 		c.mu.Unlock()
 	}
 }
 
-// SendToUser sends a message to a specific user
+// SendToUser sends a message to a specific user, letting its write queue
+// indefinitely (bounded only by the default writeWait per write, via
+// writePump). Use SendToUserWithDeadline when the caller needs delivery
+// bounded by its own deadline instead.
 func (h *WebSocketHub) SendToUser(targetUserID string, messageType string, data interface{}) {
+	h.SendToUserWithDeadline(targetUserID, messageType, data, time.Time{})
+}
+
+// SendToUserWithDeadline sends a message to a specific user, applying
+// deadline to the write once it reaches that user's connection. If
+// deadline is reached before the write completes, writePump disconnects
+// that client rather than letting it back-pressure further sends; a zero
+// deadline falls back to the per-write default (writeWait).
+func (h *WebSocketHub) SendToUserWithDeadline(targetUserID, messageType string, data interface{}, deadline time.Time) {
 	message := &WebSocketMessage{
 		Type:      messageType,
 		Data:      data,
 		Timestamp: time.Now(),
 		TargetID:  targetUserID,
+		Deadline:  deadline,
 	}
 	h.broadcast <- message
 }
@@ -1,35 +1,194 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 
+	_ "github.com/lib/pq"
+
 	"github.com/gin-gonic/gin"
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/saint/babel-protocol/backend/api/handlers"
 	"github.com/saint/babel-protocol/backend/api/middleware"
+	"github.com/saint/babel-protocol/backend/db/mongo"
+	"github.com/saint/babel-protocol/backend/db/postgres"
+	"github.com/saint/babel-protocol/backend/internal/alert"
+	"github.com/saint/babel-protocol/backend/internal/auth"
+	"github.com/saint/babel-protocol/backend/internal/bus"
+	"github.com/saint/babel-protocol/backend/internal/config"
+	"github.com/saint/babel-protocol/backend/internal/consensus/hcs"
+	"github.com/saint/babel-protocol/backend/internal/content"
+	"github.com/saint/babel-protocol/backend/internal/db"
 	"github.com/saint/babel-protocol/backend/internal/db/sqlite"
+	"github.com/saint/babel-protocol/backend/internal/decisions"
+	"github.com/saint/babel-protocol/backend/internal/federation"
+	"github.com/saint/babel-protocol/backend/internal/loaders"
+	"github.com/saint/babel-protocol/backend/internal/metrics"
+	"github.com/saint/babel-protocol/backend/internal/moderation"
+	"github.com/saint/babel-protocol/backend/internal/observability"
+	"github.com/saint/babel-protocol/backend/internal/ratelimit"
+	"github.com/saint/babel-protocol/backend/internal/user"
 	"github.com/saint/babel-protocol/backend/internal/websocket"
 )
 
 func main() {
+	// configHandler keeps serving a live Config, hot-reloaded on SIGHUP or
+	// a ".env" write, instead of the one-shot config.Load() snapshot -
+	// alert is the one subscriber wired up today (so e.g. a rotated Slack
+	// webhook takes effect without a restart); more can OnChange as needed.
+	configHandler, err := config.NewHandler("")
+	if err != nil {
+		log.Fatal("Failed to load config:", err)
+	}
+	cfg := configHandler.Current()
+
+	alert.SetDefault(alert.NewAlerterFromConfig(cfg.Alert))
+	configHandler.OnChange(func(old, new *config.Config) {
+		alert.SetDefault(alert.NewAlerterFromConfig(new.Alert))
+	})
+	go func() {
+		if err := configHandler.Watch(context.Background()); err != nil && err != context.Canceled {
+			log.Printf("config: watch stopped: %v", err)
+		}
+	}()
+
+	otelProvider, err := observability.NewProvider(context.Background(), observability.Config{
+		Enabled:          cfg.Observability.Enabled,
+		OTLPEndpoint:     cfg.Observability.OTLPEndpoint,
+		ServiceName:      cfg.Observability.ServiceName,
+		TraceSampleRatio: cfg.Observability.TraceSampleRatio,
+	})
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+	defer otelProvider.Shutdown(context.Background())
+
 	// Initialize WebSocket hub
-	hub := websocket.NewWebSocketHub()
-	go hub.Run()
+	hub := websocket.NewWebSocketHub(bus.NewInMemoryBus())
 
-	// Initialize database manager
-	dbManager, err := sqlite.NewDBManager("babel.db")
+	// Initialize database manager. The backend is selected via
+	// DATABASE_BACKEND; all three implement db.Repository so nothing past
+	// this point needs to know which one is live.
+	var dbManager db.Repository
+	switch cfg.DatabaseBackend {
+	case db.BackendPostgres:
+		dbManager, err = postgres.Open(cfg.PostgresDSN)
+	case db.BackendMongo:
+		dbManager, err = mongo.Open(cfg.MongoURI, cfg.MongoDatabase)
+	default:
+		dbManager, err = sqlite.NewDBManager(cfg.DatabasePath, cfg.HCS.NodeKey)
+	}
 	if err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
 
+	if cfg.HCS.Enabled {
+		if sqliteManager, ok := dbManager.(*sqlite.DBManager); ok {
+			if err := startHCS(cfg, sqliteManager); err != nil {
+				log.Fatal("Failed to start HCS mirroring:", err)
+			}
+		} else {
+			log.Printf("HCS mirroring is enabled but the %s backend does not support it; skipping", cfg.DatabaseBackend)
+		}
+	}
+
+	var federator *federation.Federator
+	var federationInbox *federation.HTTPInbox
+	if cfg.Federation.Enabled {
+		if store, ok := dbManager.(federation.Store); ok {
+			federator = federation.NewFederator(store)
+			federationInbox = startFederation(cfg, store, dbManager)
+		} else {
+			log.Printf("federation is enabled but the %s backend does not support it; skipping", cfg.DatabaseBackend)
+		}
+	}
+
+	var moderationSvc *moderation.Service
+	if store, ok := dbManager.(moderation.Store); ok {
+		moderationSvc = moderation.NewService(dbManager, store, hub, moderation.DefaultConfig())
+	} else {
+		log.Printf("community moderation is not available on the %s backend; skipping", cfg.DatabaseBackend)
+	}
+
+	var decisionsSvc *decisions.Service
+	if store, ok := dbManager.(decisions.Store); ok {
+		decisionsSvc, err = decisions.NewService(dbManager, store)
+		if err != nil {
+			log.Fatal("Failed to initialize decision feed:", err)
+		}
+		go decisionsSvc.Run()
+	} else {
+		log.Printf("decision-feed abuse mitigation is not available on the %s backend; skipping", cfg.DatabaseBackend)
+	}
+
+	var authStore auth.Store
+	if store, ok := dbManager.(auth.Store); ok {
+		authStore = store
+	}
+	authenticator, err := auth.New(cfg.Auth, cfg.JWT, authStore)
+	if err != nil {
+		log.Fatal("Failed to initialize auth provider:", err)
+	}
+
+	contentMgr, err := content.NewManager(dbManager, cfg.AlgorithmServiceURL, cfg.TLS, cfg.AlgorithmEnrollToken, cfg.Scoring, cfg.Batch)
+	if err != nil {
+		log.Fatal("Failed to initialize content manager:", err)
+	}
+	if store, ok := dbManager.(content.CalibrationStore); ok {
+		contentMgr.SetCalibrationStore(store)
+		contentMgr.StartCalibrationTracker()
+	} else {
+		log.Printf("voter calibration tracking is not available on the %s backend; skipping", cfg.DatabaseBackend)
+	}
+
+	userMgr := user.NewManager(dbManager, cfg.AlgorithmServiceURL)
+	if decisionsSvc != nil {
+		userMgr.SetDecisionFeeder(decisionsSvc)
+	}
+	if store, ok := dbManager.(user.Store); ok {
+		userMgr.SetStore(store)
+	} else {
+		log.Printf("admin score override/audit API is not available on the %s backend; skipping", cfg.DatabaseBackend)
+	}
+	if err := userMgr.StartPipeline(); err != nil {
+		log.Fatal("Failed to start user score-update pipeline:", err)
+	}
+
+	rateLimitBackend, err := ratelimit.NewBackend(cfg.RateLimit, redisURL(cfg.Redis))
+	if err != nil {
+		log.Fatal("Failed to initialize rate limit backend:", err)
+	}
+	rateLimiter := ratelimit.NewLimiter(cfg.RateLimit, rateLimitBackend)
+
+	hub.SetEventDispatcher(websocket.NewEventDispatcher(dbManager))
+
+	appMetrics := metrics.NewMetrics()
+	metrics.SetDefault(appMetrics)
+	hub.SetMetrics(appMetrics)
+	go hub.Run()
+
 	// Set up Gin router
 	router := gin.Default()
 
 	// Add middleware
+	router.Use(observability.Middleware(otelProvider))
 	router.Use(middleware.LoggerMiddleware())
 	router.Use(middleware.CORSMiddleware())
+	router.Use(appMetrics.RequestMiddleware())
+	router.Use(loaders.Middleware(dbManager))
+
+	// Prometheus scrape endpoint. Firewall this at the network edge and use
+	// the authenticated /sys/metrics below for an out-of-band pull path.
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Token-gated management endpoint exposing the same registry, reachable
+	// even when /metrics is firewalled off from the public network.
+	router.GET("/sys/metrics", middleware.BearerTokenMiddleware(cfg.MetricsToken), metrics.SysMetricsHandler())
 
 	// WebSocket endpoint with auth
-	router.GET("/ws", middleware.AuthMiddleware(), func(c *gin.Context) {
+	router.GET("/ws", middleware.RequireAuth(authenticator), func(c *gin.Context) {
 		hub.HandleWebSocket(c)
 	})
 
@@ -39,12 +198,27 @@ func main() {
 		// Content endpoints
 		content := api.Group("/content")
 		{
-			content.POST("", handlers.CreateContentHandler(hub, dbManager))
+			content.POST("", middleware.RateLimitMiddleware(rateLimiter, dbManager, "create_content", decisionsSvc), handlers.CreateContentHandler(hub, dbManager, federator))
 			content.GET("/:id", handlers.GetContentHandler())
-			content.POST("/:id/vote", handlers.VoteContentHandler(hub, dbManager))
-			content.POST("/:id/comment", handlers.CommentContentHandler(hub, dbManager))
-			content.POST("/:id/context", handlers.AddContextHandler(hub, dbManager))
-			content.GET("/:id/analytics", handlers.GetContentAnalyticsHandler())
+			content.POST("/:id/vote", middleware.RateLimitMiddleware(rateLimiter, dbManager, "vote_content", decisionsSvc), handlers.VoteContentHandler(hub, dbManager, federator))
+			content.POST("/:id/comment", handlers.CommentContentHandler(hub, dbManager, federator))
+			content.POST("/:id/context", middleware.RateLimitMiddleware(rateLimiter, dbManager, "add_context", decisionsSvc), handlers.AddContextHandler(hub, dbManager, federator))
+			content.GET("/:id/analytics", handlers.GetContentAnalyticsHandler(dbManager))
+			content.GET("/:id/vote/:voter_id/finality", handlers.VoteFinalityHandler(dbManager))
+			content.GET("/:id/score-updates", handlers.StreamScoreUpdatesHandler(contentMgr))
+		}
+
+		// Live score updates across every content item, for moderation UIs
+		// watching for anything crossing a consensus threshold.
+		api.GET("/score-updates", handlers.StreamScoreUpdatesHandler(contentMgr))
+
+		// Auth endpoints (login/logout/profile, pluggable local/jwt/oidc)
+		authRoutes := api.Group("/auth")
+		{
+			authRoutes.POST("/login", handlers.LoginHandler(authenticator, dbManager, userMgr))
+			authRoutes.POST("/register", handlers.RegisterHandler(authenticator, dbManager))
+			authRoutes.POST("/logout", middleware.RequireAuth(authenticator), handlers.LogoutHandler())
+			authRoutes.GET("/profile", middleware.RequireAuth(authenticator), handlers.ProfileHandler(dbManager))
 		}
 
 		// User endpoints
@@ -52,7 +226,7 @@ func main() {
 		{
 			user.GET("/:id", handlers.GetUserProfileHandler())
 			user.GET("/:id/reputation", handlers.GetUserReputationHandler())
-			user.POST("/:id/message", handlers.SendDirectMessageHandler(hub, dbManager))
+			user.POST("/:id/message", middleware.RateLimitMiddleware(rateLimiter, dbManager, "send_direct_message", decisionsSvc), handlers.SendDirectMessageHandler(hub, dbManager))
 		}
 
 		// Consensus endpoints
@@ -72,6 +246,70 @@ func main() {
 		{
 			analytics.GET("/trending", handlers.GetTrendingContentHandler())
 		}
+
+		// Bandit-driven experiment endpoints (FeedbackLoopOptimization/ABTesting)
+		experiments := api.Group("/experiments")
+		{
+			experiments.POST("/:name/assign", handlers.AssignArmHandler(dbManager))
+			experiments.POST("/:name/events", handlers.RecordExperimentEventHandler(dbManager))
+		}
+
+		// Event subscription endpoints (webhooks/outbound WebSocket delivery)
+		subscriptions := api.Group("/subscriptions", middleware.RequireAuth(authenticator))
+		{
+			subscriptions.POST("", handlers.CreateEventSubscriberHandler(dbManager))
+			subscriptions.GET("", handlers.ListEventSubscribersHandler(dbManager))
+			subscriptions.GET("/:id", handlers.GetEventSubscriberHandler(dbManager))
+			subscriptions.PUT("/:id", handlers.UpdateEventSubscriberHandler(dbManager))
+			subscriptions.DELETE("/:id", handlers.DeleteEventSubscriberHandler(dbManager))
+		}
+
+		// Admin endpoints
+		admin := api.Group("/admin", middleware.RequireAuth(authenticator))
+		{
+			admin.GET("/algorithm/enrollment", handlers.EnrollmentStatusHandler(cfg.TLS))
+		}
+
+		// Admin control-plane for user.Manager scores/reputation - overrides
+		// and recomputes a moderator triggers by hand, audited alongside the
+		// algorithm service's own metric log.
+		adminUsers := admin.Group("/users", middleware.RequireRole(auth.RoleModerator))
+		{
+			adminUsers.GET("/:id/scores", handlers.GetUserScoresHandler(userMgr))
+			adminUsers.PATCH("/:id/scores", handlers.PatchUserScoresHandler(userMgr))
+			adminUsers.PATCH("/:id/authenticity", handlers.PatchUserAuthenticityHandler(userMgr))
+			adminUsers.POST("/:id/recompute", handlers.RecomputeUserScoresHandler(userMgr))
+			adminUsers.GET("/:id/audit", handlers.UserScoreAuditHandler(userMgr))
+		}
+
+		// Federation endpoints (ActivityPub-style cross-instance propagation)
+		fed := api.Group("/federation")
+		{
+			fed.GET("/actor/:id", handlers.GetActorHandler())
+			fed.POST("/followers", handlers.AddFollowerHandler(dbManager))
+			fed.POST("/following", handlers.AddFollowingHandler(dbManager))
+			if federationInbox != nil {
+				fed.POST("/inbox/:actor_id", handlers.InboxHandler(federationInbox))
+			}
+		}
+
+		// Moderation endpoints (flagging, jury review, appeals)
+		mod := api.Group("/moderation", middleware.RequireAuth(authenticator))
+		{
+			mod.POST("/flag", handlers.FlagContentHandler(moderationSvc))
+			mod.POST("/juries/:id/vote", handlers.CastJuryVoteHandler(moderationSvc))
+			mod.POST("/juries/:id/appeal", handlers.AppealVerdictHandler(moderationSvc))
+		}
+
+		// Decision-feed endpoints (ban/captcha/throttle abuse mitigation)
+		api.GET("/captcha/challenge", handlers.CaptchaChallengeHandler())
+
+		decisionsGroup := api.Group("/decisions", middleware.RequireAuth(authenticator))
+		{
+			decisionsGroup.POST("", handlers.CreateDecisionHandler(decisionsSvc))
+			decisionsGroup.GET("", handlers.ListDecisionsHandler(decisionsSvc))
+			decisionsGroup.DELETE("/:id", handlers.DeleteDecisionHandler(decisionsSvc))
+		}
 	}
 
 	// Start server
@@ -79,3 +317,68 @@ func main() {
 		log.Fatal("Failed to start server:", err)
 	}
 }
+
+// redisURL builds the "redis://" URL ratelimit.NewBackend (and any other
+// redis.ParseURL-based constructor) expects from cfg's discrete fields.
+func redisURL(cfg config.RedisConfig) string {
+	if cfg.Password != "" {
+		return fmt.Sprintf("redis://:%s@%s/%d", cfg.Password, cfg.Address, cfg.DB)
+	}
+	return fmt.Sprintf("redis://%s/%d", cfg.Address, cfg.DB)
+}
+
+// startFederation starts the background Worker that drains store's
+// delivery outbox and the Processor that applies verified inbound
+// activities to repo, and returns the HTTPInbox the /federation/inbox
+// route pushes onto.
+func startFederation(cfg *config.Config, store federation.Store, repo db.Repository) *federation.HTTPInbox {
+	worker := federation.NewWorker(store, cfg.Federation.PollInterval, cfg.Federation.BatchSize)
+	go worker.Run()
+
+	inbox := federation.NewHTTPInbox(cfg.Federation.InboxBuffer)
+	processor := federation.NewProcessor(inbox, store, repo)
+	go processor.Run(context.Background())
+
+	return inbox
+}
+
+// startHCS builds the Hedera client and topic set from cfg, reconciles
+// store against what HCS has already recorded, and starts the background
+// worker that drains store's outbox going forward.
+func startHCS(cfg *config.Config, store *sqlite.DBManager) error {
+	client := hedera.ClientForTestnet()
+
+	operatorID, err := hedera.AccountIDFromString(cfg.HCS.AccountID)
+	if err != nil {
+		return err
+	}
+	operatorKey, err := hedera.PrivateKeyFromString(cfg.HCS.PrivateKey)
+	if err != nil {
+		return err
+	}
+	client.SetOperator(operatorID, operatorKey)
+
+	topics := hcs.TopicSet{}
+	for recordType, topicIDStr := range map[hcs.RecordType]string{
+		hcs.RecordTypeContent:  cfg.HCS.TopicContent,
+		hcs.RecordTypeVote:     cfg.HCS.TopicVote,
+		hcs.RecordTypeEvidence: cfg.HCS.TopicEvidence,
+	} {
+		topicID, err := hedera.TopicIDFromString(topicIDStr)
+		if err != nil {
+			return err
+		}
+		topics[recordType] = topicID
+	}
+
+	publisher := hcs.NewHederaPublisher(client, topics)
+
+	if err := hcs.NewReconciler(store, publisher, cfg.HCS.NodeKey).Reconcile(); err != nil {
+		log.Printf("hcs: startup reconciliation reported divergences: %v", err)
+	}
+
+	worker := hcs.NewWorker(store, publisher, cfg.HCS.PollInterval, cfg.HCS.BatchSize)
+	go worker.Run()
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// multiHandler fans a record out to every handler in the slice, letting
+// NewLogger write JSON to the file sink and color text to the console
+// from the same *slog.Logger.
+type multiHandler []slog.Handler
+
+func (m multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	for _, h := range m {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := make(multiHandler, len(m))
+	for i, h := range m {
+		next[i] = h.WithAttrs(attrs)
+	}
+	return next
+}
+
+func (m multiHandler) WithGroup(name string) slog.Handler {
+	next := make(multiHandler, len(m))
+	for i, h := range m {
+		next[i] = h.WithGroup(name)
+	}
+	return next
+}
+
+// dedupeHandler suppresses a record that is identical (same level,
+// message, and attributes) to the immediately preceding one within
+// window, chaining everything else through to next unchanged. It exists
+// for hot loops - the metrics/cache subsystems can log the same miss on
+// every tick - without dropping a genuinely new occurrence.
+type dedupeHandler struct {
+	next   slog.Handler
+	window time.Duration
+
+	mu       sync.Mutex
+	lastKey  string
+	lastSeen time.Time
+}
+
+func newDedupeHandler(next slog.Handler, window time.Duration) *dedupeHandler {
+	return &dedupeHandler{next: next, window: window}
+}
+
+func (h *dedupeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *dedupeHandler) Handle(ctx context.Context, r slog.Record) error {
+	key := recordKey(r)
+
+	h.mu.Lock()
+	suppress := key == h.lastKey && time.Since(h.lastSeen) < h.window
+	h.lastKey, h.lastSeen = key, time.Now()
+	h.mu.Unlock()
+
+	if suppress {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *dedupeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &dedupeHandler{next: h.next.WithAttrs(attrs), window: h.window}
+}
+
+func (h *dedupeHandler) WithGroup(name string) slog.Handler {
+	return &dedupeHandler{next: h.next.WithGroup(name), window: h.window}
+}
+
+func recordKey(r slog.Record) string {
+	var b strings.Builder
+	b.WriteString(r.Level.String())
+	b.WriteByte('|')
+	b.WriteString(r.Message)
+	r.Attrs(func(a slog.Attr) bool {
+		fmt.Fprintf(&b, "|%s=%v", a.Key, a.Value.Any())
+		return true
+	})
+	return b.String()
+}
+
+// colorHandler renders records as single colorized lines via ColorizeLog,
+// the console format the old hand-rolled Logger produced, keyed off the
+// "category" attribute every Logger method attaches.
+type colorHandler struct {
+	w    io.Writer
+	opts *slog.HandlerOptions
+	mu   *sync.Mutex
+
+	attrs []slog.Attr
+}
+
+func newColorHandler(w io.Writer, opts *slog.HandlerOptions) *colorHandler {
+	return &colorHandler{w: w, opts: opts, mu: &sync.Mutex{}}
+}
+
+func (h *colorHandler) Enabled(_ context.Context, level slog.Level) bool {
+	min := slog.LevelInfo
+	if h.opts != nil && h.opts.Level != nil {
+		min = h.opts.Level.Level()
+	}
+	return level >= min
+}
+
+func (h *colorHandler) Handle(_ context.Context, r slog.Record) error {
+	category := "info"
+	var fields []string
+
+	collect := func(a slog.Attr) bool {
+		if a.Key == "category" {
+			category = a.Value.String()
+			return true
+		}
+		fields = append(fields, fmt.Sprintf("%s=%v", a.Key, a.Value.Any()))
+		return true
+	}
+	for _, a := range h.attrs {
+		collect(a)
+	}
+	r.Attrs(collect)
+
+	line := fmt.Sprintf("[%s] [%s] %s", r.Time.Format("2006-01-02 15:04:05"), r.Level, r.Message)
+	if len(fields) > 0 {
+		line += " " + strings.Join(fields, " ")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := fmt.Fprintln(h.w, ColorizeLog(category, line))
+	return err
+}
+
+func (h *colorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+	next.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &next
+}
+
+func (h *colorHandler) WithGroup(_ string) slog.Handler {
+	// Groups have no meaning in this flat, single-line console format.
+	return h
+}
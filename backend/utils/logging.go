@@ -1,13 +1,17 @@
 package utils
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"os"
-	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
-// LogLevel represents the severity of a log message
+// LogLevel names this package's four severities; Level translates it to
+// the slog.Level NewLogger configures its handlers with.
 type LogLevel string
 
 const (
@@ -17,113 +21,198 @@ const (
 	ERROR   LogLevel = "ERROR"
 )
 
-// Logger handles all logging operations
+// Level returns l as a slog.Level, defaulting to LevelInfo for an unknown
+// or zero-value LogLevel.
+func (l LogLevel) Level() slog.Level {
+	switch l {
+	case DEBUG:
+		return slog.LevelDebug
+	case WARNING:
+		return slog.LevelWarn
+	case ERROR:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// dedupeWindow bounds how long the deduplicating handler suppresses
+// repeats of an identical record - long enough to flatten a hot loop's
+// spam (the cache/metrics subsystems logging the same miss every tick)
+// without hiding a genuinely new occurrence for more than a beat.
+const dedupeWindow = 2 * time.Second
+
+// Logger wraps a *slog.Logger with the category helpers the rest of the
+// codebase calls instead of formatting ad hoc strings. NewLogger fans
+// records out to a JSON file sink and, when verbose, a colorized console
+// sink, both deduplicated so a hot loop doesn't flood either one.
 type Logger struct {
-	mu        sync.Mutex
-	logFile   *os.File
-	logLevel  LogLevel
-	isVerbose bool
+	slog *slog.Logger
+	file *os.File
 }
 
-// NewLogger creates a new logger instance
+// NewLogger opens logPath (appending, creating if needed) and returns a
+// Logger that writes JSON records to it at or above level. When verbose is
+// true, a colorized text handler also writes to stdout.
 func NewLogger(logPath string, level LogLevel, verbose bool) (*Logger, error) {
 	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open log file: %v", err)
 	}
 
-	return &Logger{
-		logFile:   file,
-		logLevel:  level,
-		isVerbose: verbose,
-	}, nil
-}
+	opts := &slog.HandlerOptions{Level: level.Level()}
+	var handler slog.Handler = newDedupeHandler(slog.NewJSONHandler(file, opts), dedupeWindow)
+	if verbose {
+		handler = multiHandler{handler, newDedupeHandler(newColorHandler(os.Stdout, opts), dedupeWindow)}
+	}
 
-// formatLog creates a formatted log message
-func (l *Logger) formatLog(level LogLevel, category, message string, data ...interface{}) string {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	formattedMsg := fmt.Sprintf(message, data...)
-	return fmt.Sprintf("[%s] [%s] [%s] %s", timestamp, level, category, formattedMsg)
+	return &Logger{slog: slog.New(handler), file: file}, nil
 }
 
-// log writes a log message to both file and console
-func (l *Logger) log(level LogLevel, category, message string, data ...interface{}) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	logMsg := l.formatLog(level, category, message, data...)
+// ctxKey namespaces the context values WithContext reads so they don't
+// collide with keys set by unrelated packages.
+type ctxKey string
 
-	// Write to file (always plain text)
-	fmt.Fprintln(l.logFile, logMsg)
+const (
+	traceIDKey ctxKey = "trace_id"
+	userIDKey  ctxKey = "user_id"
+)
 
-	// Write to console (colorized)
-	if l.isVerbose {
-		fmt.Println(ColorizeLog(category, logMsg))
+// ContextWithTraceID returns a copy of ctx carrying traceID for a later
+// WithContext call to pick up.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+// ContextWithUserID returns a copy of ctx carrying userID for a later
+// WithContext call to pick up.
+func ContextWithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// WithContext returns a Logger whose records carry ctx's trace_id/user_id
+// (as set by ContextWithTraceID/ContextWithUserID), if present, so every
+// log line from a request-scoped Logger is already filterable by request
+// without every call site repeating the IDs. When ctx carries a live
+// OpenTelemetry span (e.g. one observability.Middleware started), its
+// trace_id/span_id take precedence over ContextWithTraceID's, so a log
+// line always correlates with the trace a reader is looking at.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	var attrs []any
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+		attrs = append(attrs, slog.String("span_id", sc.SpanID().String()))
+	} else if traceID, ok := ctx.Value(traceIDKey).(string); ok && traceID != "" {
+		attrs = append(attrs, slog.String("trace_id", traceID))
+	}
+	if userID, ok := ctx.Value(userIDKey).(string); ok && userID != "" {
+		attrs = append(attrs, slog.String("user_id", userID))
+	}
+	if len(attrs) == 0 {
+		return l
 	}
+	return &Logger{slog: l.slog.With(attrs...), file: l.file}
 }
 
-// User activity logging
-func (l *Logger) UserAction(userID, action string, data ...interface{}) {
-	l.log(INFO, "user", fmt.Sprintf("User %s %s", userID, action), data...)
+// logAttrs emits one record at level under category, prefixing attrs with
+// a category attribute so every handler can key off it.
+func (l *Logger) logAttrs(level slog.Level, category, message string, attrs []slog.Attr) {
+	all := make([]slog.Attr, 0, len(attrs)+1)
+	all = append(all, slog.String("category", category))
+	all = append(all, attrs...)
+	l.slog.LogAttrs(context.Background(), level, message, all...)
 }
 
-// Content activity logging
-func (l *Logger) ContentAction(contentID, action string, data ...interface{}) {
-	l.log(INFO, "content", fmt.Sprintf("Content %s %s", contentID, action), data...)
+// UserAction logs userID performing action, e.g. "login" or "logout".
+func (l *Logger) UserAction(userID, action string, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelInfo, "user", "user action", append([]slog.Attr{
+		slog.String("user_id", userID),
+		slog.String("action", action),
+	}, attrs...))
 }
 
-// Vote activity logging
-func (l *Logger) VoteAction(userID, contentID, voteType string, data ...interface{}) {
-	l.log(INFO, "vote", fmt.Sprintf("User %s voted %s on content %s", userID, voteType, contentID), data...)
+// ContentAction logs action taken on contentID, e.g. "created" or "flagged".
+func (l *Logger) ContentAction(contentID, action string, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelInfo, "content", "content action", append([]slog.Attr{
+		slog.String("content_id", contentID),
+		slog.String("action", action),
+	}, attrs...))
 }
 
-// Evidence activity logging
-func (l *Logger) EvidenceAction(evidenceID, action string, data ...interface{}) {
-	l.log(INFO, "evidence", fmt.Sprintf("Evidence %s %s", evidenceID, action), data...)
+// VoteAction logs userID casting a voteType vote on contentID.
+func (l *Logger) VoteAction(userID, contentID, voteType string, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelInfo, "vote", "vote recorded", append([]slog.Attr{
+		slog.String("user_id", userID),
+		slog.String("content_id", contentID),
+		slog.String("vote_type", voteType),
+	}, attrs...))
 }
 
-// System logging
-func (l *Logger) System(component, message string, data ...interface{}) {
-	l.log(INFO, "system", fmt.Sprintf("[%s] %s", component, message), data...)
+// EvidenceAction logs action taken on evidenceID, e.g. "submitted" or "verified".
+func (l *Logger) EvidenceAction(evidenceID, action string, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelInfo, "evidence", "evidence action", append([]slog.Attr{
+		slog.String("evidence_id", evidenceID),
+		slog.String("action", action),
+	}, attrs...))
 }
 
-// API logging
-func (l *Logger) API(method, path, status string, duration time.Duration, data ...interface{}) {
-	l.log(INFO, "api", fmt.Sprintf("%s %s [%s] %v", method, path, status, duration), data...)
+// System logs a message from component, e.g. a subsystem starting up.
+func (l *Logger) System(component, message string, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelInfo, "system", message, append([]slog.Attr{
+		slog.String("component", component),
+	}, attrs...))
 }
 
-// Database logging
-func (l *Logger) Database(operation, details string, duration time.Duration, data ...interface{}) {
-	l.log(DEBUG, "db", fmt.Sprintf("%s: %s (%v)", operation, details, duration), data...)
+// API logs one HTTP request's method, path, status, and latency.
+func (l *Logger) API(method, path, status string, duration time.Duration, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelInfo, "api", "http request", append([]slog.Attr{
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.String("status", status),
+		slog.Duration("latency", duration),
+	}, attrs...))
 }
 
-// Cache logging
-func (l *Logger) Cache(operation, key string, hit bool, data ...interface{}) {
-	status := "MISS"
-	if hit {
-		status = "HIT"
-	}
-	l.log(DEBUG, "cache", fmt.Sprintf("%s %s: %s", operation, key, status), data...)
+// Database logs one database operation's details and latency at debug
+// level - too frequent for INFO in production, useful when debugging.
+func (l *Logger) Database(operation, details string, duration time.Duration, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelDebug, "db", details, append([]slog.Attr{
+		slog.String("operation", operation),
+		slog.Duration("latency", duration),
+	}, attrs...))
 }
 
-// Error logging
-func (l *Logger) Error(component string, err error, data ...interface{}) {
-	l.log(ERROR, "error", fmt.Sprintf("[%s] %v", component, err), data...)
+// Cache logs a cache operation's hit/miss outcome for key.
+func (l *Logger) Cache(operation, key string, hit bool, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelDebug, "cache", "cache operation", append([]slog.Attr{
+		slog.String("operation", operation),
+		slog.String("key", key),
+		slog.Bool("hit", hit),
+	}, attrs...))
 }
 
-// Warning logging
-func (l *Logger) Warning(component string, message string, data ...interface{}) {
-	l.log(WARNING, "warning", fmt.Sprintf("[%s] %s", component, message), data...)
+// Error logs err from component.
+func (l *Logger) Error(component string, err error, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelError, "error", err.Error(), append([]slog.Attr{
+		slog.String("component", component),
+	}, attrs...))
 }
 
-// Debug logging
-func (l *Logger) Debug(component string, message string, data ...interface{}) {
-	if l.logLevel == DEBUG {
-		l.log(DEBUG, "debug", fmt.Sprintf("[%s] %s", component, message), data...)
-	}
+// Warning logs message from component at warning level.
+func (l *Logger) Warning(component, message string, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelWarn, "warning", message, append([]slog.Attr{
+		slog.String("component", component),
+	}, attrs...))
+}
+
+// Debug logs message from component at debug level.
+func (l *Logger) Debug(component, message string, attrs ...slog.Attr) {
+	l.logAttrs(slog.LevelDebug, "debug", message, append([]slog.Attr{
+		slog.String("component", component),
+	}, attrs...))
 }
 
-// Close closes the log file
+// Close closes the log file.
 func (l *Logger) Close() error {
-	return l.logFile.Close()
+	return l.file.Close()
 }
@@ -1,161 +0,0 @@
-package api
-
-import (
-	"encoding/json"
-	"log"
-	"sync"
-
-	"github.com/gin-gonic/gin"
-	"github.com/gorilla/websocket"
-)
-
-// WebSocketHub maintains active connections and broadcasts messages
-type WebSocketHub struct {
-	clients    map[*WebSocketClient]bool
-	broadcast  chan []byte
-	register   chan *WebSocketClient
-	unregister chan *WebSocketClient
-	mu         sync.RWMutex
-}
-
-// WebSocketClient represents a connected client
-type WebSocketClient struct {
-	hub  *WebSocketHub
-	conn *websocket.Conn
-	send chan []byte
-}
-
-// NewWebSocketHub creates a new WebSocket hub
-func NewWebSocketHub() *WebSocketHub {
-	return &WebSocketHub{
-		clients:    make(map[*WebSocketClient]bool),
-		broadcast:  make(chan []byte),
-		register:   make(chan *WebSocketClient),
-		unregister: make(chan *WebSocketClient),
-	}
-}
-
-// Run starts the WebSocket hub
-func (h *WebSocketHub) Run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.mu.Lock()
-			h.clients[client] = true
-			h.mu.Unlock()
-
-		case client := <-h.unregister:
-			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
-			}
-			h.mu.Unlock()
-
-		case message := <-h.broadcast:
-			h.mu.RLock()
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
-				}
-			}
-			h.mu.RUnlock()
-		}
-	}
-}
-
-// BroadcastUpdate sends an update to all connected clients
-func (h *WebSocketHub) BroadcastUpdate(updateType string, data interface{}) {
-	update := struct {
-		Type string      `json:"type"`
-		Data interface{} `json:"data"`
-	}{
-		Type: updateType,
-		Data: data,
-	}
-
-	message, err := json.Marshal(update)
-	if err != nil {
-		log.Printf("Error marshaling update: %v", err)
-		return
-	}
-
-	h.broadcast <- message
-}
-
-// HandleWebSocket handles WebSocket connections
-func (h *WebSocketHub) HandleWebSocket(c *gin.Context) {
-	upgrader := websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		CheckOrigin: func(r *gin.Request) bool {
-			return true // TODO: Implement proper origin checking
-		},
-	}
-
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		log.Printf("Error upgrading connection: %v", err)
-		return
-	}
-
-	client := &WebSocketClient{
-		hub:  h,
-		conn: conn,
-		send: make(chan []byte, 256),
-	}
-
-	client.hub.register <- client
-
-	go client.writePump()
-	go client.readPump()
-}
-
-// readPump pumps messages from the WebSocket connection to the hub
-func (c *WebSocketClient) readPump() {
-	defer func() {
-		c.hub.unregister <- c
-		c.conn.Close()
-	}()
-
-	for {
-		_, _, err := c.conn.ReadMessage()
-		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-				log.Printf("Error reading message: %v", err)
-			}
-			break
-		}
-		// Handle incoming messages if needed
-	}
-}
-
-// writePump pumps messages from the hub to the WebSocket connection
-func (c *WebSocketClient) writePump() {
-	defer func() {
-		c.conn.Close()
-	}()
-
-	for {
-		select {
-		case message, ok := <-c.send:
-			if !ok {
-				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
-				return
-			}
-
-			w, err := c.conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return
-			}
-			w.Write(message)
-
-			if err := w.Close(); err != nil {
-				return
-			}
-		}
-	}
-}